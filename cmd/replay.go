@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/born1337/hyperliquid-terminal/internal/app"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/record"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var replaySpeed string
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay a session recorded with --record",
+	Long:  "Replay a session recorded with --record. WebSocket messages are fed through the same handlers as a live session and REST requests are answered from the recording, so the UI reproduces what was on screen.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		speed, err := record.ParseSpeed(replaySpeed)
+		if err != nil {
+			return err
+		}
+		player, err := record.Open(args[0], speed)
+		if err != nil {
+			return err
+		}
+
+		meta := player.Meta
+		cfg := config.New(meta.Address, meta.Testnet, meta.Vault)
+		cfg.WalletName = meta.WalletName
+		cfg.SortPrefs, _ = config.LoadSortPrefs()
+		cfg.Watchlist, _ = config.LoadWatchlist()
+
+		p := tea.NewProgram(app.NewReplayModel(cfg, player), tea.WithAltScreen())
+		_, err = p.Run()
+		return err
+	},
+}
+
+func init() {
+	replayCmd.Flags().StringVar(&replaySpeed, "speed", "1x", "Playback speed multiplier, e.g. 10x")
+	rootCmd.AddCommand(replayCmd)
+}
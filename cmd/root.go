@@ -4,16 +4,25 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
+	"github.com/born1337/hyperliquid-terminal/internal/anomaly"
 	"github.com/born1337/hyperliquid-terminal/internal/app"
 	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/fakehl"
+	"github.com/born1337/hyperliquid-terminal/internal/record"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
 var (
-	testnet bool
-	vault   bool
+	testnet  bool
+	vault    bool
+	demo     bool
+	scenario string
+	recordTo string
+	keepMids bool
+	scan     = config.ScanThresholds(anomaly.DefaultThresholds)
 )
 
 var rootCmd = &cobra.Command{
@@ -22,6 +31,10 @@ var rootCmd = &cobra.Command{
 	Long:  "Real-time terminal dashboard for monitoring Hyperliquid positions, orders, fills, funding, portfolio, and vaults.\n\nRun with an address argument or configure wallets in ~/.config/hltui/wallets.json",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if demo {
+			return runDemo()
+		}
+
 		var cfg *config.Config
 
 		// Try loading wallets from config
@@ -52,17 +65,57 @@ var rootCmd = &cobra.Command{
 			cfg = config.NewWithWallets(wallets, 0, testnet, vault)
 		}
 
-		m := app.NewModel(cfg)
-
-		p := tea.NewProgram(m, tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			return err
-		}
-		return nil
+		return runTUI(cfg)
 	},
 	Version: config.Version,
 }
 
+func runTUI(cfg *config.Config) error {
+	// Unreadable files just mean the default sort orders and no watchlist
+	cfg.SortPrefs, _ = config.LoadSortPrefs()
+	cfg.Watchlist, _ = config.LoadWatchlist()
+	cfg.KeepMids = keepMids
+	cfg.Scan = &scan
+	m := app.NewModel(cfg)
+
+	if recordTo != "" {
+		w, err := record.Create(recordTo, record.Meta{
+			Version:    config.Version,
+			Address:    cfg.Address,
+			WalletName: cfg.WalletName,
+			Testnet:    cfg.IsTestnet,
+			Vault:      cfg.IsVault,
+		})
+		if err != nil {
+			return fmt.Errorf("record: %w", err)
+		}
+		defer w.Close()
+		m = m.WithRecorder(w)
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// runDemo starts the bundled fake Hyperliquid server on a local port and
+// points the TUI at it.
+func runDemo() error {
+	srv, err := fakehl.NewDemo(scenario)
+	if err != nil {
+		return err
+	}
+	baseURL, err := srv.Start("127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer srv.Close()
+
+	return runTUI(config.NewDemo(srv.User(), baseURL))
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -72,4 +125,12 @@ func Execute() {
 func init() {
 	rootCmd.Flags().BoolVarP(&testnet, "testnet", "t", false, "Use testnet API")
 	rootCmd.Flags().BoolVarP(&vault, "vault", "V", false, "Treat address as vault")
+	rootCmd.Flags().BoolVar(&demo, "demo", false, "Run against a built-in fake exchange (no network)")
+	rootCmd.Flags().StringVar(&recordTo, "record", "", "Record WebSocket messages and REST responses to `file` for replay")
+	rootCmd.Flags().BoolVar(&keepMids, "keep-mids", false, "Save the mid price history to ~/.config/hltui/mids.json and restore it on the next run")
+	rootCmd.Flags().Float64Var(&scan.OIJump, "scan-oi", scan.OIJump, "Scanner: flag open interest rising by this `%` over the window")
+	rootCmd.Flags().Float64Var(&scan.VolumeRatio, "scan-volume", scan.VolumeRatio, "Scanner: flag the hourly volume traded over the window at this `multiple` of its 24h average")
+	rootCmd.Flags().Float64Var(&scan.FundingSpike, "scan-funding", scan.FundingSpike, "Scanner: flag funding this many annualized `points` off its average over the window")
+	rootCmd.Flags().Float64Var(&scan.BasisWiden, "scan-basis", scan.BasisWiden, "Scanner: flag the mark/oracle basis widening by this many `points` over the window")
+	rootCmd.Flags().StringVar(&scenario, "scenario", "demo", "Demo scenario: "+strings.Join(fakehl.ScenarioNames(), ", "))
 }
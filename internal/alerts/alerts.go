@@ -0,0 +1,63 @@
+package alerts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+// maxAlerts caps how many alerts the log keeps.
+const maxAlerts = 200
+
+// Alert is a single notification raised by a data source (lockups, scanner, ...).
+type Alert struct {
+	Time    time.Time
+	Source  string
+	Message string
+}
+
+// Log is a bounded, concurrency-safe list of alerts, newest last.
+type Log struct {
+	mu    sync.Mutex
+	items []Alert
+}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Push records a new alert stamped with the current time, which in a replay
+// is the recording's.
+func (l *Log) Push(source, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items = append(l.items, Alert{Time: util.Now(), Source: source, Message: message})
+	if len(l.items) > maxAlerts {
+		l.items = l.items[len(l.items)-maxAlerts:]
+	}
+}
+
+// Latest returns the most recent alert, if any.
+func (l *Log) Latest() (Alert, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.items) == 0 {
+		return Alert{}, false
+	}
+	return l.items[len(l.items)-1], true
+}
+
+// Recent returns up to n of the newest alerts, newest first.
+func (l *Log) Recent(n int) []Alert {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n > len(l.items) {
+		n = len(l.items)
+	}
+	out := make([]Alert, n)
+	for i := 0; i < n; i++ {
+		out[i] = l.items[len(l.items)-1-i]
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+func TestLogLatestAndRecent(t *testing.T) {
+	l := NewLog()
+	if _, ok := l.Latest(); ok {
+		t.Error("Latest on empty log should return false")
+	}
+
+	l.Push("lockup", "first")
+	l.Push("lockup", "second")
+	l.Push("scanner", "third")
+
+	latest, ok := l.Latest()
+	if !ok || latest.Message != "third" {
+		t.Errorf("Latest = %+v, want third", latest)
+	}
+
+	recent := l.Recent(2)
+	if len(recent) != 2 {
+		t.Fatalf("Recent(2) len = %d, want 2", len(recent))
+	}
+	if recent[0].Message != "third" || recent[1].Message != "second" {
+		t.Errorf("Recent(2) = %q, %q; want third, second", recent[0].Message, recent[1].Message)
+	}
+
+	if got := len(l.Recent(10)); got != 3 {
+		t.Errorf("Recent(10) len = %d, want 3", got)
+	}
+}
+
+func TestLogCap(t *testing.T) {
+	l := NewLog()
+	for i := 0; i < maxAlerts+50; i++ {
+		l.Push("test", "x")
+	}
+	if got := len(l.Recent(maxAlerts * 2)); got != maxAlerts {
+		t.Errorf("len = %d, want %d", got, maxAlerts)
+	}
+}
+
+func TestPushUsesClock(t *testing.T) {
+	at := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	prev := util.Now
+	util.Now = func() time.Time { return at }
+	t.Cleanup(func() { util.Now = prev })
+
+	l := NewLog()
+	l.Push("lockup", "unlocked")
+	if a, _ := l.Latest(); !a.Time.Equal(at) {
+		t.Errorf("Time = %v, want %v", a.Time, at)
+	}
+}
@@ -0,0 +1,207 @@
+// Package anomaly flags markets whose asset contexts moved unusually over a
+// recent window: open interest piling in, volume trading far above its
+// daily rate, funding spiking and the mark drifting from the oracle. It reads
+// the histories the store samples from every metaAndAssetCtxs snapshot, so
+// nothing is flagged until the app has run for a window.
+package anomaly
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+// Signal is a kind of move the scanner looks for.
+type Signal int
+
+const (
+	OpenInterest Signal = iota
+	Volume
+	Funding
+	Basis
+)
+
+// Signals are every signal, in the order a market's flags are listed.
+var Signals = []Signal{OpenInterest, Volume, Funding, Basis}
+
+// Label names the signal in tables.
+func (s Signal) Label() string {
+	switch s {
+	case OpenInterest:
+		return "OI"
+	case Volume:
+		return "VOLUME"
+	case Funding:
+		return "FUNDING"
+	case Basis:
+		return "BASIS"
+	}
+	return "?"
+}
+
+// MinOpenInterest is the open interest, in USD, under which markets are not
+// scanned: a single trade swings their contexts.
+const MinOpenInterest = 1_000_000
+
+// Thresholds are how far a market has to move over the window to be
+// flagged. A threshold of zero turns its signal off.
+type Thresholds struct {
+	OIJump       float64 // open interest rise, %
+	VolumeRatio  float64 // recent hourly volume over its 24h average
+	FundingSpike float64 // annualized funding off its average, % points
+	BasisWiden   float64 // mark/oracle basis widening, % points
+}
+
+// DefaultThresholds are the thresholds without --scan-* flags.
+var DefaultThresholds = Thresholds{
+	OIJump:       10,
+	VolumeRatio:  1.5,
+	FundingSpike: 25,
+	BasisWiden:   0.2,
+}
+
+// Rules are what the scanner flags: moves past the thresholds over Window.
+type Rules struct {
+	Window time.Duration
+	Thresholds
+}
+
+// Flag is one signal a market tripped.
+type Flag struct {
+	Coin   string
+	Signal Signal
+	// Move is how far the signal moved, in its threshold's unit: % for open
+	// interest, a multiple for volume, annualized % points for funding and
+	// % points for basis.
+	Move float64
+	// Before and Now are the value at the window's start (open interest,
+	// basis, volume) or averaged across it (funding), and the latest one:
+	// open interest in coins, funding as an annualized % and basis as a %
+	// of the oracle price. Volume is in USD an hour: the 24h average at the
+	// window's start, and the rate traded across the window.
+	Before, Now float64
+	// Score is Move over its threshold, so flags of different signals
+	// rank together; 1 has just tripped.
+	Score float64
+}
+
+// Scan returns the flags s's histories trip under r, highest score first.
+func Scan(s *store.Store, r Rules) []Flag {
+	market := s.Market()
+	if market == nil {
+		return nil
+	}
+	var flags []Flag
+	for _, a := range market.Assets {
+		if a.OpenInterestUSD < MinOpenInterest {
+			continue
+		}
+		for _, sig := range Signals {
+			if f, ok := check(s, a.Name, sig, r); ok {
+				flags = append(flags, f)
+			}
+		}
+	}
+	slices.SortFunc(flags, func(a, b Flag) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Coin, b.Coin), cmp.Compare(a.Signal, b.Signal))
+	})
+	return flags
+}
+
+// check measures one signal of coin, reporting whether it tripped r.
+func check(s *store.Store, coin string, sig Signal, r Rules) (Flag, bool) {
+	var metric store.Metric
+	var threshold float64
+	switch sig {
+	case OpenInterest:
+		metric, threshold = store.MetricOpenInterest, r.OIJump
+	case Volume:
+		metric, threshold = store.MetricVolume, r.VolumeRatio
+	case Funding:
+		metric, threshold = store.MetricFunding, r.FundingSpike
+	case Basis:
+		metric, threshold = store.MetricBasis, r.BasisWiden
+	}
+	samples, ok := s.ContextSeries(coin, metric, r.Window)
+	if !ok || len(samples) < 2 || threshold <= 0 {
+		return Flag{}, false
+	}
+	first, last := samples[0].Value, samples[len(samples)-1].Value
+
+	f := Flag{Coin: coin, Signal: sig, Before: first, Now: last}
+	switch sig {
+	case OpenInterest:
+		if first <= 0 {
+			return Flag{}, false
+		}
+		f.Move = (last - first) / first * 100
+	case Volume:
+		f.Before, f.Now = first/24, volumeRate(samples)
+		if f.Before <= 0 {
+			return Flag{}, false
+		}
+		f.Move = f.Now / f.Before
+	case Funding:
+		f.Before, f.Now = util.AnnualizeFunding(mean(samples[:len(samples)-1])), util.AnnualizeFunding(last)
+		f.Move = f.Now - f.Before
+	case Basis:
+		f.Move = math.Abs(last) - math.Abs(first)
+	}
+	// Funding spikes either way; the others only count as they grow
+	move := f.Move
+	if sig == Funding {
+		move = math.Abs(move)
+	}
+	if move < threshold {
+		return Flag{}, false
+	}
+	f.Score = move / threshold
+	return f, true
+}
+
+func mean(samples []store.Sample) float64 {
+	var sum float64
+	for _, x := range samples {
+		sum += x.Value
+	}
+	return sum / float64(len(samples))
+}
+
+// volumeRate estimates the USD an hour traded across samples of the rolling
+// 24h volume. Between two samples the figure gains what traded and loses
+// what traded a day earlier; the part that fell out of the day is taken to
+// have traded at the day's average rate.
+func volumeRate(samples []store.Sample) float64 {
+	var traded float64
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		elapsed := float64(cur.Time-prev.Time) / float64(time.Hour.Milliseconds())
+		traded += cur.Value - prev.Value + prev.Value*elapsed/24
+	}
+	hours := float64(samples[len(samples)-1].Time-samples[0].Time) / float64(time.Hour.Milliseconds())
+	if hours <= 0 {
+		return 0
+	}
+	return max(traded/hours, 0)
+}
+
+// Describe says what tripped the flag, for an alert.
+func (f Flag) Describe(window time.Duration) string {
+	span := util.FormatWindow(window)
+	switch f.Signal {
+	case OpenInterest:
+		return fmt.Sprintf("%s open interest %+.1f%% in %s", f.Coin, f.Move, span)
+	case Volume:
+		return fmt.Sprintf("%s volume %.2fx its 24h rate over %s", f.Coin, f.Move, span)
+	case Funding:
+		return fmt.Sprintf("%s funding %.1f%% APR, %+.1f pts on its %s average", f.Coin, f.Now, f.Move, span)
+	case Basis:
+		return fmt.Sprintf("%s basis %+.3f%%, widened %.3f pts in %s", f.Coin, f.Now, f.Move, span)
+	}
+	return f.Coin
+}
@@ -0,0 +1,102 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+// ctx is one market's asset context as plain numbers.
+type ctx struct {
+	mark, oracle, oi, volume, funding float64
+}
+
+func snapshot(ctxs map[string]ctx) *api.MetaAndAssetCtxs {
+	meta := &api.MetaAndAssetCtxs{}
+	for _, coin := range []string{"BTC", "ETH", "TINY"} {
+		c := ctxs[coin]
+		meta.Meta.Universe = append(meta.Meta.Universe, api.AssetMeta{Name: coin})
+		meta.AssetCtxs = append(meta.AssetCtxs, api.AssetCtx{
+			MarkPx:       decimal.NewFromFloat(c.mark),
+			OraclePx:     decimal.NewFromFloat(c.oracle),
+			OpenInterest: decimal.NewFromFloat(c.oi),
+			DayNtlVlm:    decimal.NewFromFloat(c.volume),
+			Funding:      decimal.NewFromFloat(c.funding),
+		})
+	}
+	return meta
+}
+
+func TestScan(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	prev := util.Now
+	util.Now = func() time.Time { return now }
+	t.Cleanup(func() { util.Now = prev })
+
+	calm := map[string]ctx{
+		"BTC":  {90000, 90000, 100, 1e9, 0.0000125},
+		"ETH":  {3000, 3000, 10000, 5e8, 0.0000125},
+		"TINY": {1, 1, 1000, 1e4, 0.0000125}, // $1K of open interest
+	}
+	s := store.New()
+	s.SetMetaAndAssetCtxs(snapshot(calm))
+	rules := Rules{Window: time.Hour, Thresholds: DefaultThresholds}
+	if flags := Scan(s, rules); len(flags) != 0 {
+		t.Fatalf("flags before the window is covered: %v", flags)
+	}
+
+	now = now.Add(30 * time.Minute)
+	s.SetMetaAndAssetCtxs(snapshot(calm))
+	now = now.Add(30 * time.Minute)
+	s.SetMetaAndAssetCtxs(snapshot(map[string]ctx{
+		"BTC":  {90450, 90000, 115, 2e9, 0.0001},      // OI +15%, volume 25x its daily rate, funding spike, basis 0.5%
+		"ETH":  {2999, 3000, 10500, 5.05e8, -0.00001}, // under every threshold
+		"TINY": {1, 1, 5000, 1e6, 0.001},              // too small to scan
+	}))
+
+	flags := Scan(s, rules)
+	got := map[Signal]Flag{}
+	for _, f := range flags {
+		if f.Coin != "BTC" {
+			t.Errorf("unexpected flag %+v", f)
+		}
+		got[f.Signal] = f
+	}
+	if len(got) != 4 {
+		t.Fatalf("flags = %+v, want all four BTC signals", flags)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+	if f := got[OpenInterest]; !near(f.Move, 15) || !near(f.Score, 1.5) {
+		t.Errorf("OI flag = %+v", f)
+	}
+	// 1e9 traded in the second half hour on top of 1e9/24 an hour rolling
+	// out of the day, against 1e9/24 an hour before
+	if f := got[Volume]; !near(f.Move, 25) || !near(f.Before, 1e9/24) || !near(f.Now, 1e9+1e9/24) {
+		t.Errorf("volume flag = %+v", f)
+	}
+	if f := got[Funding]; !near(f.Now, 87.6) || !near(f.Move, 87.6-10.95) {
+		t.Errorf("funding flag = %+v", f)
+	}
+	if f := got[Basis]; !near(f.Move, 0.5) {
+		t.Errorf("basis flag = %+v", f)
+	}
+	for i := 1; i < len(flags); i++ {
+		if flags[i].Score > flags[i-1].Score {
+			t.Errorf("flags not ranked by score: %+v", flags)
+		}
+	}
+
+	if d := got[OpenInterest].Describe(time.Hour); d != "BTC open interest +15.0% in 1h" {
+		t.Errorf("Describe = %q", d)
+	}
+
+	// A longer window isn't covered yet
+	if flags := Scan(s, Rules{Window: 4 * time.Hour, Thresholds: DefaultThresholds}); len(flags) != 0 {
+		t.Errorf("4h flags = %v", flags)
+	}
+}
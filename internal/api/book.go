@@ -0,0 +1,41 @@
+package api
+
+import "context"
+
+func (c *Client) GetL2Book(ctx context.Context, coin string) (*L2Book, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "l2Book",
+		"coin": coin,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var book L2Book
+	if err := decode("l2Book", body, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// GetCandleSnapshot returns coin's candles of the given interval ("1m",
+// "15m", "1h", "1d", ...) between startTime and endTime in unix ms, oldest
+// first. Only the most recent 5000 candles are available.
+func (c *Client) GetCandleSnapshot(ctx context.Context, coin, interval string, startTime, endTime int64) ([]Candle, error) {
+	body, err := c.post(ctx, map[string]interface{}{
+		"type": "candleSnapshot",
+		"req": map[string]interface{}{
+			"coin":      coin,
+			"interval":  interval,
+			"startTime": startTime,
+			"endTime":   endTime,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var candles []Candle
+	if err := decode("candleSnapshot", body, &candles); err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
@@ -1,9 +1,9 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetClearinghouseState(user string) (*ClearinghouseState, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetClearinghouseState(ctx context.Context, user string) (*ClearinghouseState, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "clearinghouseState",
 		"user": user,
 	})
@@ -11,7 +11,7 @@ func (c *Client) GetClearinghouseState(user string) (*ClearinghouseState, error)
 		return nil, err
 	}
 	var state ClearinghouseState
-	if err := json.Unmarshal(body, &state); err != nil {
+	if err := decode("clearinghouseState", body, &state); err != nil {
 		return nil, err
 	}
 	return &state, nil
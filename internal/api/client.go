@@ -2,16 +2,31 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+// Requests that hit a rate limit or a server error are retried with
+// exponential backoff and full jitter.
+const (
+	maxRetries = 3
+	retryBase  = 500 * time.Millisecond
+	retryMax   = 10 * time.Second
+)
+
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	onResponse func(req []byte, status int, body []byte)
+	limiter    *Limiter // nil: unlimited
+	retryBase  time.Duration
 }
 
 func NewClient(infoURL string) *Client {
@@ -20,32 +35,126 @@ func NewClient(infoURL string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		limiter:   limiterFor(infoURL),
+		retryBase: retryBase,
 	}
 }
 
-func (c *Client) post(reqBody interface{}) ([]byte, error) {
+// NewClientWithTransport is NewClient with a custom round tripper, e.g. to
+// serve responses from a recording. Such clients aren't rate limited.
+func NewClientWithTransport(infoURL string, rt http.RoundTripper) *Client {
+	c := NewClient(infoURL)
+	c.httpClient.Transport = rt
+	c.limiter = nil
+	return c
+}
+
+// OnResponse registers fn to observe every raw request and response body,
+// including non-200 responses.
+func (c *Client) OnResponse(fn func(req []byte, status int, body []byte)) {
+	c.onResponse = fn
+}
+
+// Limiter returns the request weight budget this client draws from, shared
+// with every other client of the same host; nil if it isn't limited.
+func (c *Client) Limiter() *Limiter {
+	return c.limiter
+}
+
+// post sends an info request, waiting for its weight in the budget and
+// retrying rate limits and server errors.
+func (c *Client) post(ctx context.Context, reqBody interface{}) ([]byte, error) {
 	data, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
+	var req struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(data, &req)
+
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, RequestWeight(req.Type)); err != nil {
+				return nil, err
+			}
+		}
+		body, err := c.do(ctx, req.Type, data)
+		if err == nil {
+			if c.limiter != nil {
+				c.limiter.Charge(responseWeight(req.Type, body))
+			}
+			return body, nil
+		}
+
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || !httpErr.Temporary() || attempt == maxRetries {
+			return nil, err
+		}
+		if errors.Is(err, ErrRateLimited) && c.limiter != nil {
+			// Other requests would only be turned away too
+			c.limiter.Drain()
+		}
+		t := time.NewTimer(backoff(c.retryBase, attempt, httpErr.RetryAfter))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, typ string, data []byte) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", typ, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Post(c.baseURL, "application/json", bytes.NewReader(data))
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("http post: %w", err)
+		return nil, fmt.Errorf("%s: http post: %w", typ, err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read body: %w", err)
+		return nil, fmt.Errorf("%s: read body: %w", typ, err)
+	}
+	if c.onResponse != nil {
+		c.onResponse(data, resp.StatusCode, body)
 	}
 
-	if resp.StatusCode == 429 {
-		return nil, fmt.Errorf("rate limited")
+	if resp.StatusCode != http.StatusOK {
+		e := &HTTPError{Type: typ, Status: resp.StatusCode, Body: string(body)}
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			e.RetryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, e
 	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("http %d: %s", resp.StatusCode, string(body))
+	return body, nil
+}
+
+// backoff is how long to wait before retry attempt+1: what the server asked
+// for, or a random duration up to a cap growing exponentially from base.
+func backoff(base time.Duration, attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
 	}
+	ceiling := min(base<<attempt, retryMax)
+	return rand.N(ceiling) + time.Millisecond
+}
 
-	return body, nil
+// responseWeight is the list weight of a response body: the additional
+// weight for the number of entries it carries.
+func responseWeight(typ string, body []byte) int {
+	if listRequests[typ] == 0 {
+		return 0
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return 0
+	}
+	return ResponseWeight(typ, len(items))
 }
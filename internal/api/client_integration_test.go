@@ -22,19 +22,19 @@ func TestIntegrationClearinghouseState(t *testing.T) {
 	}
 
 	c := newTestClient()
-	state, err := c.GetClearinghouseState(testAddr)
+	state, err := c.GetClearinghouseState(t.Context(), testAddr)
 	if err != nil {
 		t.Fatalf("GetClearinghouseState error: %v", err)
 	}
 
-	if state.MarginSummary.AccountValue == "" {
-		t.Error("AccountValue is empty")
+	if state.MarginSummary.AccountValue.IsZero() {
+		t.Error("AccountValue is zero")
 	}
-	if state.MarginSummary.TotalNtlPos == "" {
-		t.Error("TotalNtlPos is empty")
+	if state.MarginSummary.TotalNtlPos.IsZero() {
+		t.Error("TotalNtlPos is zero")
 	}
-	if state.Withdrawable == "" {
-		t.Error("Withdrawable is empty")
+	if state.Withdrawable.IsZero() {
+		t.Error("Withdrawable is zero")
 	}
 
 	t.Logf("Account value: %s", state.MarginSummary.AccountValue)
@@ -45,8 +45,8 @@ func TestIntegrationClearinghouseState(t *testing.T) {
 		if pos.Coin == "" {
 			t.Error("First position Coin is empty")
 		}
-		if pos.Szi == "" {
-			t.Error("First position Szi is empty")
+		if pos.Szi.IsZero() {
+			t.Error("First position Szi is zero")
 		}
 		t.Logf("First position: %s size=%s pnl=%s", pos.Coin, pos.Szi, pos.UnrealizedPnl)
 
@@ -61,7 +61,7 @@ func TestIntegrationAllMids(t *testing.T) {
 	}
 
 	c := newTestClient()
-	mids, err := c.GetAllMids()
+	mids, err := c.GetAllMids(t.Context())
 	if err != nil {
 		t.Fatalf("GetAllMids error: %v", err)
 	}
@@ -87,7 +87,7 @@ func TestIntegrationMetaAndAssetCtxs(t *testing.T) {
 	}
 
 	c := newTestClient()
-	result, err := c.GetMetaAndAssetCtxs()
+	result, err := c.GetMetaAndAssetCtxs(t.Context())
 	if err != nil {
 		t.Fatalf("GetMetaAndAssetCtxs error: %v", err)
 	}
@@ -107,8 +107,8 @@ func TestIntegrationMetaAndAssetCtxs(t *testing.T) {
 	if result.Meta.Universe[0].Name != "BTC" {
 		t.Errorf("First asset = %q, want BTC", result.Meta.Universe[0].Name)
 	}
-	if result.AssetCtxs[0].Funding == "" {
-		t.Error("First AssetCtx Funding is empty")
+	if result.AssetCtxs[0].Funding.IsZero() {
+		t.Error("First AssetCtx Funding is zero")
 	}
 
 	t.Logf("Assets: %d, BTC funding=%s", len(result.Meta.Universe), result.AssetCtxs[0].Funding)
@@ -120,7 +120,7 @@ func TestIntegrationOpenOrders(t *testing.T) {
 	}
 
 	c := newTestClient()
-	orders, err := c.GetOpenOrders(testAddr)
+	orders, err := c.GetOpenOrders(t.Context(), testAddr)
 	if err != nil {
 		t.Fatalf("GetOpenOrders error: %v", err)
 	}
@@ -141,7 +141,7 @@ func TestIntegrationUserFills(t *testing.T) {
 	}
 
 	c := newTestClient()
-	fills, err := c.GetUserFills(testAddr)
+	fills, err := c.GetUserFills(t.Context(), testAddr)
 	if err != nil {
 		t.Fatalf("GetUserFills error: %v", err)
 	}
@@ -154,11 +154,11 @@ func TestIntegrationUserFills(t *testing.T) {
 	if fill.Coin == "" {
 		t.Error("Fill Coin is empty")
 	}
-	if fill.Px == "" {
-		t.Error("Fill Px is empty")
+	if fill.Px.IsZero() {
+		t.Error("Fill Px is zero")
 	}
-	if fill.Fee == "" {
-		t.Error("Fill Fee is empty")
+	if fill.Fee.IsZero() {
+		t.Error("Fill Fee is zero")
 	}
 	if fill.Time == 0 {
 		t.Error("Fill Time is zero")
@@ -174,7 +174,7 @@ func TestIntegrationUserFunding(t *testing.T) {
 
 	c := newTestClient()
 	weekAgo := time.Now().Add(-7 * 24 * time.Hour).UnixMilli()
-	payments, err := c.GetUserFunding(testAddr, weekAgo)
+	payments, err := c.GetUserFunding(t.Context(), testAddr, weekAgo)
 	if err != nil {
 		t.Fatalf("GetUserFunding error: %v", err)
 	}
@@ -188,11 +188,11 @@ func TestIntegrationUserFunding(t *testing.T) {
 	if fp.Coin == "" {
 		t.Error("FundingPayment Coin is empty (delta flattening likely broken)")
 	}
-	if fp.Usdc == "" {
-		t.Error("FundingPayment Usdc is empty (delta flattening likely broken)")
+	if fp.Usdc.IsZero() {
+		t.Error("FundingPayment Usdc is zero (delta flattening likely broken)")
 	}
-	if fp.FundingRate == "" {
-		t.Error("FundingPayment FundingRate is empty")
+	if fp.FundingRate.IsZero() {
+		t.Error("FundingPayment FundingRate is zero")
 	}
 	if fp.Time == 0 {
 		t.Error("FundingPayment Time is zero")
@@ -207,7 +207,7 @@ func TestIntegrationPortfolio(t *testing.T) {
 	}
 
 	c := newTestClient()
-	periods, err := c.GetPortfolio(testAddr)
+	periods, err := c.GetPortfolio(t.Context(), testAddr)
 	if err != nil {
 		t.Fatalf("GetPortfolio error: %v", err)
 	}
@@ -250,19 +250,19 @@ func TestIntegrationUserFees(t *testing.T) {
 	}
 
 	c := newTestClient()
-	fees, err := c.GetUserFees(testAddr)
+	fees, err := c.GetUserFees(t.Context(), testAddr)
 	if err != nil {
 		t.Fatalf("GetUserFees error: %v", err)
 	}
 
-	if fees.UserCrossRate == "" {
-		t.Error("UserCrossRate is empty")
+	if fees.UserCrossRate.IsZero() {
+		t.Error("UserCrossRate is zero")
 	}
-	if fees.UserAddRate == "" {
-		t.Error("UserAddRate is empty")
+	if fees.UserAddRate.IsZero() {
+		t.Error("UserAddRate is zero")
 	}
-	if fees.FeeSchedule.Cross == "" {
-		t.Error("FeeSchedule.Cross is empty")
+	if fees.FeeSchedule.Cross.IsZero() {
+		t.Error("FeeSchedule.Cross is zero")
 	}
 
 	t.Logf("Fees: taker=%s, maker=%s, schedule.cross=%s", fees.UserCrossRate, fees.UserAddRate, fees.FeeSchedule.Cross)
@@ -274,7 +274,7 @@ func TestIntegrationUserVaultEquities(t *testing.T) {
 	}
 
 	c := newTestClient()
-	equities, err := c.GetUserVaultEquities(testAddr)
+	equities, err := c.GetUserVaultEquities(t.Context(), testAddr)
 	if err != nil {
 		t.Fatalf("GetUserVaultEquities error: %v", err)
 	}
@@ -284,8 +284,8 @@ func TestIntegrationUserVaultEquities(t *testing.T) {
 		if ve.VaultAddress == "" {
 			t.Error("VaultAddress is empty")
 		}
-		if ve.Equity == "" {
-			t.Error("Equity is empty")
+		if ve.Equity.IsZero() {
+			t.Error("Equity is zero")
 		}
 		t.Logf("  vault=%s equity=%s", ve.VaultAddress[:10]+"...", ve.Equity)
 	}
@@ -0,0 +1,150 @@
+package api_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/fakehl"
+)
+
+// Offline counterparts of the integration tests, served by fakehl.
+
+func newFakeClient(t *testing.T) *api.Client {
+	t.Helper()
+	f, err := fakehl.LoadFixture("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := fakehl.New(f, fakehl.Scenario{}, 1)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return api.NewClient(ts.URL + "/info")
+}
+
+func TestClientAccountEndpoints(t *testing.T) {
+	c := newFakeClient(t)
+	addr := fakehl.DemoAddress
+
+	state, err := c.GetClearinghouseState(t.Context(), addr)
+	if err != nil {
+		t.Fatalf("GetClearinghouseState: %v", err)
+	}
+	if state.MarginSummary.AccountValue.IsZero() || len(state.AssetPositions) == 0 {
+		t.Errorf("clearinghouse state = %+v", state.MarginSummary)
+	}
+	for _, ap := range state.AssetPositions {
+		p := ap.Position
+		if (p.Leverage.Type == "isolated") != (p.LiquidationPx != nil) {
+			t.Errorf("%s: %s margin with liqPx %v", p.Coin, p.Leverage.Type, p.LiquidationPx)
+		}
+	}
+
+	orders, err := c.GetOpenOrders(t.Context(), addr)
+	if err != nil || len(orders) == 0 {
+		t.Errorf("GetOpenOrders = %d, %v", len(orders), err)
+	}
+
+	fills, err := c.GetUserFills(t.Context(), addr)
+	if err != nil || len(fills) == 0 {
+		t.Errorf("GetUserFills = %d, %v", len(fills), err)
+	}
+
+	funding, err := c.GetUserFunding(t.Context(), addr, time.Now().Add(-24*time.Hour).UnixMilli())
+	if err != nil || len(funding) == 0 || funding[0].Coin == "" {
+		t.Errorf("GetUserFunding = %d, %v", len(funding), err)
+	}
+
+	portfolio, err := c.GetPortfolio(t.Context(), addr)
+	if err != nil || len(portfolio) != 8 {
+		t.Errorf("GetPortfolio = %d periods, %v", len(portfolio), err)
+	}
+
+	fees, err := c.GetUserFees(t.Context(), addr)
+	if err != nil || fees.UserCrossRate.IsZero() {
+		t.Errorf("GetUserFees = %+v, %v", fees, err)
+	}
+
+	limit, err := c.GetUserRateLimit(t.Context(), addr)
+	if err != nil || limit.NRequestsCap <= 10_000 || limit.Remaining() <= 0 {
+		t.Errorf("GetUserRateLimit = %+v, %v", limit, err)
+	}
+}
+
+func TestClientMarketEndpoints(t *testing.T) {
+	c := newFakeClient(t)
+
+	mids, err := c.GetAllMids(t.Context())
+	if err != nil || mids["BTC"].IsZero() {
+		t.Errorf("GetAllMids = %v, %v", mids, err)
+	}
+
+	meta, err := c.GetMetaAndAssetCtxs(t.Context())
+	if err != nil {
+		t.Fatalf("GetMetaAndAssetCtxs: %v", err)
+	}
+	if len(meta.Meta.Universe) == 0 || len(meta.Meta.Universe) != len(meta.AssetCtxs) {
+		t.Errorf("universe %d, ctxs %d", len(meta.Meta.Universe), len(meta.AssetCtxs))
+	}
+
+	predicted, err := c.GetPredictedFundings(t.Context())
+	if err != nil || len(predicted) == 0 {
+		t.Fatalf("GetPredictedFundings = %d, %v", len(predicted), err)
+	}
+	if _, ok := predicted[0].Venue(api.VenueHyperliquid); !ok {
+		t.Error("predicted fundings missing HlPerp venue")
+	}
+
+	book, err := c.GetL2Book(t.Context(), "BTC")
+	if err != nil {
+		t.Fatalf("GetL2Book: %v", err)
+	}
+	if len(book.Bids()) == 0 || len(book.Asks()) == 0 || book.Bids()[0].Px.Cmp(book.Asks()[0].Px) >= 0 {
+		t.Errorf("book = %+v", book)
+	}
+
+	end := time.Now()
+	candles, err := c.GetCandleSnapshot(t.Context(), "BTC", "1h", end.Add(-24*time.Hour).UnixMilli(), end.UnixMilli())
+	if err != nil || len(candles) < 24 {
+		t.Fatalf("GetCandleSnapshot = %d, %v", len(candles), err)
+	}
+	if last := candles[len(candles)-1]; last.Interval != "1h" || last.High.Cmp(last.Low) < 0 || last.OpenTime < candles[0].OpenTime {
+		t.Errorf("last candle = %+v", last)
+	}
+}
+
+func TestClientVaultAndStakingEndpoints(t *testing.T) {
+	c := newFakeClient(t)
+	addr := fakehl.DemoAddress
+
+	equities, err := c.GetUserVaultEquities(t.Context(), addr)
+	if err != nil || len(equities) == 0 {
+		t.Fatalf("GetUserVaultEquities = %d, %v", len(equities), err)
+	}
+	details, err := c.GetVaultDetails(t.Context(), equities[0].VaultAddress, addr)
+	if err != nil {
+		t.Fatalf("GetVaultDetails: %v", err)
+	}
+	if details.FollowerState == nil || len(details.Portfolio) == 0 {
+		t.Errorf("vault details = %+v", details)
+	}
+
+	summaries, err := c.GetVaultSummaries(t.Context())
+	if err != nil || len(summaries) == 0 {
+		t.Errorf("GetVaultSummaries = %d, %v", len(summaries), err)
+	}
+
+	summary, err := c.GetDelegatorSummary(t.Context(), addr)
+	if err != nil || summary.Delegated.IsZero() {
+		t.Errorf("GetDelegatorSummary = %+v, %v", summary, err)
+	}
+	history, err := c.GetDelegatorHistory(t.Context(), addr)
+	if err != nil || len(history) == 0 || history[0].Delta.Kind() != "delegate" {
+		t.Errorf("GetDelegatorHistory = %+v, %v", history, err)
+	}
+	validators, err := c.GetValidatorSummaries(t.Context())
+	if err != nil || len(validators) == 0 {
+		t.Errorf("GetValidatorSummaries = %d, %v", len(validators), err)
+	}
+}
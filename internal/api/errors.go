@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrRateLimited matches, via errors.Is, an HTTPError for a 429 response.
+var ErrRateLimited = errors.New("rate limited")
+
+// HTTPError is a non-200 response to an info request.
+type HTTPError struct {
+	Type       string // info request type, e.g. "userFills"
+	Status     int
+	Body       string
+	RetryAfter time.Duration // from the Retry-After header; 0 if absent
+}
+
+func (e *HTTPError) Error() string {
+	if e.Status == http.StatusTooManyRequests {
+		return fmt.Sprintf("%s: rate limited", e.Type)
+	}
+	return fmt.Sprintf("%s: http %d: %s", e.Type, e.Status, e.Body)
+}
+
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrRateLimited && e.Status == http.StatusTooManyRequests
+}
+
+// Temporary reports whether the request is worth retrying: rate limits and
+// server errors.
+func (e *HTTPError) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// DecodeError is a response that didn't parse as the expected type.
+type DecodeError struct {
+	Type string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: decode response: %v", e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+func decode(typ string, body []byte, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{Type: typ, Err: err}
+	}
+	return nil
+}
@@ -1,9 +1,9 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetUserFees(user string) (*UserFees, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetUserFees(ctx context.Context, user string) (*UserFees, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "userFees",
 		"user": user,
 	})
@@ -11,7 +11,7 @@ func (c *Client) GetUserFees(user string) (*UserFees, error) {
 		return nil, err
 	}
 	var fees UserFees
-	if err := json.Unmarshal(body, &fees); err != nil {
+	if err := decode("userFees", body, &fees); err != nil {
 		return nil, err
 	}
 	return &fees, nil
@@ -1,9 +1,9 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetUserFills(user string) ([]Fill, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetUserFills(ctx context.Context, user string) ([]Fill, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "userFills",
 		"user": user,
 	})
@@ -11,14 +11,14 @@ func (c *Client) GetUserFills(user string) ([]Fill, error) {
 		return nil, err
 	}
 	var fills []Fill
-	if err := json.Unmarshal(body, &fills); err != nil {
+	if err := decode("userFills", body, &fills); err != nil {
 		return nil, err
 	}
 	return fills, nil
 }
 
-func (c *Client) GetUserFillsByTime(user string, startTime int64) ([]Fill, error) {
-	body, err := c.post(map[string]interface{}{
+func (c *Client) GetUserFillsByTime(ctx context.Context, user string, startTime int64) ([]Fill, error) {
+	body, err := c.post(ctx, map[string]interface{}{
 		"type":      "userFillsByTime",
 		"user":      user,
 		"startTime": startTime,
@@ -27,7 +27,7 @@ func (c *Client) GetUserFillsByTime(user string, startTime int64) ([]Fill, error
 		return nil, err
 	}
 	var fills []Fill
-	if err := json.Unmarshal(body, &fills); err != nil {
+	if err := decode("userFillsByTime", body, &fills); err != nil {
 		return nil, err
 	}
 	return fills, nil
@@ -1,9 +1,9 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetUserFunding(user string, startTime int64) ([]FundingPayment, error) {
-	body, err := c.post(map[string]interface{}{
+func (c *Client) GetUserFunding(ctx context.Context, user string, startTime int64) ([]FundingPayment, error) {
+	body, err := c.post(ctx, map[string]interface{}{
 		"type":      "userFunding",
 		"user":      user,
 		"startTime": startTime,
@@ -14,7 +14,7 @@ func (c *Client) GetUserFunding(user string, startTime int64) ([]FundingPayment,
 
 	// API returns [{time, hash, delta: {coin, usdc, szi, fundingRate}}, ...]
 	var rawPayments []FundingPaymentRaw
-	if err := json.Unmarshal(body, &rawPayments); err != nil {
+	if err := decode("userFunding", body, &rawPayments); err != nil {
 		return nil, err
 	}
 
@@ -32,8 +32,8 @@ func (c *Client) GetUserFunding(user string, startTime int64) ([]FundingPayment,
 	return payments, nil
 }
 
-func (c *Client) GetFundingHistory(coin string, startTime int64) ([]FundingHistoryEntry, error) {
-	body, err := c.post(map[string]interface{}{
+func (c *Client) GetFundingHistory(ctx context.Context, coin string, startTime int64) ([]FundingHistoryEntry, error) {
+	body, err := c.post(ctx, map[string]interface{}{
 		"type":      "fundingHistory",
 		"coin":      coin,
 		"startTime": startTime,
@@ -42,7 +42,7 @@ func (c *Client) GetFundingHistory(coin string, startTime int64) ([]FundingHisto
 		return nil, err
 	}
 	var entries []FundingHistoryEntry
-	if err := json.Unmarshal(body, &entries); err != nil {
+	if err := decode("fundingHistory", body, &entries); err != nil {
 		return nil, err
 	}
 	return entries, nil
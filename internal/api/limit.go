@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Hyperliquid allows each IP an aggregate request weight per minute across
+// all REST requests.
+const (
+	IPWeightPerMinute = 1200
+
+	// Weights of info requests
+	lightWeight    = 2
+	defaultWeight  = 20
+	userRoleWeight = 60
+)
+
+// lightRequests cost lightWeight; every other info request costs
+// defaultWeight.
+var lightRequests = map[string]bool{
+	"l2Book":                 true,
+	"allMids":                true,
+	"clearinghouseState":     true,
+	"orderStatus":            true,
+	"spotClearinghouseState": true,
+	"exchangeStatus":         true,
+}
+
+// listRequests cost an additional 1 per this many items returned.
+var listRequests = map[string]int{
+	"recentTrades":             20,
+	"historicalOrders":         20,
+	"userFills":                20,
+	"userFillsByTime":          20,
+	"fundingHistory":           20,
+	"userFunding":              20,
+	"nonUserFundingUpdates":    20,
+	"twapHistory":              20,
+	"userTwapSliceFills":       20,
+	"userTwapSliceFillsByTime": 20,
+	"delegatorHistory":         20,
+	"delegatorRewards":         20,
+	"validatorStats":           20,
+	"candleSnapshot":           60,
+}
+
+// RequestWeight is the weight an info request of type typ is charged up
+// front.
+func RequestWeight(typ string) int {
+	switch {
+	case lightRequests[typ]:
+		return lightWeight
+	case typ == "userRole":
+		return userRoleWeight
+	}
+	return defaultWeight
+}
+
+// ResponseWeight is the additional weight charged for a response of type
+// typ carrying items entries.
+func ResponseWeight(typ string, items int) int {
+	if per := listRequests[typ]; per > 0 {
+		return items / per
+	}
+	return 0
+}
+
+// Limiter is a token bucket of request weight refilling at a steady rate.
+// Wait blocks until enough weight is available; Charge bills weight that
+// only became known after the response, which may leave the bucket in debt.
+type Limiter struct {
+	mu       sync.Mutex
+	capacity float64
+	perSec   float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// NewLimiter returns a full bucket of capacity weight refilling at
+// perMinute.
+func NewLimiter(capacity, perMinute int) *Limiter {
+	return &Limiter{
+		capacity: float64(capacity),
+		perSec:   float64(perMinute) / 60,
+		tokens:   float64(capacity),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+func (l *Limiter) refill() {
+	now := l.now()
+	l.tokens = min(l.capacity, l.tokens+now.Sub(l.last).Seconds()*l.perSec)
+	l.last = now
+}
+
+// Wait takes weight from the bucket, blocking until it is available or ctx
+// is done.
+func (l *Limiter) Wait(ctx context.Context, weight int) error {
+	for {
+		l.mu.Lock()
+		l.refill()
+		if l.tokens >= float64(weight) {
+			l.tokens -= float64(weight)
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((float64(weight) - l.tokens) / l.perSec * float64(time.Second))
+		l.mu.Unlock()
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Charge takes weight without waiting.
+func (l *Limiter) Charge(weight int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	l.tokens -= float64(weight)
+}
+
+// Drain empties the bucket, after the server has said the budget is spent.
+func (l *Limiter) Drain() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	l.tokens = min(l.tokens, 0)
+}
+
+// Available returns the weight that can be spent right now and the
+// capacity.
+func (l *Limiter) Available() (available, capacity int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	return int(max(l.tokens, 0)), int(l.capacity)
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[string]*Limiter{}
+)
+
+// limiterFor returns the limiter shared by every client of the host in
+// infoURL: the budget is per IP, not per wallet.
+func limiterFor(infoURL string) *Limiter {
+	host := infoURL
+	if u, err := url.Parse(infoURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[host]
+	if !ok {
+		l = NewLimiter(IPWeightPerMinute, IPWeightPerMinute)
+		limiters[host] = l
+	}
+	return l
+}
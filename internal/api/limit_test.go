@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestWeights(t *testing.T) {
+	tests := []struct {
+		typ   string
+		items int
+		want  int
+	}{
+		{"allMids", 0, 2},
+		{"clearinghouseState", 0, 2},
+		{"metaAndAssetCtxs", 0, 20},
+		{"userRole", 0, 60},
+		{"userFills", 2000, 20 + 100},
+		{"userFunding", 19, 20},
+		{"candleSnapshot", 120, 20 + 2},
+	}
+	for _, tt := range tests {
+		if got := RequestWeight(tt.typ) + ResponseWeight(tt.typ, tt.items); got != tt.want {
+			t.Errorf("%s with %d items = %d, want %d", tt.typ, tt.items, got, tt.want)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(100, 60) // refills 1 per second
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	ctx := context.Background()
+	if err := l.Wait(ctx, 80); err != nil {
+		t.Fatal(err)
+	}
+	l.Charge(30) // a long list response puts the bucket in debt
+	if got, _ := l.Available(); got != 0 {
+		t.Errorf("available = %d, want 0 while in debt", got)
+	}
+	now = now.Add(30 * time.Second)
+	if got, _ := l.Available(); got != 20 {
+		t.Errorf("available = %d, want 20 after 30s", got)
+	}
+
+	// Not enough weight: Wait blocks until the context gives up
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, 50); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %v, want deadline exceeded", err)
+	}
+
+	l.Drain()
+	if got, _ := l.Available(); got != 0 {
+		t.Errorf("available = %d after Drain", got)
+	}
+}
+
+// flakyServer fails the first n requests with status, then answers ok.
+func flakyServer(t *testing.T, n int32, status int) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			http.Error(w, "try later", status)
+			return
+		}
+		w.Write([]byte(`{"BTC":"91000"}`))
+	}))
+	t.Cleanup(ts.Close)
+	c := NewClient(ts.URL)
+	c.retryBase = time.Millisecond
+	return c, &calls
+}
+
+func TestClientRetries(t *testing.T) {
+	c, calls := flakyServer(t, 2, http.StatusTooManyRequests)
+	mids, err := c.GetAllMids(t.Context())
+	if err != nil || mids["BTC"].String() != "91000" {
+		t.Fatalf("GetAllMids = %v, %v", mids, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+
+	c, calls = flakyServer(t, 10, http.StatusBadGateway)
+	_, err = c.GetAllMids(t.Context())
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusBadGateway || httpErr.Type != "allMids" {
+		t.Fatalf("err = %v, want a 502 HTTPError", err)
+	}
+	if calls.Load() != maxRetries+1 {
+		t.Errorf("calls = %d, want %d", calls.Load(), maxRetries+1)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	c, calls := flakyServer(t, 10, http.StatusTooManyRequests)
+	if _, err := c.GetAllMids(t.Context()); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("err = %v, want ErrRateLimited", err)
+	}
+	if got, _ := c.Limiter().Available(); got >= IPWeightPerMinute/2 {
+		t.Errorf("available = %d, want the budget drained after a 429", got)
+	}
+
+	// Client errors aren't retried
+	c, calls = flakyServer(t, 1, http.StatusUnprocessableEntity)
+	if _, err := c.GetAllMids(t.Context()); err == nil || errors.Is(err, ErrRateLimited) || calls.Load() != 1 {
+		t.Errorf("err = %v after %d calls", err, calls.Load())
+	}
+
+	// A response of the wrong shape
+	c, _ = flakyServer(t, 0, 0)
+	var decodeErr *DecodeError
+	if _, err := c.GetUserFills(t.Context(), "0x0"); !errors.As(err, &decodeErr) || decodeErr.Type != "userFills" {
+		t.Errorf("err = %v, want a DecodeError", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := c.GetAllMids(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
@@ -1,30 +1,30 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetMetaAndAssetCtxs() (*MetaAndAssetCtxs, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetMetaAndAssetCtxs(ctx context.Context) (*MetaAndAssetCtxs, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "metaAndAssetCtxs",
 	})
 	if err != nil {
 		return nil, err
 	}
 	var result MetaAndAssetCtxs
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := decode("metaAndAssetCtxs", body, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-func (c *Client) GetPredictedFundings() (PredictedFundings, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetPredictedFundings(ctx context.Context) (PredictedFundings, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "predictedFundings",
 	})
 	if err != nil {
 		return nil, err
 	}
 	var result PredictedFundings
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := decode("predictedFundings", body, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
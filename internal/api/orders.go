@@ -1,9 +1,9 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetOpenOrders(user string) ([]OpenOrder, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetOpenOrders(ctx context.Context, user string) ([]OpenOrder, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "frontendOpenOrders",
 		"user": user,
 	})
@@ -11,7 +11,7 @@ func (c *Client) GetOpenOrders(user string) ([]OpenOrder, error) {
 		return nil, err
 	}
 	var orders []OpenOrder
-	if err := json.Unmarshal(body, &orders); err != nil {
+	if err := decode("frontendOpenOrders", body, &orders); err != nil {
 		return nil, err
 	}
 	return orders, nil
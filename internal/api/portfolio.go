@@ -1,12 +1,18 @@
 package api
 
-func (c *Client) GetPortfolio(user string) ([]PortfolioPeriod, error) {
-	body, err := c.post(map[string]string{
+import "context"
+
+func (c *Client) GetPortfolio(ctx context.Context, user string) ([]PortfolioPeriod, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "portfolio",
 		"user": user,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return ParsePortfolio(body)
+	var periods PortfolioPeriods
+	if err := decode("portfolio", body, &periods); err != nil {
+		return nil, err
+	}
+	return periods, nil
 }
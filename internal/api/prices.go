@@ -1,16 +1,16 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetAllMids() (AllMids, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetAllMids(ctx context.Context) (AllMids, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "allMids",
 	})
 	if err != nil {
 		return nil, err
 	}
 	var mids AllMids
-	if err := json.Unmarshal(body, &mids); err != nil {
+	if err := decode("allMids", body, &mids); err != nil {
 		return nil, err
 	}
 	return mids, nil
@@ -0,0 +1,18 @@
+package api
+
+import "context"
+
+func (c *Client) GetUserRateLimit(ctx context.Context, user string) (*UserRateLimit, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "userRateLimit",
+		"user": user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var limit UserRateLimit
+	if err := decode("userRateLimit", body, &limit); err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import "context"
+
+func (c *Client) GetDelegatorSummary(ctx context.Context, user string) (*DelegatorSummary, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "delegatorSummary",
+		"user": user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var summary DelegatorSummary
+	if err := decode("delegatorSummary", body, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func (c *Client) GetDelegations(ctx context.Context, user string) ([]Delegation, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "delegations",
+		"user": user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var delegations []Delegation
+	if err := decode("delegations", body, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+func (c *Client) GetDelegatorRewards(ctx context.Context, user string) ([]DelegatorReward, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "delegatorRewards",
+		"user": user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var rewards []DelegatorReward
+	if err := decode("delegatorRewards", body, &rewards); err != nil {
+		return nil, err
+	}
+	return rewards, nil
+}
+
+func (c *Client) GetDelegatorHistory(ctx context.Context, user string) ([]DelegatorEvent, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "delegatorHistory",
+		"user": user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var events []DelegatorEvent
+	if err := decode("delegatorHistory", body, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *Client) GetValidatorSummaries(ctx context.Context) ([]ValidatorSummary, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "validatorSummaries",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var validators []ValidatorSummary
+	if err := decode("validatorSummaries", body, &validators); err != nil {
+		return nil, err
+	}
+	return validators, nil
+}
@@ -1,20 +1,24 @@
 package api
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
 
 // clearinghouseState response
 type ClearinghouseState struct {
 	MarginSummary              MarginSummary   `json:"marginSummary"`
-	CrossMaintenanceMarginUsed string          `json:"crossMaintenanceMarginUsed"`
-	Withdrawable               string          `json:"withdrawable"`
+	CrossMaintenanceMarginUsed decimal.Decimal `json:"crossMaintenanceMarginUsed"`
+	Withdrawable               decimal.Decimal `json:"withdrawable"`
 	AssetPositions             []AssetPosition `json:"assetPositions"`
 }
 
 type MarginSummary struct {
-	AccountValue    string `json:"accountValue"`
-	TotalNtlPos     string `json:"totalNtlPos"`
-	TotalRawUsd     string `json:"totalRawUsd"`
-	TotalMarginUsed string `json:"totalMarginUsed"`
+	AccountValue    decimal.Decimal `json:"accountValue"`
+	TotalNtlPos     decimal.Decimal `json:"totalNtlPos"`
+	TotalRawUsd     decimal.Decimal `json:"totalRawUsd"`
+	TotalMarginUsed decimal.Decimal `json:"totalMarginUsed"`
 }
 
 type AssetPosition struct {
@@ -23,17 +27,17 @@ type AssetPosition struct {
 }
 
 type Position struct {
-	Coin           string      `json:"coin"`
-	Szi            string      `json:"szi"`
-	EntryPx        string      `json:"entryPx"`
-	PositionValue  string      `json:"positionValue"`
-	UnrealizedPnl  string      `json:"unrealizedPnl"`
-	ReturnOnEquity string      `json:"returnOnEquity"`
-	Leverage       Leverage    `json:"leverage"`
-	LiquidationPx  *string     `json:"liquidationPx"` // nullable - null for cross-margin
-	MarginUsed     string      `json:"marginUsed"`
-	MaxLeverage    int         `json:"maxLeverage"`
-	CumFunding     *CumFunding `json:"cumFunding,omitempty"`
+	Coin           string           `json:"coin"`
+	Szi            decimal.Decimal  `json:"szi"`
+	EntryPx        decimal.Decimal  `json:"entryPx"`
+	PositionValue  decimal.Decimal  `json:"positionValue"`
+	UnrealizedPnl  decimal.Decimal  `json:"unrealizedPnl"`
+	ReturnOnEquity decimal.Decimal  `json:"returnOnEquity"`
+	Leverage       Leverage         `json:"leverage"`
+	LiquidationPx  *decimal.Decimal `json:"liquidationPx"` // nullable - null for cross-margin
+	MarginUsed     decimal.Decimal  `json:"marginUsed"`
+	MaxLeverage    int              `json:"maxLeverage"`
+	CumFunding     *CumFunding      `json:"cumFunding,omitempty"`
 }
 
 type Leverage struct {
@@ -42,13 +46,13 @@ type Leverage struct {
 }
 
 type CumFunding struct {
-	AllTime     string `json:"allTime"`
-	SinceOpen   string `json:"sinceOpen"`
-	SinceChange string `json:"sinceChange"`
+	AllTime     decimal.Decimal `json:"allTime"`
+	SinceOpen   decimal.Decimal `json:"sinceOpen"`
+	SinceChange decimal.Decimal `json:"sinceChange"`
 }
 
-// allMids response: map[string]string
-type AllMids map[string]string
+// allMids response: coin -> mid price
+type AllMids map[string]decimal.Decimal
 
 // metaAndAssetCtxs response: [Meta, [AssetCtx...]]
 type MetaAndAssetCtxs struct {
@@ -68,15 +72,15 @@ type AssetMeta struct {
 }
 
 type AssetCtx struct {
-	Funding      string   `json:"funding"`
-	OpenInterest string   `json:"openInterest"`
-	PrevDayPx    string   `json:"prevDayPx"`
-	DayNtlVlm   string   `json:"dayNtlVlm"`
-	Premium      string   `json:"premium,omitempty"`
-	OraclePx     string   `json:"oraclePx"`
-	MarkPx       string   `json:"markPx"`
-	MidPx        string   `json:"midPx,omitempty"`
-	ImpactPxs    []string `json:"impactPxs,omitempty"`
+	Funding      decimal.Decimal   `json:"funding"`
+	OpenInterest decimal.Decimal   `json:"openInterest"`
+	PrevDayPx    decimal.Decimal   `json:"prevDayPx"`
+	DayNtlVlm    decimal.Decimal   `json:"dayNtlVlm"`
+	Premium      decimal.Decimal   `json:"premium,omitzero"`
+	OraclePx     decimal.Decimal   `json:"oraclePx"`
+	MarkPx       decimal.Decimal   `json:"markPx"`
+	MidPx        decimal.Decimal   `json:"midPx,omitzero"`
+	ImpactPxs    []decimal.Decimal `json:"impactPxs,omitzero"`
 }
 
 func (m *MetaAndAssetCtxs) UnmarshalJSON(data []byte) error {
@@ -96,48 +100,115 @@ func (m *MetaAndAssetCtxs) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// predictedFundings response
-type PredictedFundings [][]interface{} // [[AssetMeta, PredictedFunding], ...]
+// predictedFundings response: [[coin, [[venue, {fundingRate, nextFundingTime}], ...]], ...]
+type PredictedFundings []PredictedFunding
 
+// PredictedFunding is the next funding rate for one coin on each venue.
 type PredictedFunding struct {
-	Venue   string `json:"venue"`
-	Funding string `json:"funding"`
+	Coin   string
+	Venues []VenueFunding
+}
+
+type VenueFunding struct {
+	Venue                string          `json:"-"` // "HlPerp", "BinPerp", "BybitPerp"
+	FundingRate          decimal.Decimal `json:"fundingRate"`
+	NextFundingTime      int64           `json:"nextFundingTime"`
+	FundingIntervalHours int             `json:"fundingIntervalHours,omitempty"`
+}
+
+// Venue names used by predictedFundings
+const (
+	VenueHyperliquid = "HlPerp"
+	VenueBinance     = "BinPerp"
+	VenueBybit       = "BybitPerp"
+)
+
+func (p *PredictedFunding) UnmarshalJSON(data []byte) error {
+	var raw [2]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw[0], &p.Coin); err != nil {
+		return err
+	}
+	var venues [][2]json.RawMessage
+	if err := json.Unmarshal(raw[1], &venues); err != nil {
+		return err
+	}
+	p.Venues = p.Venues[:0]
+	for _, v := range venues {
+		var vf VenueFunding
+		if err := json.Unmarshal(v[0], &vf.Venue); err != nil {
+			return err
+		}
+		// Venues without a listing come back as null
+		if string(v[1]) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(v[1], &vf); err != nil {
+			return err
+		}
+		p.Venues = append(p.Venues, vf)
+	}
+	return nil
+}
+
+// Venue returns the funding for the named venue, if listed.
+func (p PredictedFunding) Venue(name string) (VenueFunding, bool) {
+	for _, v := range p.Venues {
+		if v.Venue == name {
+			return v, true
+		}
+	}
+	return VenueFunding{}, false
+}
+
+// IntervalHours is how many hours one funding period covers. Hyperliquid
+// pays hourly; CEX venues default to 8h when the response doesn't say.
+func (v VenueFunding) IntervalHours() int {
+	if v.FundingIntervalHours > 0 {
+		return v.FundingIntervalHours
+	}
+	if v.Venue == VenueHyperliquid {
+		return 1
+	}
+	return 8
 }
 
 // frontendOpenOrders response
 type OpenOrder struct {
-	Coin             string `json:"coin"`
-	Side             string `json:"side"`
-	LimitPx          string `json:"limitPx"`
-	Sz               string `json:"sz"`
-	Oid              int64  `json:"oid"`
-	Timestamp        int64  `json:"timestamp"`
-	OrigSz           string `json:"origSz"`
-	Cloid            string `json:"cloid,omitempty"`
-	OrderType        string `json:"orderType"`
-	TriggerPx        string `json:"triggerPx,omitempty"`
-	IsTrigger        bool   `json:"isTrigger"`
-	TriggerCondition string `json:"triggerCondition,omitempty"`
-	ReduceOnly       bool   `json:"reduceOnly"`
-	Children         []any  `json:"children,omitempty"`
+	Coin             string          `json:"coin"`
+	Side             string          `json:"side"`
+	LimitPx          decimal.Decimal `json:"limitPx"`
+	Sz               decimal.Decimal `json:"sz"`
+	Oid              int64           `json:"oid"`
+	Timestamp        int64           `json:"timestamp"`
+	OrigSz           decimal.Decimal `json:"origSz"`
+	Cloid            string          `json:"cloid,omitempty"`
+	OrderType        string          `json:"orderType"`
+	TriggerPx        decimal.Decimal `json:"triggerPx,omitzero"`
+	IsTrigger        bool            `json:"isTrigger"`
+	TriggerCondition string          `json:"triggerCondition,omitempty"`
+	ReduceOnly       bool            `json:"reduceOnly"`
+	Children         []any           `json:"children,omitempty"`
 }
 
 // userFills response
 type Fill struct {
-	Coin          string `json:"coin"`
-	Px            string `json:"px"`
-	Sz            string `json:"sz"`
-	Side          string `json:"side"`
-	Time          int64  `json:"time"`
-	StartPosition string `json:"startPosition"`
-	Dir           string `json:"dir"`
-	ClosedPnl     string `json:"closedPnl"`
-	Hash          string `json:"hash"`
-	Oid           int64  `json:"oid"`
-	Crossed       bool   `json:"crossed"`
-	Fee           string `json:"fee"`
-	Tid           int64  `json:"tid"`
-	FeeToken      string `json:"feeToken"`
+	Coin          string          `json:"coin"`
+	Px            decimal.Decimal `json:"px"`
+	Sz            decimal.Decimal `json:"sz"`
+	Side          string          `json:"side"`
+	Time          int64           `json:"time"`
+	StartPosition decimal.Decimal `json:"startPosition"`
+	Dir           string          `json:"dir"`
+	ClosedPnl     decimal.Decimal `json:"closedPnl"`
+	Hash          string          `json:"hash"`
+	Oid           int64           `json:"oid"`
+	Crossed       bool            `json:"crossed"`
+	Fee           decimal.Decimal `json:"fee"`
+	Tid           int64           `json:"tid"`
+	FeeToken      string          `json:"feeToken"`
 }
 
 // userFunding response - API returns {time, hash, delta: {type, coin, usdc, szi, fundingRate}}
@@ -148,28 +219,65 @@ type FundingPaymentRaw struct {
 }
 
 type FundingPaymentData struct {
-	Type        string `json:"type"`
-	Coin        string `json:"coin"`
-	Usdc        string `json:"usdc"`
-	Szi         string `json:"szi"`
-	FundingRate string `json:"fundingRate"`
+	Type        string          `json:"type"`
+	Coin        string          `json:"coin"`
+	Usdc        decimal.Decimal `json:"usdc"`
+	Szi         decimal.Decimal `json:"szi"`
+	FundingRate decimal.Decimal `json:"fundingRate"`
 }
 
 // FundingPayment is the flattened form used internally
 type FundingPayment struct {
 	Time        int64
 	Coin        string
-	Usdc        string
-	Szi         string
-	FundingRate string
+	Usdc        decimal.Decimal
+	Szi         decimal.Decimal
+	FundingRate decimal.Decimal
 }
 
 // fundingHistory response
 type FundingHistoryEntry struct {
-	Coin        string `json:"coin"`
-	FundingRate string `json:"fundingRate"`
-	Premium     string `json:"premium"`
-	Time        int64  `json:"time"`
+	Coin        string          `json:"coin"`
+	FundingRate decimal.Decimal `json:"fundingRate"`
+	Premium     decimal.Decimal `json:"premium"`
+	Time        int64           `json:"time"`
+}
+
+// l2Book response: levels are [bids, asks], best price first
+type L2Book struct {
+	Coin   string       `json:"coin"`
+	Time   int64        `json:"time"`
+	Levels [2][]L2Level `json:"levels"`
+}
+
+type L2Level struct {
+	Px decimal.Decimal `json:"px"`
+	Sz decimal.Decimal `json:"sz"`
+	N  int             `json:"n"` // number of orders
+}
+
+// Bids returns the bid levels, best first.
+func (b L2Book) Bids() []L2Level {
+	return b.Levels[0]
+}
+
+// Asks returns the ask levels, best first.
+func (b L2Book) Asks() []L2Level {
+	return b.Levels[1]
+}
+
+// candleSnapshot response
+type Candle struct {
+	OpenTime  int64           `json:"t"`
+	CloseTime int64           `json:"T"`
+	Coin      string          `json:"s"`
+	Interval  string          `json:"i"`
+	Open      decimal.Decimal `json:"o"`
+	Close     decimal.Decimal `json:"c"`
+	High      decimal.Decimal `json:"h"`
+	Low       decimal.Decimal `json:"l"`
+	Volume    decimal.Decimal `json:"v"` // in base units
+	Trades    int             `json:"n"`
 }
 
 // portfolio response: [["day", {accountValueHistory, pnlHistory, vlm}], ...]
@@ -177,12 +285,12 @@ type PortfolioPeriod struct {
 	Name                string
 	AccountValueHistory []TimeValue
 	PnlHistory          []TimeValue
-	Vlm                 string
+	Vlm                 decimal.Decimal
 }
 
 type TimeValue struct {
 	Time  int64
-	Value string
+	Value decimal.Decimal
 }
 
 func (tv *TimeValue) UnmarshalJSON(data []byte) error {
@@ -200,9 +308,22 @@ func (tv *TimeValue) UnmarshalJSON(data []byte) error {
 }
 
 type portfolioPeriodData struct {
-	AccountValueHistory []TimeValue `json:"accountValueHistory"`
-	PnlHistory          []TimeValue `json:"pnlHistory"`
-	Vlm                 string      `json:"vlm"`
+	AccountValueHistory []TimeValue     `json:"accountValueHistory"`
+	PnlHistory          []TimeValue     `json:"pnlHistory"`
+	Vlm                 decimal.Decimal `json:"vlm"`
+}
+
+// PortfolioPeriods decodes the [[name, data], ...] shape when embedded in
+// another response (e.g. vaultDetails.portfolio).
+type PortfolioPeriods []PortfolioPeriod
+
+func (pp *PortfolioPeriods) UnmarshalJSON(data []byte) error {
+	periods, err := ParsePortfolio(data)
+	if err != nil {
+		return err
+	}
+	*pp = periods
+	return nil
 }
 
 // ParsePortfolio parses the raw portfolio response which is [[name, data], ...]
@@ -241,51 +362,165 @@ func ParsePortfolio(data []byte) ([]PortfolioPeriod, error) {
 
 // userFees response
 type UserFees struct {
-	ActiveReferralDiscount string        `json:"activeReferralDiscount"`
-	DailyUserVlm           []DailyVolume `json:"dailyUserVlm"`
-	FeeSchedule            FeeSchedule   `json:"feeSchedule"`
-	UserCrossRate          string        `json:"userCrossRate"`
-	UserAddRate            string        `json:"userAddRate"`
+	ActiveReferralDiscount decimal.Decimal `json:"activeReferralDiscount"`
+	DailyUserVlm           []DailyVolume   `json:"dailyUserVlm"`
+	FeeSchedule            FeeSchedule     `json:"feeSchedule"`
+	UserCrossRate          decimal.Decimal `json:"userCrossRate"`
+	UserAddRate            decimal.Decimal `json:"userAddRate"`
 }
 
 type DailyVolume struct {
-	Date  string `json:"date"`
-	Cross string `json:"userCross"`
-	Add   string `json:"userAdd"`
+	Date  string          `json:"date"`
+	Cross decimal.Decimal `json:"userCross"`
+	Add   decimal.Decimal `json:"userAdd"`
 }
 
 type FeeSchedule struct {
-	Cross string `json:"cross"`
-	Add   string `json:"add"`
+	Cross decimal.Decimal `json:"cross"`
+	Add   decimal.Decimal `json:"add"`
 }
 
 // userVaultEquities response
 type VaultEquity struct {
-	VaultAddress         string `json:"vaultAddress"`
-	Equity               string `json:"equity"`
-	LockedUntilTimestamp int64  `json:"lockedUntilTimestamp"`
+	VaultAddress         string          `json:"vaultAddress"`
+	Equity               decimal.Decimal `json:"equity"`
+	LockedUntilTimestamp int64           `json:"lockedUntilTimestamp"`
 }
 
 // vaultDetails response
 type VaultDetails struct {
-	Name             string         `json:"name"`
-	VaultAddress     string         `json:"vaultAddress"`
-	Leader           string         `json:"leader"`
-	Description      string         `json:"description"`
-	LeaderCommission float64        `json:"leaderCommission"`
-	MaxDistributable float64        `json:"maxDistributable"`
-	APR              float64        `json:"apr"`
-	IsClosed         bool           `json:"isClosed"`
-	AllowDeposits    bool           `json:"allowDeposits"`
-	FollowerState    *FollowerState `json:"followerState,omitempty"`
+	Name             string           `json:"name"`
+	VaultAddress     string           `json:"vaultAddress"`
+	Leader           string           `json:"leader"`
+	Description      string           `json:"description"`
+	LeaderCommission float64          `json:"leaderCommission"`
+	LeaderFraction   float64          `json:"leaderFraction"`
+	MaxDistributable decimal.Decimal  `json:"maxDistributable"`
+	MaxWithdrawable  decimal.Decimal  `json:"maxWithdrawable"`
+	APR              float64          `json:"apr"`
+	IsClosed         bool             `json:"isClosed"`
+	AllowDeposits    bool             `json:"allowDeposits"`
+	FollowerState    *FollowerState   `json:"followerState,omitempty"`
+	Followers        []FollowerState  `json:"followers,omitempty"`
+	Portfolio        PortfolioPeriods `json:"portfolio,omitempty"`
+}
+
+// vaultSummaries response
+type VaultSummary struct {
+	Name             string            `json:"name"`
+	VaultAddress     string            `json:"vaultAddress"`
+	Leader           string            `json:"leader"`
+	Tvl              decimal.Decimal   `json:"tvl"`
+	IsClosed         bool              `json:"isClosed"`
+	Relationship     VaultRelationship `json:"relationship"`
+	CreateTimeMillis int64             `json:"createTimeMillis"`
+}
+
+type VaultRelationship struct {
+	Type string `json:"type"` // "normal", "parent" or "child"
 }
 
 type FollowerState struct {
-	User           string `json:"user"`
-	VaultEquity    string `json:"vaultEquity"`
-	Pnl            string `json:"pnl"`
-	AllTimePnl     string `json:"allTimePnl"`
-	DaysFollowing  int    `json:"daysFollowing"`
-	VaultEntryTime int64  `json:"vaultEntryTime"`
-	LockupUntil    int64  `json:"lockupUntil"`
+	User           string          `json:"user"`
+	VaultEquity    decimal.Decimal `json:"vaultEquity"`
+	Pnl            decimal.Decimal `json:"pnl"`
+	AllTimePnl     decimal.Decimal `json:"allTimePnl"`
+	DaysFollowing  int             `json:"daysFollowing"`
+	VaultEntryTime int64           `json:"vaultEntryTime"`
+	LockupUntil    int64           `json:"lockupUntil"`
+}
+
+// delegatorSummary response
+type DelegatorSummary struct {
+	Delegated              decimal.Decimal `json:"delegated"`
+	Undelegated            decimal.Decimal `json:"undelegated"`
+	TotalPendingWithdrawal decimal.Decimal `json:"totalPendingWithdrawal"`
+	NPendingWithdrawals    int             `json:"nPendingWithdrawals"`
+}
+
+// delegations response
+type Delegation struct {
+	Validator            string          `json:"validator"`
+	Amount               decimal.Decimal `json:"amount"`
+	LockedUntilTimestamp int64           `json:"lockedUntilTimestamp"`
+}
+
+// delegatorRewards response
+type DelegatorReward struct {
+	Time        int64           `json:"time"`
+	Source      string          `json:"source"` // "delegation" or "commission"
+	TotalAmount decimal.Decimal `json:"totalAmount"`
+}
+
+// delegatorHistory response: {time, hash, delta} where delta holds exactly
+// one of delegate, cDeposit or withdrawal
+type DelegatorEvent struct {
+	Time  int64               `json:"time"`
+	Hash  string              `json:"hash"`
+	Delta DelegatorEventDelta `json:"delta"`
+}
+
+type DelegatorEventDelta struct {
+	Delegate *struct {
+		Validator    string          `json:"validator"`
+		Amount       decimal.Decimal `json:"amount"`
+		IsUndelegate bool            `json:"isUndelegate"`
+	} `json:"delegate,omitempty"`
+	CDeposit *struct {
+		Amount decimal.Decimal `json:"amount"`
+	} `json:"cDeposit,omitempty"`
+	Withdrawal *struct {
+		Amount decimal.Decimal `json:"amount"`
+		Phase  string          `json:"phase"` // "initiated" or "finalized"
+	} `json:"withdrawal,omitempty"`
+}
+
+// Kind returns a short label for the event type.
+func (d DelegatorEventDelta) Kind() string {
+	switch {
+	case d.Delegate != nil && d.Delegate.IsUndelegate:
+		return "undelegate"
+	case d.Delegate != nil:
+		return "delegate"
+	case d.CDeposit != nil:
+		return "deposit"
+	case d.Withdrawal != nil:
+		return "withdraw " + d.Withdrawal.Phase
+	}
+	return "unknown"
+}
+
+// Amount returns the HYPE amount moved by the event.
+func (d DelegatorEventDelta) Amount() decimal.Decimal {
+	switch {
+	case d.Delegate != nil:
+		return d.Delegate.Amount
+	case d.CDeposit != nil:
+		return d.CDeposit.Amount
+	case d.Withdrawal != nil:
+		return d.Withdrawal.Amount
+	}
+	return decimal.Zero
+}
+
+// validatorSummaries response (only the fields the staking view uses)
+type ValidatorSummary struct {
+	Validator  string          `json:"validator"`
+	Name       string          `json:"name"`
+	Commission decimal.Decimal `json:"commission"`
+	IsActive   bool            `json:"isActive"`
+	IsJailed   bool            `json:"isJailed"`
+}
+
+// userRateLimit response: the wallet's address-based request budget, which
+// grows with traded volume
+type UserRateLimit struct {
+	CumVlm        decimal.Decimal `json:"cumVlm"`
+	NRequestsUsed int64           `json:"nRequestsUsed"`
+	NRequestsCap  int64           `json:"nRequestsCap"`
+}
+
+// Remaining returns how many more requests the wallet may send.
+func (r UserRateLimit) Remaining() int64 {
+	return max(r.NRequestsCap-r.NRequestsUsed, 0)
 }
@@ -44,10 +44,10 @@ func TestClearinghouseStateUnmarshal(t *testing.T) {
 		t.Fatalf("Unmarshal error: %v", err)
 	}
 
-	if state.MarginSummary.AccountValue != "314096.11" {
+	if state.MarginSummary.AccountValue.String() != "314096.11" {
 		t.Errorf("AccountValue = %q, want %q", state.MarginSummary.AccountValue, "314096.11")
 	}
-	if state.Withdrawable != "34867.51" {
+	if state.Withdrawable.String() != "34867.51" {
 		t.Errorf("Withdrawable = %q, want %q", state.Withdrawable, "34867.51")
 	}
 	if len(state.AssetPositions) != 1 {
@@ -58,7 +58,7 @@ func TestClearinghouseStateUnmarshal(t *testing.T) {
 	if pos.Coin != "BTC" {
 		t.Errorf("Coin = %q, want %q", pos.Coin, "BTC")
 	}
-	if pos.Szi != "1.0982" {
+	if pos.Szi.String() != "1.0982" {
 		t.Errorf("Szi = %q, want %q", pos.Szi, "1.0982")
 	}
 	if pos.Leverage.Value != 31 {
@@ -71,7 +71,7 @@ func TestClearinghouseStateUnmarshal(t *testing.T) {
 	if pos.CumFunding == nil {
 		t.Fatal("CumFunding is nil, want non-nil")
 	}
-	if pos.CumFunding.SinceOpen != "40.66" {
+	if pos.CumFunding.SinceOpen.String() != "40.66" {
 		t.Errorf("CumFunding.SinceOpen = %q, want %q", pos.CumFunding.SinceOpen, "40.66")
 	}
 }
@@ -107,7 +107,7 @@ func TestClearinghouseStateLiquidationPxPresent(t *testing.T) {
 	if pos.LiquidationPx == nil {
 		t.Fatal("LiquidationPx is nil, want non-nil")
 	}
-	if *pos.LiquidationPx != "2500.00" {
+	if pos.LiquidationPx.String() != "2500.00" {
 		t.Errorf("LiquidationPx = %q, want %q", *pos.LiquidationPx, "2500.00")
 	}
 }
@@ -138,10 +138,10 @@ func TestMetaAndAssetCtxsUnmarshal(t *testing.T) {
 	if len(result.AssetCtxs) != 2 {
 		t.Fatalf("AssetCtxs len = %d, want 2", len(result.AssetCtxs))
 	}
-	if result.AssetCtxs[0].Funding != "0.0001" {
+	if result.AssetCtxs[0].Funding.String() != "0.0001" {
 		t.Errorf("AssetCtxs[0].Funding = %q, want 0.0001", result.AssetCtxs[0].Funding)
 	}
-	if result.AssetCtxs[1].Funding != "-0.00005" {
+	if result.AssetCtxs[1].Funding.String() != "-0.00005" {
 		t.Errorf("AssetCtxs[1].Funding = %q, want -0.00005", result.AssetCtxs[1].Funding)
 	}
 }
@@ -171,13 +171,13 @@ func TestFundingPaymentRawUnmarshal(t *testing.T) {
 	if fp.Delta.Coin != "kPEPE" {
 		t.Errorf("Delta.Coin = %q, want kPEPE", fp.Delta.Coin)
 	}
-	if fp.Delta.Usdc != "-0.275994" {
+	if fp.Delta.Usdc.String() != "-0.275994" {
 		t.Errorf("Delta.Usdc = %q, want -0.275994", fp.Delta.Usdc)
 	}
-	if fp.Delta.FundingRate != "0.0000061016" {
+	if fp.Delta.FundingRate.String() != "0.0000061016" {
 		t.Errorf("Delta.FundingRate = %q, want 0.0000061016", fp.Delta.FundingRate)
 	}
-	if fp.Delta.Szi != "11770244.0" {
+	if fp.Delta.Szi.String() != "11770244.0" {
 		t.Errorf("Delta.Szi = %q, want 11770244.0", fp.Delta.Szi)
 	}
 }
@@ -199,7 +199,7 @@ func TestFundingPaymentArrayUnmarshal(t *testing.T) {
 	if rawPayments[0].Delta.Coin != "BTC" {
 		t.Errorf("[0].Delta.Coin = %q, want BTC", rawPayments[0].Delta.Coin)
 	}
-	if rawPayments[1].Delta.Usdc != "5.25" {
+	if rawPayments[1].Delta.Usdc.String() != "5.25" {
 		t.Errorf("[1].Delta.Usdc = %q, want 5.25", rawPayments[1].Delta.Usdc)
 	}
 }
@@ -230,7 +230,7 @@ func TestParsePortfolio(t *testing.T) {
 	if periods[0].Name != "day" {
 		t.Errorf("[0].Name = %q, want day", periods[0].Name)
 	}
-	if periods[0].Vlm != "252695.71" {
+	if periods[0].Vlm.String() != "252695.71" {
 		t.Errorf("[0].Vlm = %q, want 252695.71", periods[0].Vlm)
 	}
 	if len(periods[0].PnlHistory) != 2 {
@@ -239,7 +239,7 @@ func TestParsePortfolio(t *testing.T) {
 	if periods[0].PnlHistory[0].Time != 1770926342418 {
 		t.Errorf("[0].PnlHistory[0].Time = %d, want 1770926342418", periods[0].PnlHistory[0].Time)
 	}
-	if periods[0].PnlHistory[1].Value != "500.25" {
+	if periods[0].PnlHistory[1].Value.String() != "500.25" {
 		t.Errorf("[0].PnlHistory[1].Value = %q, want 500.25", periods[0].PnlHistory[1].Value)
 	}
 	if len(periods[0].AccountValueHistory) != 2 {
@@ -249,7 +249,7 @@ func TestParsePortfolio(t *testing.T) {
 	if periods[1].Name != "allTime" {
 		t.Errorf("[1].Name = %q, want allTime", periods[1].Name)
 	}
-	if periods[1].Vlm != "76267102.44" {
+	if periods[1].Vlm.String() != "76267102.44" {
 		t.Errorf("[1].Vlm = %q, want 76267102.44", periods[1].Vlm)
 	}
 }
@@ -271,7 +271,7 @@ func TestAllMidsUnmarshal(t *testing.T) {
 	if err := json.Unmarshal([]byte(raw), &mids); err != nil {
 		t.Fatalf("Unmarshal error: %v", err)
 	}
-	if mids["BTC"] != "91234.50" {
+	if mids["BTC"].String() != "91234.50" {
 		t.Errorf("BTC = %q, want 91234.50", mids["BTC"])
 	}
 	if len(mids) != 3 {
@@ -334,7 +334,7 @@ func TestFillUnmarshal(t *testing.T) {
 	if fill.Coin != "MON" {
 		t.Errorf("Coin = %q, want MON", fill.Coin)
 	}
-	if fill.Fee != "3.840185" {
+	if fill.Fee.String() != "3.840185" {
 		t.Errorf("Fee = %q, want 3.840185", fill.Fee)
 	}
 	if !fill.Crossed {
@@ -356,19 +356,19 @@ func TestUserFeesUnmarshal(t *testing.T) {
 	if err := json.Unmarshal([]byte(raw), &fees); err != nil {
 		t.Fatalf("Unmarshal error: %v", err)
 	}
-	if fees.UserCrossRate != "0.0004" {
+	if fees.UserCrossRate.String() != "0.0004" {
 		t.Errorf("UserCrossRate = %q, want 0.0004", fees.UserCrossRate)
 	}
-	if fees.UserAddRate != "0.00012" {
+	if fees.UserAddRate.String() != "0.00012" {
 		t.Errorf("UserAddRate = %q, want 0.00012", fees.UserAddRate)
 	}
-	if fees.FeeSchedule.Cross != "0.00045" {
+	if fees.FeeSchedule.Cross.String() != "0.00045" {
 		t.Errorf("FeeSchedule.Cross = %q, want 0.00045", fees.FeeSchedule.Cross)
 	}
 	if len(fees.DailyUserVlm) != 1 {
 		t.Fatalf("DailyUserVlm len = %d, want 1", len(fees.DailyUserVlm))
 	}
-	if fees.DailyUserVlm[0].Cross != "1860117.31" {
+	if fees.DailyUserVlm[0].Cross.String() != "1860117.31" {
 		t.Errorf("DailyUserVlm[0].Cross = %q, want 1860117.31", fees.DailyUserVlm[0].Cross)
 	}
 }
@@ -384,7 +384,7 @@ func TestVaultEquityUnmarshal(t *testing.T) {
 	if len(equities) != 1 {
 		t.Fatalf("len = %d, want 1", len(equities))
 	}
-	if equities[0].Equity != "80379985.9635280073" {
+	if equities[0].Equity.String() != "80379985.9635280073" {
 		t.Errorf("Equity = %q, want 80379985.9635280073", equities[0].Equity)
 	}
 }
@@ -423,7 +423,7 @@ func TestVaultDetailsUnmarshal(t *testing.T) {
 	if details.FollowerState == nil {
 		t.Fatal("FollowerState is nil")
 	}
-	if details.FollowerState.Pnl != "1636917.2868910581" {
+	if details.FollowerState.Pnl.String() != "1636917.2868910581" {
 		t.Errorf("FollowerState.Pnl = %q, want 1636917.2868910581", details.FollowerState.Pnl)
 	}
 	if details.FollowerState.DaysFollowing != 982 {
@@ -440,7 +440,223 @@ func TestTimeValueUnmarshal(t *testing.T) {
 	if tv.Time != 1770926342418 {
 		t.Errorf("Time = %d, want 1770926342418", tv.Time)
 	}
-	if tv.Value != "100500.25" {
+	if tv.Value.String() != "100500.25" {
 		t.Errorf("Value = %q, want 100500.25", tv.Value)
 	}
 }
+
+func TestVaultDetailsFollowersAndPortfolio(t *testing.T) {
+	raw := `{
+		"name": "Test Vault",
+		"vaultAddress": "0x1111111111111111111111111111111111111111",
+		"leader": "0x2222222222222222222222222222222222222222",
+		"leaderCommission": 0.1,
+		"leaderFraction": 0.25,
+		"maxWithdrawable": 1200.5,
+		"isClosed": true,
+		"allowDeposits": false,
+		"followers": [
+			{"user": "Leader", "vaultEquity": "2500.0", "pnl": "100.0", "allTimePnl": "150.0", "daysFollowing": 30, "vaultEntryTime": 1700000000000, "lockupUntil": 0},
+			{"user": "0x3333333333333333333333333333333333333333", "vaultEquity": "7500.0", "pnl": "-20.0", "allTimePnl": "-20.0", "daysFollowing": 2, "vaultEntryTime": 1770000000000, "lockupUntil": 1770345600000}
+		],
+		"portfolio": [
+			["day", {"accountValueHistory": [[1770000000000, "10000.0"]], "pnlHistory": [[1770000000000, "80.0"]], "vlm": "0.0"}],
+			["allTime", {"accountValueHistory": [[1700000000000, "1000.0"], [1770000000000, "10000.0"]], "pnlHistory": [], "vlm": "5000.0"}]
+		]
+	}`
+	var details VaultDetails
+	if err := json.Unmarshal([]byte(raw), &details); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !details.IsClosed || details.AllowDeposits {
+		t.Errorf("IsClosed=%v AllowDeposits=%v, want true/false", details.IsClosed, details.AllowDeposits)
+	}
+	if details.LeaderFraction != 0.25 {
+		t.Errorf("LeaderFraction = %v, want 0.25", details.LeaderFraction)
+	}
+	if len(details.Followers) != 2 {
+		t.Fatalf("Followers len = %d, want 2", len(details.Followers))
+	}
+	if details.Followers[1].LockupUntil != 1770345600000 {
+		t.Errorf("Followers[1].LockupUntil = %d", details.Followers[1].LockupUntil)
+	}
+	if len(details.Portfolio) != 2 {
+		t.Fatalf("Portfolio len = %d, want 2", len(details.Portfolio))
+	}
+	if details.Portfolio[1].Name != "allTime" || len(details.Portfolio[1].AccountValueHistory) != 2 {
+		t.Errorf("Portfolio[1] = %+v", details.Portfolio[1])
+	}
+}
+
+func TestVaultSummaryUnmarshal(t *testing.T) {
+	raw := `[{
+		"name": "Growi HF",
+		"vaultAddress": "0x1e37a337ed460039d1b15bd3bc489de789768d5e",
+		"leader": "0x7789fcd1b1fa4f4a2e5a2f6b8a8fb1d2a6b8c6f1",
+		"tvl": "4383200.123",
+		"isClosed": false,
+		"relationship": {"type": "normal"},
+		"createTimeMillis": 1717000000000
+	}]`
+	var summaries []VaultSummary
+	if err := json.Unmarshal([]byte(raw), &summaries); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("len = %d, want 1", len(summaries))
+	}
+	s := summaries[0]
+	if s.Tvl.String() != "4383200.123" {
+		t.Errorf("Tvl = %q, want 4383200.123", s.Tvl)
+	}
+	if s.Relationship.Type != "normal" {
+		t.Errorf("Relationship.Type = %q, want normal", s.Relationship.Type)
+	}
+	if s.CreateTimeMillis != 1717000000000 {
+		t.Errorf("CreateTimeMillis = %d", s.CreateTimeMillis)
+	}
+}
+
+func TestDelegatorSummaryUnmarshal(t *testing.T) {
+	raw := `{"delegated": "12060.16529862", "undelegated": "0.0", "totalPendingWithdrawal": "250.5", "nPendingWithdrawals": 1}`
+	var s DelegatorSummary
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if s.Delegated.String() != "12060.16529862" {
+		t.Errorf("Delegated = %q", s.Delegated)
+	}
+	if s.NPendingWithdrawals != 1 || s.TotalPendingWithdrawal.String() != "250.5" {
+		t.Errorf("pending = %d/%q", s.NPendingWithdrawals, s.TotalPendingWithdrawal)
+	}
+}
+
+func TestDelegationsAndRewardsUnmarshal(t *testing.T) {
+	var delegations []Delegation
+	if err := json.Unmarshal([]byte(`[{"validator": "0x5ac99df645f3414876c816caa18b2d234024b487", "amount": "12060.16529862", "lockedUntilTimestamp": 1735466781353}]`), &delegations); err != nil {
+		t.Fatalf("Unmarshal delegations: %v", err)
+	}
+	if len(delegations) != 1 || delegations[0].LockedUntilTimestamp != 1735466781353 {
+		t.Errorf("delegations = %+v", delegations)
+	}
+
+	var rewards []DelegatorReward
+	if err := json.Unmarshal([]byte(`[{"time": 1736726400073, "source": "delegation", "totalAmount": "0.73117184"}]`), &rewards); err != nil {
+		t.Fatalf("Unmarshal rewards: %v", err)
+	}
+	if len(rewards) != 1 || rewards[0].Source != "delegation" || rewards[0].TotalAmount.String() != "0.73117184" {
+		t.Errorf("rewards = %+v", rewards)
+	}
+}
+
+func TestDelegatorEventUnmarshal(t *testing.T) {
+	raw := `[
+		{"time": 1735380381353, "hash": "0x1", "delta": {"delegate": {"validator": "0x5ac9", "amount": "10000.0", "isUndelegate": false}}},
+		{"time": 1735380381354, "hash": "0x2", "delta": {"delegate": {"validator": "0x5ac9", "amount": "500.0", "isUndelegate": true}}},
+		{"time": 1735380381355, "hash": "0x3", "delta": {"cDeposit": {"amount": "12000.0"}}},
+		{"time": 1735380381356, "hash": "0x4", "delta": {"withdrawal": {"amount": "100.0", "phase": "initiated"}}}
+	]`
+	var events []DelegatorEvent
+	if err := json.Unmarshal([]byte(raw), &events); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	want := []struct{ kind, amount string }{
+		{"delegate", "10000.0"},
+		{"undelegate", "500.0"},
+		{"deposit", "12000.0"},
+		{"withdraw initiated", "100.0"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("len = %d, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if got := events[i].Delta.Kind(); got != w.kind {
+			t.Errorf("events[%d].Kind() = %q, want %q", i, got, w.kind)
+		}
+		if got := events[i].Delta.Amount(); got.String() != w.amount {
+			t.Errorf("events[%d].Amount() = %q, want %q", i, got, w.amount)
+		}
+	}
+}
+
+func TestPredictedFundingsUnmarshal(t *testing.T) {
+	raw := `[
+		["BTC", [
+			["BinPerp", {"fundingRate": "0.0001", "nextFundingTime": 1733961600000}],
+			["HlPerp", {"fundingRate": "0.0000125", "nextFundingTime": 1733958000000}],
+			["BybitPerp", {"fundingRate": "0.00005", "nextFundingTime": 1733961600000, "fundingIntervalHours": 4}]
+		]],
+		["PURR", [
+			["BinPerp", null],
+			["HlPerp", {"fundingRate": "-0.00002", "nextFundingTime": 1733958000000}]
+		]]
+	]`
+	var pf PredictedFundings
+	if err := json.Unmarshal([]byte(raw), &pf); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(pf) != 2 {
+		t.Fatalf("len = %d, want 2", len(pf))
+	}
+	if pf[0].Coin != "BTC" || len(pf[0].Venues) != 3 {
+		t.Fatalf("pf[0] = %+v", pf[0])
+	}
+	hl, ok := pf[0].Venue(VenueHyperliquid)
+	if !ok || hl.FundingRate.String() != "0.0000125" || hl.NextFundingTime != 1733958000000 {
+		t.Errorf("HlPerp = %+v, ok=%v", hl, ok)
+	}
+	if hl.IntervalHours() != 1 {
+		t.Errorf("HlPerp IntervalHours = %d, want 1", hl.IntervalHours())
+	}
+	bin, _ := pf[0].Venue(VenueBinance)
+	if bin.IntervalHours() != 8 {
+		t.Errorf("BinPerp IntervalHours = %d, want 8", bin.IntervalHours())
+	}
+	bybit, _ := pf[0].Venue(VenueBybit)
+	if bybit.IntervalHours() != 4 {
+		t.Errorf("BybitPerp IntervalHours = %d, want 4", bybit.IntervalHours())
+	}
+
+	// null venues are skipped
+	if len(pf[1].Venues) != 1 {
+		t.Errorf("PURR venues = %d, want 1", len(pf[1].Venues))
+	}
+	if _, ok := pf[1].Venue(VenueBinance); ok {
+		t.Error("PURR should not list BinPerp")
+	}
+}
+
+func TestL2BookUnmarshal(t *testing.T) {
+	raw := `{"coin":"ETH","time":1733958000123,"levels":[
+		[{"px":"3400.1","sz":"12.5","n":4},{"px":"3400.0","sz":"1.2","n":1}],
+		[{"px":"3400.3","sz":"0.8","n":2}]
+	]}`
+	var book L2Book
+	if err := json.Unmarshal([]byte(raw), &book); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if book.Coin != "ETH" || len(book.Bids()) != 2 || len(book.Asks()) != 1 {
+		t.Fatalf("book = %+v", book)
+	}
+	if b := book.Bids()[0]; b.Px.String() != "3400.1" || b.Sz.String() != "12.5" || b.N != 4 {
+		t.Errorf("best bid = %+v", b)
+	}
+	if a := book.Asks()[0]; a.Px.String() != "3400.3" {
+		t.Errorf("best ask = %+v", a)
+	}
+}
+
+func TestCandleUnmarshal(t *testing.T) {
+	raw := `[{"t":1733954400000,"T":1733957999999,"s":"BTC","i":"1h","o":"100950.0","c":"101200.0","h":"101400.0","l":"100800.0","v":"312.51","n":4210}]`
+	var candles []Candle
+	if err := json.Unmarshal([]byte(raw), &candles); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	c := candles[0]
+	if c.OpenTime != 1733954400000 || c.CloseTime != 1733957999999 || c.Coin != "BTC" || c.Interval != "1h" || c.Trades != 4210 {
+		t.Errorf("candle = %+v", c)
+	}
+	if c.Open.String() != "100950.0" || c.Close.String() != "101200.0" || c.High.String() != "101400.0" || c.Low.String() != "100800.0" || c.Volume.String() != "312.51" {
+		t.Errorf("prices = %+v", c)
+	}
+}
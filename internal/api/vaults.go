@@ -1,9 +1,9 @@
 package api
 
-import "encoding/json"
+import "context"
 
-func (c *Client) GetUserVaultEquities(user string) ([]VaultEquity, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetUserVaultEquities(ctx context.Context, user string) ([]VaultEquity, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type": "userVaultEquities",
 		"user": user,
 	})
@@ -11,14 +11,14 @@ func (c *Client) GetUserVaultEquities(user string) ([]VaultEquity, error) {
 		return nil, err
 	}
 	var equities []VaultEquity
-	if err := json.Unmarshal(body, &equities); err != nil {
+	if err := decode("userVaultEquities", body, &equities); err != nil {
 		return nil, err
 	}
 	return equities, nil
 }
 
-func (c *Client) GetVaultDetails(vaultAddress, user string) (*VaultDetails, error) {
-	body, err := c.post(map[string]string{
+func (c *Client) GetVaultDetails(ctx context.Context, vaultAddress, user string) (*VaultDetails, error) {
+	body, err := c.post(ctx, map[string]string{
 		"type":         "vaultDetails",
 		"vaultAddress": vaultAddress,
 		"user":         user,
@@ -27,8 +27,22 @@ func (c *Client) GetVaultDetails(vaultAddress, user string) (*VaultDetails, erro
 		return nil, err
 	}
 	var details VaultDetails
-	if err := json.Unmarshal(body, &details); err != nil {
+	if err := decode("vaultDetails", body, &details); err != nil {
 		return nil, err
 	}
 	return &details, nil
 }
+
+func (c *Client) GetVaultSummaries(ctx context.Context) ([]VaultSummary, error) {
+	body, err := c.post(ctx, map[string]string{
+		"type": "vaultSummaries",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var summaries []VaultSummary
+	if err := decode("vaultSummaries", body, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
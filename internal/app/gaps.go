@@ -0,0 +1,153 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// gapOverlap re-reads a little before the last event seen; merging
+	// dedupes whatever is read twice.
+	gapOverlap = 5 * time.Second
+	// maxBackfill bounds how far back a backfill reaches.
+	maxBackfill = 24 * time.Hour
+)
+
+// streamMarks is the newest event time (ms) seen on each user stream, from
+// WS or REST. After a reconnect the gap since then is backfilled.
+type streamMarks struct {
+	fills    int64
+	fundings int64
+	orders   int64
+}
+
+func (s *streamMarks) sawFills(fills []api.Fill) {
+	for _, f := range fills {
+		s.fills = max(s.fills, f.Time)
+	}
+}
+
+func (s *streamMarks) sawFundings(payments []api.FundingPayment) {
+	for _, p := range payments {
+		s.fundings = max(s.fundings, p.Time)
+	}
+}
+
+func (s *streamMarks) sawOrders(updates []ws.OrderUpdate) {
+	for _, u := range updates {
+		s.orders = max(s.orders, u.StatusTimestamp)
+	}
+}
+
+// gapStart is where a backfill for a stream last seen at mark begins: just
+// before the mark, or just before the connection dropped if nothing was
+// seen, and never more than maxBackfill ago.
+func gapStart(mark int64, lostAt, now time.Time) int64 {
+	start := lostAt.Add(-gapOverlap).UnixMilli()
+	if mark > 0 {
+		start = mark - gapOverlap.Milliseconds()
+	}
+	return max(start, now.Add(-maxBackfill).UnixMilli())
+}
+
+// Gap backfill loaded after a reconnect
+type gapBackfillMsg struct {
+	User     string
+	Fills    []api.Fill
+	Fundings []api.FundingPayment
+	Orders   []api.OpenOrder
+	Err      error
+}
+
+// backfillGap fetches fills and funding since each stream's mark, and the
+// open orders, to cover what the WebSocket missed while it was down.
+func (m Model) backfillGap() tea.Cmd {
+	client, ctx, user, marks, lostAt := m.api, m.ctx, m.cfg.Address, m.marks, m.wsLostAt
+	return func() tea.Msg {
+		now := time.Now()
+		var (
+			wg       sync.WaitGroup
+			msg      = gapBackfillMsg{User: user}
+			errs     [3]error
+			fills    []api.Fill
+			fundings []api.FundingPayment
+			orders   []api.OpenOrder
+		)
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			fills, errs[0] = client.GetUserFillsByTime(ctx, user, gapStart(marks.fills, lostAt, now))
+		}()
+		go func() {
+			defer wg.Done()
+			fundings, errs[1] = client.GetUserFunding(ctx, user, gapStart(marks.fundings, lostAt, now))
+		}()
+		go func() {
+			defer wg.Done()
+			orders, errs[2] = client.GetOpenOrders(ctx, user)
+		}()
+		wg.Wait()
+
+		msg.Fills, msg.Fundings = fills, fundings
+		if errs[2] == nil {
+			// nil would read as "no orders open"
+			msg.Orders = orders
+			if msg.Orders == nil {
+				msg.Orders = []api.OpenOrder{}
+			}
+		}
+		for _, err := range errs {
+			if err != nil {
+				msg.Err = err
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// applyBackfill merges a gap backfill and reports what it recovered.
+func (m *Model) applyBackfill(msg gapBackfillMsg) {
+	if msg.User != m.cfg.Address {
+		return
+	}
+	fills := m.store.MergeFills(msg.Fills)
+	m.marks.sawFills(msg.Fills)
+	fundings := m.store.MergeFundingPayments(msg.Fundings)
+	m.marks.sawFundings(msg.Fundings)
+	var orders int
+	if msg.Orders != nil {
+		opened, closed := m.store.ReconcileOpenOrders(msg.Orders)
+		orders = opened + closed
+	}
+
+	var parts []string
+	if fills > 0 {
+		parts = append(parts, plural(fills, "fill"))
+	}
+	if fundings > 0 {
+		parts = append(parts, plural(fundings, "funding payment"))
+	}
+	if orders > 0 {
+		parts = append(parts, plural(orders, "order change"))
+	}
+	switch {
+	case len(parts) > 0:
+		m.alerts.Push("ws", "Recovered "+strings.Join(parts, ", ")+" missed while disconnected")
+	case msg.Err != nil:
+		m.alerts.Push("ws", "Gap backfill failed: "+msg.Err.Error())
+	}
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
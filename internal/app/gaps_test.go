@@ -0,0 +1,99 @@
+package app
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/fakehl"
+)
+
+func TestGapStart(t *testing.T) {
+	now := time.UnixMilli(100_000_000)
+	lostAt := now.Add(-time.Minute)
+	tests := []struct {
+		name string
+		mark int64
+		want int64
+	}{
+		{"from mark", now.Add(-10 * time.Minute).UnixMilli(), now.Add(-10*time.Minute - gapOverlap).UnixMilli()},
+		{"nothing seen", 0, lostAt.Add(-gapOverlap).UnixMilli()},
+		{"capped", 1, now.Add(-maxBackfill).UnixMilli()},
+	}
+	for _, tt := range tests {
+		if got := gapStart(tt.mark, lostAt, now); got != tt.want {
+			t.Errorf("%s: gapStart = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// loadedModel returns a model that has done its initial load from a fake
+// server.
+func loadedModel(t *testing.T) (Model, *fakehl.Server) {
+	t.Helper()
+	f, err := fakehl.LoadFixture("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := fakehl.New(f, fakehl.Scenario{}, 1)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	m := NewModel(config.NewDemo(srv.User(), ts.URL))
+	out, _ := m.Update(m.fetchInitialData()())
+	return out.(Model), srv
+}
+
+func TestBackfillRecoversGap(t *testing.T) {
+	m, srv := loadedModel(t)
+	fillsBefore := len(m.store.Fills())
+	ordersBefore := len(m.store.OpenOrders())
+
+	// The connection drops, and meanwhile a trade fills and an order rests
+	m.wsLostAt = time.Now().Add(-time.Second)
+	srv.Apply(fakehl.Trade{Coin: "ETH", Sz: 0.5})
+	srv.Apply(fakehl.PlaceOrder{Coin: "SOL", IsBuy: true, Sz: 10, Offset: 2})
+
+	out, _ := m.Update(m.backfillGap()())
+	m = out.(Model)
+	if got := len(m.store.Fills()); got != fillsBefore+1 {
+		t.Errorf("fills = %d, want %d", got, fillsBefore+1)
+	}
+	if got := len(m.store.OpenOrders()); got != ordersBefore+1 {
+		t.Errorf("open orders = %d, want %d", got, ordersBefore+1)
+	}
+	// The initial load only gets the first page of a week of funding, so
+	// the backfill also picks up the newest payments
+	if a, ok := m.alerts.Latest(); !ok || !strings.HasPrefix(a.Message, "Recovered 1 fill, ") || !strings.HasSuffix(a.Message, ", 1 order change missed while disconnected") {
+		t.Errorf("alert = %q", a.Message)
+	}
+
+	// A second reconnect over the same window adds nothing
+	out, _ = m.Update(m.backfillGap()())
+	m = out.(Model)
+	if got := len(m.store.Fills()); got != fillsBefore+1 {
+		t.Errorf("after repeat backfill fills = %d, want %d", got, fillsBefore+1)
+	}
+	fills := m.store.Fills()
+	for i := 1; i < len(fills); i++ {
+		if fills[i].Time > fills[i-1].Time {
+			t.Fatalf("fills not newest first at %d", i)
+		}
+	}
+}
+
+func TestBackfillDroppedAfterWalletSwitch(t *testing.T) {
+	m, srv := loadedModel(t)
+	m.wsLostAt = time.Now()
+	srv.Apply(fakehl.Trade{Coin: "ETH", Sz: 0.5})
+	msg := m.backfillGap()().(gapBackfillMsg)
+
+	fills := len(m.store.Fills())
+	m.cfg.Address = "0x0000000000000000000000000000000000000001"
+	m.applyBackfill(msg)
+	if len(m.store.Fills()) != fills {
+		t.Error("backfill for the previous wallet was applied")
+	}
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// midHistorySaveEvery is how often the mids history is written with
+// --keep-mids, besides on quit.
+const midHistorySaveEvery = 5 * time.Minute
+
+// midHistoryFile is ~/.config/hltui/mids.json. Mids from the other network
+// are not restored.
+type midHistoryFile struct {
+	Testnet bool                      `json:"testnet"`
+	Mids    map[string][]store.Sample `json:"mids"`
+}
+
+func midHistoryPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mids.json"), nil
+}
+
+// keepMids reports whether the mids history is saved and restored: only
+// with --keep-mids, and never for demo or replayed prices.
+func (m Model) keepMids() bool {
+	return m.cfg.KeepMids && !m.cfg.IsDemo && m.replay == nil
+}
+
+// loadMidHistory reads the mids an earlier run saved. A missing file is
+// not an error.
+func (m Model) loadMidHistory() tea.Cmd {
+	testnet := m.cfg.IsTestnet
+	return func() tea.Msg {
+		path, err := midHistoryPath()
+		if err != nil {
+			return midHistoryLoadedMsg{Err: err}
+		}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return midHistoryLoadedMsg{}
+		}
+		if err != nil {
+			return midHistoryLoadedMsg{Err: err}
+		}
+		var f midHistoryFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return midHistoryLoadedMsg{Err: err}
+		}
+		if f.Testnet != testnet {
+			return midHistoryLoadedMsg{}
+		}
+		return midHistoryLoadedMsg{Mids: f.Mids}
+	}
+}
+
+// saveMidHistory writes the mids history off the UI goroutine.
+func (m Model) saveMidHistory() tea.Cmd {
+	s, testnet := m.store, m.cfg.IsTestnet
+	return func() tea.Msg {
+		return midHistorySavedMsg{Err: writeMidHistory(s, testnet)}
+	}
+}
+
+// writeMidHistory writes s's mids history through a temporary file, so a
+// run killed mid-write leaves the previous one in place.
+func writeMidHistory(s *store.Store, testnet bool) error {
+	path, err := midHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(midHistoryFile{Testnet: testnet, Mids: s.MidHistory()})
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func midHistoryTick() tea.Cmd {
+	return tea.Tick(midHistorySaveEvery, func(time.Time) tea.Msg {
+		return midHistoryTickMsg{}
+	})
+}
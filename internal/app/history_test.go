@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+func TestMidHistoryKept(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+	prev := util.Now
+	util.Now = func() time.Time { return now }
+	t.Cleanup(func() { util.Now = prev })
+
+	newModel := func(testnet bool) Model {
+		cfg := config.New("0x0000000000000000000000000000000000000001", testnet, false)
+		cfg.KeepMids = true
+		return NewModel(cfg)
+	}
+
+	m := newModel(false)
+	m.store.SetMetaAndAssetCtxs(&api.MetaAndAssetCtxs{
+		Meta:      api.Meta{Universe: []api.AssetMeta{{Name: "BTC"}}},
+		AssetCtxs: []api.AssetCtx{{}},
+	})
+	m.store.UpdateMids(api.AllMids{"BTC": decimal.MustParse("100")})
+	now = now.Add(time.Hour)
+	m.store.UpdateMids(api.AllMids{"BTC": decimal.MustParse("105")})
+	out, _ := m.Update(m.saveMidHistory()())
+	if m = out.(Model); m.errMsg != "" {
+		t.Fatalf("errMsg = %q", m.errMsg)
+	}
+
+	// The next run restores it
+	next := newModel(false)
+	out, _ = next.Update(next.loadMidHistory()())
+	next = out.(Model)
+	if pct, ok := next.store.MidChange("BTC", time.Hour); !ok || pct != 5 {
+		t.Errorf("restored 1h change = %v, %v; want 5", pct, ok)
+	}
+
+	// but not on the other network
+	testnet := newModel(true)
+	out, _ = testnet.Update(testnet.loadMidHistory()())
+	testnet = out.(Model)
+	if h := testnet.store.MidHistory(); len(h) != 0 {
+		t.Errorf("testnet restored mainnet mids: %v", h)
+	}
+
+	demo := config.NewDemo("0x0000000000000000000000000000000000000001", "http://127.0.0.1:1")
+	demo.KeepMids = true
+	if NewModel(demo).keepMids() {
+		t.Error("demo prices kept")
+	}
+}
@@ -15,10 +15,13 @@ type KeyMap struct {
 	View4        key.Binding
 	View5        key.Binding
 	View6        key.Binding
+	View7        key.Binding
+	View8        key.Binding
 	Up           key.Binding
 	Down         key.Binding
 	Refresh      key.Binding
 	Help         key.Binding
+	Errors       key.Binding
 	WalletPicker key.Binding
 }
 
@@ -50,6 +53,8 @@ var Keys = KeyMap{
 	View4: key.NewBinding(key.WithKeys("4"), key.WithHelp("4", "funding")),
 	View5: key.NewBinding(key.WithKeys("5"), key.WithHelp("5", "portfolio")),
 	View6: key.NewBinding(key.WithKeys("6"), key.WithHelp("6", "vaults")),
+	View7: key.NewBinding(key.WithKeys("7"), key.WithHelp("7", "staking")),
+	View8: key.NewBinding(key.WithKeys("8"), key.WithHelp("8", "scanner")),
 	Up: key.NewBinding(
 		key.WithKeys("k", "up"),
 		key.WithHelp("k/up", "scroll up"),
@@ -66,6 +71,10 @@ var Keys = KeyMap{
 		key.WithKeys(";"),
 		key.WithHelp(";", "help"),
 	),
+	Errors: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "data source errors"),
+	),
 	WalletPicker: key.NewBinding(
 		key.WithKeys("w"),
 		key.WithHelp("w", "switch wallet"),
@@ -2,6 +2,7 @@ package app
 
 import (
 	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/ws"
 )
 
@@ -16,17 +17,28 @@ type InitialDataMsg struct {
 	Portfolio []api.PortfolioPeriod
 	Fees      *api.UserFees
 	Vaults    []api.VaultEquity
-	Err       error
+	// Vault mode only: details of the monitored vault itself
+	ManagedVault *api.VaultDetails
+	RateLimit    *api.UserRateLimit
+	// Outcome of each source fetched, by store.Source* name; the data of
+	// any that failed is left as it was. Err is the account source's.
+	Errs map[string]error
+	Err  error
 }
 
-// WebSocket message received
+// WebSocket messages received since the last batch, in arrival order
 type WSMsg struct {
-	Msg ws.Message
+	Msgs []ws.Message
 }
 
 // Periodic refresh tick
 type RefreshTickMsg struct{}
 
+// Replay playback started, reached a recorded refresh, or ran out of entries
+type replayStartedMsg struct{}
+type replayRefreshMsg struct{}
+type replayDoneMsg struct{}
+
 // Vault details loaded
 type VaultDetailsMsg struct {
 	Address string
@@ -34,12 +46,97 @@ type VaultDetailsMsg struct {
 	Err     error
 }
 
+// Public vault list loaded for the explorer
+type VaultSummariesMsg struct {
+	Summaries []api.VaultSummary
+	Err       error
+}
+
+// One step of the background explorer enrichment: details for Address. User
+// is the wallet the request was made for, so results arriving after a wallet
+// switch can be dropped.
+type vaultEnrichMsg struct {
+	User    string
+	Address string
+	Details *api.VaultDetails
+	Err     error
+}
+
+// Vault equities for every configured wallet, keyed by wallet address
+type WalletVaultsMsg struct {
+	Equities map[string][]api.VaultEquity
+}
+
+// HYPE staking data loaded
+type StakingMsg struct {
+	Summary     *api.DelegatorSummary
+	Delegations []api.Delegation
+	Rewards     []api.DelegatorReward
+	History     []api.DelegatorEvent
+	Validators  []api.ValidatorSummary
+	// Err is the first failure of the wallet's staking requests; the
+	// validator list is global and fails separately
+	Err           error
+	ValidatorsErr error
+}
+
+// Predicted next funding across venues loaded
+type PredictedFundingsMsg struct {
+	Fundings api.PredictedFundings
+	Err      error
+}
+
+// Funding rate history loaded for one coin
+type FundingHistoryMsg struct {
+	Coin    string
+	Entries []api.FundingHistoryEntry
+	Err     error
+}
+
+// Order book and candles loaded for the asset detail screen
+type AssetDetailMsg struct {
+	Coin       string
+	Book       *api.L2Book
+	BookErr    error
+	Candles    []api.Candle
+	CandlesErr error
+}
+
+// Sort preferences written to disk
+type sortPrefsSavedMsg struct {
+	Err error
+}
+
+// Watchlist written to disk
+type watchlistSavedMsg struct {
+	Err error
+}
+
+// Mids history saved by an earlier run read from disk
+type midHistoryLoadedMsg struct {
+	Mids map[string][]store.Sample
+	Err  error
+}
+
+// Mids history written to disk
+type midHistorySavedMsg struct {
+	Err error
+}
+
+// Time to write the mids history
+type midHistoryTickMsg struct{}
+
 // Error message
 type ErrMsg struct {
 	Err error
 }
 
-// WS connection status changed
+// WS connection status changed. client is the connection that reported
+// it, so events from a connection closed by a wallet switch can be dropped.
 type WSStatusMsg struct {
-	Connected bool
+	Status ws.Status
+	client *ws.Client
 }
+
+// Once a second, to keep data ages current
+type ageTickMsg struct{}
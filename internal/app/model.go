@@ -1,28 +1,48 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/born1337/hyperliquid-terminal/internal/alerts"
+	"github.com/born1337/hyperliquid-terminal/internal/anomaly"
 	"github.com/born1337/hyperliquid-terminal/internal/api"
 	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/record"
 	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
 	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
 	"github.com/born1337/hyperliquid-terminal/internal/views/fills"
 	"github.com/born1337/hyperliquid-terminal/internal/views/funding"
 	"github.com/born1337/hyperliquid-terminal/internal/views/market"
 	"github.com/born1337/hyperliquid-terminal/internal/views/orders"
 	"github.com/born1337/hyperliquid-terminal/internal/views/portfolio"
 	"github.com/born1337/hyperliquid-terminal/internal/views/positions"
+	"github.com/born1337/hyperliquid-terminal/internal/views/scanner"
+	"github.com/born1337/hyperliquid-terminal/internal/views/staking"
+	"github.com/born1337/hyperliquid-terminal/internal/views/vaultmgr"
 	"github.com/born1337/hyperliquid-terminal/internal/views/vaults"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
-const maxFills = 5000
+
+// Vault explorer enrichment: how many of the largest vaults get their details
+// fetched in the background on top of the ones in view, and the pause
+// between requests so the explorer doesn't eat the request budget.
+const (
+	maxEnrichVaults  = 30
+	vaultEnrichDelay = 2 * time.Second
+)
 
 const (
 	ViewMarket = iota
@@ -32,6 +52,10 @@ const (
 	ViewFunding
 	ViewPortfolio
 	ViewVaults
+	ViewStaking
+	ViewScanner
+
+	numViews = ViewScanner + 1
 )
 
 type Model struct {
@@ -39,16 +63,53 @@ type Model struct {
 	store  *store.Store
 	api    *api.Client
 	ws     *ws.Client
-	wsCh   chan ws.Message
+	feed   *ws.Feed
+
+	// Scopes the active wallet's requests; canceled when switching away
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	activeView  int
 	showHelp    bool
+	showErrors  bool
 	width       int
 	height      int
 	errMsg      string
-	wsConnected bool
+	wsState     ws.State
 	loading     bool
 
+	// When account data was last loaded over REST and streamed over
+	// webData2, and when staking was loaded, for data ages
+	lastRefresh time.Time
+	lastLive    time.Time
+	lastStaking time.Time
+
+	// Newest event seen per user stream, and when the WS last dropped, for
+	// backfilling the gap after a reconnect
+	marks    streamMarks
+	wsLostAt time.Time
+
+	// Vault explorer background enrichment in progress, and the vaults it
+	// has asked for
+	vaultEnriching   bool
+	vaultEnrichTried map[string]bool
+
+	// Alerts raised by data sources, and lockup-expiry alerting state
+	alerts          *alerts.Log
+	lockupAlerts    bool
+	lastLockupCheck time.Time
+
+	// Scanner alerting state: the flags raised at the previous check, and
+	// whether asset contexts have landed since, set from the store's events
+	scanAlerts      bool
+	scanFlagged     map[scanFlag]bool
+	contextsUpdated *atomic.Bool
+
+	// Session recording and replay; nil when not in use
+	recorder *record.Writer
+	replay   *record.Player
+	replayCh chan tea.Msg
+
 	// Wallet picker
 	showWalletPicker bool
 	walletCursor     int
@@ -70,17 +131,22 @@ type Model struct {
 	funding   funding.Model
 	portfolio portfolio.Model
 	vaults    vaults.Model
+	vaultMgr  vaultmgr.Model
+	staking   staking.Model
+	scanner   scanner.Model
+
+	// Asset detail screen, shown over the active view while open
+	showDetail bool
+	detail     asset.Model
 }
 
 func NewModel(cfg *config.Config) Model {
 	s := store.New()
-	wsCh := make(chan ws.Message, 256)
 
-	return Model{
+	m := Model{
 		cfg:    cfg,
 		store:  s,
-		api:    api.NewClient(cfg.InfoURL()),
-		wsCh:   wsCh,
+		feed:   ws.NewFeed(),
 		loading: true,
 
 		market:    market.New(s),
@@ -90,24 +156,92 @@ func NewModel(cfg *config.Config) Model {
 		funding:   funding.New(s),
 		portfolio: portfolio.New(s),
 		vaults:    vaults.New(s),
+		vaultMgr:  vaultmgr.New(s),
+		staking:   staking.New(s),
+		scanner:   scanner.New(s),
+
+		alerts:     alerts.NewLog(),
+		scanAlerts: true,
+	}
+	m.api = m.newAPIClient()
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	updated := new(atomic.Bool)
+	s.Subscribe(func(e store.Event) {
+		if _, ok := e.(store.ContextsUpdated); ok {
+			updated.Store(true)
+		}
+	})
+	m.contextsUpdated = updated
+	m.vaults.SetWallets(cfg.Wallets, cfg.Address)
+	if cfg.Scan != nil {
+		m.scanner.SetThresholds(anomaly.Thresholds(*cfg.Scan))
 	}
+	m.scanner.SetAlerts(m.scanAlerts)
+	m.applyPrefs()
+	return m
+}
+
+// WithRecorder returns the model with every REST response and WebSocket
+// message written to w.
+func (m Model) WithRecorder(w *record.Writer) Model {
+	m.recorder = w
+	m.api = m.newAPIClient()
+	return m
+}
+
+// NewReplayModel creates a model that plays back a recording instead of
+// talking to the network: REST requests are answered from the recording and
+// WebSocket messages are fed through handleWSMessage on the replay clock.
+// util.Now follows the replay clock too, so countdowns, ages, filters and
+// the mid and context histories see recorded time.
+func NewReplayModel(cfg *config.Config, p *record.Player) Model {
+	util.Now = func() time.Time { return time.UnixMilli(p.Now()) }
+	m := NewModel(cfg)
+	m.replay = p
+	m.replayCh = make(chan tea.Msg, 16)
+	m.api = m.newAPIClient()
+	return m
+}
+
+func (m Model) newAPIClient() *api.Client {
+	var c *api.Client
+	if m.replay != nil {
+		c = api.NewClientWithTransport(m.cfg.InfoURL(), m.replay.Transport())
+	} else {
+		c = api.NewClient(m.cfg.InfoURL())
+	}
+	if m.recorder != nil {
+		c.OnResponse(m.recorder.REST)
+	}
+	return c
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	stream := m.connectWS()
+	if m.replay != nil {
+		stream = m.startReplay()
+	}
+	cmds := []tea.Cmd{
 		m.fetchInitialData(),
-		m.connectWS(),
-	)
+		m.fetchWalletVaults(),
+		m.fetchStaking(),
+		stream,
+		ageTick(),
+	}
+	if m.keepMids() {
+		cmds = append(cmds, m.loadMidHistory(), midHistoryTick())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m Model) fetchInitialData() tea.Cmd {
+	ctx := m.ctx
 	return func() tea.Msg {
 		addr := m.cfg.Address
 		weekAgo := time.Now().Add(-7 * 24 * time.Hour).UnixMilli()
 
 		var (
 			state     *api.ClearinghouseState
-			stateErr  error
 			mids      api.AllMids
 			meta      *api.MetaAndAssetCtxs
 			openOrders []api.OpenOrder
@@ -116,30 +250,47 @@ func (m Model) fetchInitialData() tea.Cmd {
 			portfolio  []api.PortfolioPeriod
 			fees       *api.UserFees
 			vaultEq    []api.VaultEquity
+			managed    *api.VaultDetails
+			rateLimit  *api.UserRateLimit
 		)
 
-		var wg sync.WaitGroup
-		wg.Add(9)
-
-		go func() { defer wg.Done(); state, stateErr = m.api.GetClearinghouseState(addr) }()
-		go func() { defer wg.Done(); mids, _ = m.api.GetAllMids() }()
-		go func() { defer wg.Done(); meta, _ = m.api.GetMetaAndAssetCtxs() }()
-		go func() { defer wg.Done(); openOrders, _ = m.api.GetOpenOrders(addr) }()
-		go func() { defer wg.Done(); userFills, _ = m.api.GetUserFills(addr) }()
-		go func() { defer wg.Done(); fundingPay, _ = m.api.GetUserFunding(addr, weekAgo) }()
-		go func() { defer wg.Done(); portfolio, _ = m.api.GetPortfolio(addr) }()
-		go func() { defer wg.Done(); fees, _ = m.api.GetUserFees(addr) }()
-		go func() { defer wg.Done(); vaultEq, _ = m.api.GetUserVaultEquities(addr) }()
-
-		wg.Wait()
+		// Each source is fetched in parallel and its outcome kept, so one
+		// failing doesn't hide the others
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs = make(map[string]error)
+		)
+		fetch := func(source string, f func() error) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := f()
+				mu.Lock()
+				errs[source] = err
+				mu.Unlock()
+			}()
+		}
 
-		if stateErr != nil {
-			return InitialDataMsg{Err: stateErr}
+		fetch(store.SourceAccount, func() (err error) { state, err = m.api.GetClearinghouseState(ctx, addr); return })
+		fetch(store.SourceMids, func() (err error) { mids, err = m.api.GetAllMids(ctx); return })
+		fetch(store.SourceMeta, func() (err error) { meta, err = m.api.GetMetaAndAssetCtxs(ctx); return })
+		fetch(store.SourceOrders, func() (err error) { openOrders, err = m.api.GetOpenOrders(ctx, addr); return })
+		fetch(store.SourceFills, func() (err error) { userFills, err = m.api.GetUserFills(ctx, addr); return })
+		fetch(store.SourceFunding, func() (err error) { fundingPay, err = m.api.GetUserFunding(ctx, addr, weekAgo); return })
+		fetch(store.SourcePortfolio, func() (err error) { portfolio, err = m.api.GetPortfolio(ctx, addr); return })
+		fetch(store.SourceFees, func() (err error) { fees, err = m.api.GetUserFees(ctx, addr); return })
+		fetch(store.SourceVaults, func() (err error) { vaultEq, err = m.api.GetUserVaultEquities(ctx, addr); return })
+		fetch(store.SourceRateLimit, func() (err error) { rateLimit, err = m.api.GetUserRateLimit(ctx, addr); return })
+		if m.cfg.IsVault {
+			fetch(store.SourceVaultDetails, func() (err error) { managed, err = m.api.GetVaultDetails(ctx, addr, addr); return })
 		}
 
+		wg.Wait()
+
 		// Cap fills to prevent unbounded growth
-		if len(userFills) > maxFills {
-			userFills = userFills[:maxFills]
+		if len(userFills) > store.MaxFills {
+			userFills = userFills[:store.MaxFills]
 		}
 
 		return InitialDataMsg{
@@ -152,44 +303,135 @@ func (m Model) fetchInitialData() tea.Cmd {
 			Portfolio: portfolio,
 			Fees:      fees,
 			Vaults:    vaultEq,
+
+			ManagedVault: managed,
+			RateLimit:    rateLimit,
+
+			Errs: errs,
+			Err:  errs[store.SourceAccount],
 		}
 	}
 }
 
 func (m Model) connectWS() tea.Cmd {
 	return func() tea.Msg {
-		client := ws.NewClient(m.cfg.WSBaseURL, m.wsCh)
-		if err := client.Connect(); err != nil {
-			return WSStatusMsg{Connected: false}
+		client := ws.NewClient(m.cfg.WSBaseURL, m.feed)
+		if m.recorder != nil {
+			client.OnMessage(m.recorder.WS)
 		}
 
-		// Subscribe
+		// Queued, then sent on every (re)connect
 		client.Subscribe(ws.SubAllMids())
 		client.Subscribe(ws.SubUserFills(m.cfg.Address))
 		client.Subscribe(ws.SubUserFundings(m.cfg.Address))
 		client.Subscribe(ws.SubOrderUpdates(m.cfg.Address))
+		client.Subscribe(ws.SubWebData2(m.cfg.Address))
 
-		return wsConnectedMsg{client: client}
+		// A failed first dial keeps retrying; the status channel reports it
+		client.Start()
+		return wsStartedMsg{client: client}
 	}
 }
 
-type wsConnectedMsg struct {
+type wsStartedMsg struct {
 	client *ws.Client
 }
 
-func waitForWS(ch chan ws.Message) tea.Cmd {
+// waitForWSStatus delivers the client's next connection state change. It
+// stops once the client is closed.
+func waitForWSStatus(client *ws.Client) tea.Cmd {
 	return func() tea.Msg {
-		msg := <-ch
-		return WSMsg{Msg: msg}
+		s, ok := <-client.Status()
+		if !ok {
+			return nil
+		}
+		return WSStatusMsg{Status: s, client: client}
+	}
+}
+
+// waitForWS delivers everything queued on the feed as one batch. It stops
+// once the feed is closed.
+func waitForWS(feed *ws.Feed) tea.Cmd {
+	return func() tea.Msg {
+		msgs, ok := feed.Next()
+		if !ok {
+			return nil
+		}
+		return WSMsg{Msgs: msgs}
 	}
 }
 
+// startReplay plays the recording in the background. WebSocket messages go
+// through the normal feed; each recorded clearinghouseState marks a
+// refresh cycle, so the app refetches (from the recording) at the same
+// points it did live.
+func (m Model) startReplay() tea.Cmd {
+	p, feed, ch := m.replay, m.feed, m.replayCh
+	return func() tea.Msg {
+		go func() {
+			p.Run(nil, feed.Push, func(e record.Entry) {
+				if e.RESTType() == "clearinghouseState" {
+					ch <- replayRefreshMsg{}
+				}
+			})
+			ch <- replayDoneMsg{}
+		}()
+		return replayStartedMsg{}
+	}
+}
+
+func waitForReplay(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func ageTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return ageTickMsg{}
+	})
+}
+
 func refreshTick() tea.Cmd {
 	return tea.Tick(30*time.Second, func(time.Time) tea.Msg {
 		return RefreshTickMsg{}
 	})
 }
 
+// viewSources are the REST sources each view's data comes from.
+var viewSources = [numViews][]string{
+	ViewMarket:    {store.SourceMids, store.SourceMeta},
+	ViewPositions: {store.SourceAccount},
+	ViewOrders:    {store.SourceOrders},
+	ViewFills:     {store.SourceFills},
+	ViewFunding:   {store.SourceFunding, store.SourcePredictedFunding, store.SourceFundingHistory},
+	ViewPortfolio: {store.SourcePortfolio, store.SourceFees},
+	ViewVaults:    {store.SourceVaults, store.SourceVaultDetails, store.SourceVaultList},
+	ViewStaking:   {store.SourceStaking, store.SourceValidators},
+	ViewScanner:   {store.SourceMids, store.SourceMeta},
+}
+
+// failingTabs flags the views with a source whose latest fetch failed.
+func (m Model) failingTabs() []bool {
+	failing := make([]bool, numViews)
+	for v, sources := range viewSources {
+		failing[v] = len(m.store.Failing(sources...)) > 0
+	}
+	return failing
+}
+
+// tabNames returns the tab labels for the active wallet. In vault mode the
+// Vaults tab shows the Vault Manager for the monitored vault instead of the
+// wallet's own vault investments.
+func (m Model) tabNames() []string {
+	names := make([]string, len(ui.TabNames))
+	copy(names, ui.TabNames)
+	if m.cfg.IsVault {
+		names[ViewVaults] = "Vault Mgr"
+	}
+	return names
+}
+
 func (m *Model) handleWSMessage(msg ws.Message) {
 	switch msg.Channel {
 	case "allMids":
@@ -197,53 +439,339 @@ func (m *Model) handleWSMessage(msg ws.Message) {
 		if err := json.Unmarshal(msg.Data, &data); err == nil {
 			m.store.UpdateMids(data.Mids)
 		}
+	case "webData2":
+		var data ws.WebData2
+		if err := json.Unmarshal(msg.Data, &data); err == nil && strings.EqualFold(data.User, m.cfg.Address) {
+			m.store.ApplyWebData2(data)
+			m.lastLive = util.Now()
+		}
 	case "orderUpdates":
 		// Apply delta updates from WS instead of full refetch
 		var updates []ws.OrderUpdate
 		if err := json.Unmarshal(msg.Data, &updates); err == nil {
 			m.store.ApplyOrderUpdates(updates)
+			m.marks.sawOrders(updates)
+		}
+	case "userFills":
+		// Snapshots (sent again on every resubscribe) overlap what is
+		// held; merging dedupes them
+		var data ws.UserFills
+		if err := json.Unmarshal(msg.Data, &data); err == nil {
+			m.mergeWSFills(data.Fills)
+		}
+	case "userFundings":
+		var data ws.UserFundings
+		if err := json.Unmarshal(msg.Data, &data); err == nil && len(data.Fundings) > 0 {
+			payments := make([]api.FundingPayment, len(data.Fundings))
+			for i, f := range data.Fundings {
+				payments[i] = api.FundingPayment{Time: f.Time, Coin: f.Coin, Usdc: f.Usdc, Szi: f.Szi, FundingRate: f.FundingRate}
+			}
+			m.store.MergeFundingPayments(payments)
+			m.marks.sawFundings(payments)
 		}
 	case "user":
 		var event ws.UserEvent
 		if err := json.Unmarshal(msg.Data, &event); err == nil {
-			if len(event.Fills) > 0 {
-				m.store.Lock()
-				newFills := make([]api.Fill, len(event.Fills))
-				for i, f := range event.Fills {
-					newFills[i] = api.Fill{
-						Coin:      f.Coin,
-						Px:        f.Px,
-						Sz:        f.Sz,
-						Side:      f.Side,
-						Time:      f.Time,
-						ClosedPnl: f.ClosedPnl,
-						Hash:      f.Hash,
-						Fee:       f.Fee,
-						Tid:       f.Tid,
-						Dir:       f.Dir,
-					}
-				}
-				combined := append(newFills, m.store.Fills...)
-				if len(combined) > maxFills {
-					combined = combined[:maxFills]
-				}
-				m.store.Fills = combined
-				m.store.Unlock()
-			}
+			m.mergeWSFills(event.Fills)
 		}
 	}
 }
 
+// mergeWSFills adds fills from a WS event, skipping any already held.
+func (m *Model) mergeWSFills(fills []ws.UserFillWs) {
+	if len(fills) == 0 {
+		return
+	}
+	newFills := make([]api.Fill, len(fills))
+	for i, f := range fills {
+		newFills[i] = api.Fill{
+			Coin:      f.Coin,
+			Px:        f.Px,
+			Sz:        f.Sz,
+			Side:      f.Side,
+			Time:      f.Time,
+			ClosedPnl: f.ClosedPnl,
+			Hash:      f.Hash,
+			Fee:       f.Fee,
+			Tid:       f.Tid,
+			Oid:       f.Oid,
+			Dir:       f.Dir,
+		}
+	}
+	m.store.MergeFills(newFills)
+	m.marks.sawFills(newFills)
+}
+
 func (m Model) fetchVaultDetails(addr string) tea.Cmd {
+	ctx := m.ctx
 	return func() tea.Msg {
-		details, err := m.api.GetVaultDetails(addr, m.cfg.Address)
+		details, err := m.api.GetVaultDetails(ctx, addr, m.cfg.Address)
 		return VaultDetailsMsg{Address: addr, Details: details, Err: err}
 	}
 }
 
+func (m Model) fetchPredictedFundings() tea.Cmd {
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		fundings, err := client.GetPredictedFundings(ctx)
+		return PredictedFundingsMsg{Fundings: fundings, Err: err}
+	}
+}
+
+// fundingHistoryPage is the most entries fundingHistory returns per call.
+const fundingHistoryPage = 500
+
+// fetchFundingHistory loads 30 days of hourly funding for coin, paging
+// forward from the start time until a short page comes back.
+func (m Model) fetchFundingHistory(coin string) tea.Cmd {
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		start := time.Now().Add(-30 * 24 * time.Hour).UnixMilli()
+		var all []api.FundingHistoryEntry
+		for {
+			page, err := client.GetFundingHistory(ctx, coin, start)
+			if err != nil {
+				return FundingHistoryMsg{Coin: coin, Err: err}
+			}
+			all = append(all, page...)
+			if len(page) < fundingHistoryPage {
+				break
+			}
+			start = page[len(page)-1].Time + 1
+		}
+		return FundingHistoryMsg{Coin: coin, Entries: all}
+	}
+}
+
+// detailCandles is the interval and span of the asset detail price chart.
+const (
+	detailCandleInterval = "1h"
+	detailCandleSpan     = 7 * 24 * time.Hour
+)
+
+// fetchAssetDetail loads coin's order book and recent candles for the
+// asset detail screen.
+func (m Model) fetchAssetDetail(coin string) tea.Cmd {
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		msg := AssetDetailMsg{Coin: coin}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			msg.Book, msg.BookErr = client.GetL2Book(ctx, coin)
+		}()
+		go func() {
+			defer wg.Done()
+			end := time.Now()
+			msg.Candles, msg.CandlesErr = client.GetCandleSnapshot(ctx, coin, detailCandleInterval, end.Add(-detailCandleSpan).UnixMilli(), end.UnixMilli())
+		}()
+		wg.Wait()
+		return msg
+	}
+}
+
+// fetchStaking loads HYPE staking state for the active wallet. Staking
+// changes slowly, so it is fetched on startup, wallet switch and manual
+// refresh rather than on every refresh tick.
+func (m Model) fetchStaking() tea.Cmd {
+	addr := m.cfg.Address
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		var msg StakingMsg
+		var errs [4]error
+		var wg sync.WaitGroup
+		wg.Add(5)
+		go func() { defer wg.Done(); msg.Summary, errs[0] = client.GetDelegatorSummary(ctx, addr) }()
+		go func() { defer wg.Done(); msg.Delegations, errs[1] = client.GetDelegations(ctx, addr) }()
+		go func() { defer wg.Done(); msg.Rewards, errs[2] = client.GetDelegatorRewards(ctx, addr) }()
+		go func() { defer wg.Done(); msg.History, errs[3] = client.GetDelegatorHistory(ctx, addr) }()
+		go func() { defer wg.Done(); msg.Validators, msg.ValidatorsErr = client.GetValidatorSummaries(ctx) }()
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				msg.Err = err
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// fetchWalletVaults loads vault equities for every configured wallet on the
+// active network, for the cross-wallet unlock timeline.
+func (m Model) fetchWalletVaults() tea.Cmd {
+	var addrs []string
+	for _, w := range m.cfg.Wallets {
+		if w.Testnet == m.cfg.IsTestnet {
+			addrs = append(addrs, w.Address)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil
+	}
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		equities := make(map[string][]api.VaultEquity, len(addrs))
+		for _, addr := range addrs {
+			wg.Add(1)
+			go func(addr string) {
+				defer wg.Done()
+				eq, err := client.GetUserVaultEquities(ctx, addr)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				equities[addr] = eq
+				mu.Unlock()
+			}(addr)
+		}
+		wg.Wait()
+		return WalletVaultsMsg{Equities: equities}
+	}
+}
+
+// checkLockupAlerts raises an alert for every vault lockup (in any wallet)
+// that ended since the previous check.
+func (m *Model) checkLockupAlerts(now time.Time) {
+	since := m.lastLockupCheck.UnixMilli()
+	m.lastLockupCheck = now
+	for _, u := range m.store.VaultUnlocks(m.cfg.Address) {
+		if u.UnlockAt <= since || u.UnlockAt > now.UnixMilli() {
+			continue
+		}
+		name := m.store.VaultName(u.VaultAddress)
+		if name == "" {
+			name = config.TruncateAddress(u.VaultAddress)
+		}
+		wallet := config.TruncateAddress(u.Wallet)
+		for _, w := range m.cfg.Wallets {
+			if strings.EqualFold(w.Address, u.Wallet) {
+				wallet = w.Name
+				break
+			}
+		}
+		m.alerts.Push("lockup", fmt.Sprintf("Lockup ended: %s (%s) %s now withdrawable", name, wallet, util.FormatUSD(u.Equity)))
+	}
+}
+
+// scanFlag identifies a scanner flag across checks.
+type scanFlag struct {
+	coin   string
+	signal anomaly.Signal
+}
+
+// checkScanAlerts raises an alert for every flag the scanner raises that
+// it didn't at the previous check, under the Scanner view's rules. A flag
+// that clears and trips again alerts again. It runs whenever new asset
+// contexts land.
+func (m *Model) checkScanAlerts() {
+	rules := m.scanner.Rules()
+	flags := anomaly.Scan(m.store, rules)
+	// Lowest score first, so the strongest is the latest alert
+	for i := len(flags) - 1; i >= 0; i-- {
+		if f := flags[i]; !m.scanFlagged[scanFlag{f.Coin, f.Signal}] {
+			m.alerts.Push("scanner", f.Describe(rules.Window))
+		}
+	}
+	m.scanFlagged = flagSet(flags)
+}
+
+func flagSet(flags []anomaly.Flag) map[scanFlag]bool {
+	set := make(map[scanFlag]bool, len(flags))
+	for _, f := range flags {
+		set[scanFlag{f.Coin, f.Signal}] = true
+	}
+	return set
+}
+
+// syncVaultWallets refreshes the vaults view's wallet labels after the wallet
+// list or active wallet changes.
+func (m *Model) syncVaultWallets() {
+	m.vaults.SetWallets(m.cfg.Wallets, m.cfg.Address)
+	m.vaults.SetLockupAlerts(m.lockupAlerts)
+}
+
+func (m Model) fetchVaultSummaries() tea.Cmd {
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		summaries, err := client.GetVaultSummaries(ctx)
+		return VaultSummariesMsg{Summaries: summaries, Err: err}
+	}
+}
+
+// startVaultEnrichment starts fetching vaultDetails for the explorer's
+// vaults one at a time. Returns nil if nothing needs fetching or a chain is
+// already running.
+func (m *Model) startVaultEnrichment() tea.Cmd {
+	if m.vaultEnriching {
+		return nil
+	}
+	addr := m.nextEnrichVault()
+	if addr == "" {
+		return nil
+	}
+	m.vaultEnriching = true
+	return m.enrichVault(addr, 0)
+}
+
+// nextEnrichVault picks the vault whose details to fetch next: the
+// explorer's cursor row and the rows in view first, then the largest open
+// vaults by TVL. Returns "" once every candidate has been asked for.
+func (m Model) nextEnrichVault() string {
+	details := m.store.VaultDetails()
+	wanted := func(addr string) bool {
+		_, ok := details[addr]
+		return !ok && !m.vaultEnrichTried[addr]
+	}
+	if m.activeView == ViewVaults && !m.cfg.IsVault {
+		for _, addr := range m.vaults.MissingDetails() {
+			if wanted(addr) {
+				return addr
+			}
+		}
+	}
+
+	var candidates []api.VaultSummary
+	for _, s := range m.store.VaultSummaries() {
+		if s.IsClosed || s.Relationship.Type == "child" {
+			continue
+		}
+		candidates = append(candidates, s)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Tvl.Cmp(candidates[j].Tvl) > 0
+	})
+	for _, s := range candidates[:min(len(candidates), maxEnrichVaults)] {
+		if wanted(s.VaultAddress) {
+			return s.VaultAddress
+		}
+	}
+	return ""
+}
+
+// enrichVault fetches details for addr after delay, as one step of the
+// enrichment chain.
+func (m *Model) enrichVault(addr string, delay time.Duration) tea.Cmd {
+	if m.vaultEnrichTried == nil {
+		m.vaultEnrichTried = make(map[string]bool)
+	}
+	m.vaultEnrichTried[addr] = true
+	user := m.cfg.Address
+	client, ctx := m.api, m.ctx
+	return func() tea.Msg {
+		time.Sleep(delay)
+		details, err := client.GetVaultDetails(ctx, addr, user)
+		return vaultEnrichMsg{User: user, Address: addr, Details: details, Err: err}
+	}
+}
+
 // switchWallet closes WS, switches config, clears store, and re-fetches data.
 func (m *Model) switchWallet(idx int) tea.Cmd {
-	if idx == m.cfg.ActiveWallet {
+	// Replays and recordings are pinned to one wallet
+	if idx == m.cfg.ActiveWallet || !m.walletsEditable() {
 		return nil
 	}
 
@@ -252,7 +780,14 @@ func (m *Model) switchWallet(idx int) tea.Cmd {
 		m.ws.Close()
 		m.ws = nil
 	}
-	m.wsConnected = false
+	m.wsState = ws.StateConnecting
+	m.lastRefresh, m.lastLive, m.lastStaking = time.Time{}, time.Time{}, time.Time{}
+	m.marks, m.wsLostAt = streamMarks{}, time.Time{}
+	m.vaultEnriching, m.vaultEnrichTried = false, nil
+
+	// Abandon the previous wallet's requests
+	m.cancel()
+	m.ctx, m.cancel = context.WithCancel(context.Background())
 
 	// Switch config (may change network)
 	networkChanged := m.cfg.SwitchToWallet(idx)
@@ -260,13 +795,14 @@ func (m *Model) switchWallet(idx int) tea.Cmd {
 	// Clear store
 	if networkChanged {
 		m.store.ClearAll()
-		m.api = api.NewClient(m.cfg.InfoURL())
+		m.api = m.newAPIClient()
 	} else {
 		m.store.ClearUserData()
 	}
 
-	// New WS channel so stale goroutines drain harmlessly into the old one
-	m.wsCh = make(chan ws.Message, 256)
+	// New feed; the old one is closed so its reader stops
+	m.feed.Close()
+	m.feed = ws.NewFeed()
 
 	// Reset per-wallet views
 	m.resetViewScrolls()
@@ -274,10 +810,11 @@ func (m *Model) switchWallet(idx int) tea.Cmd {
 	m.loading = true
 	m.errMsg = ""
 
-	return tea.Batch(
-		m.fetchInitialData(),
-		m.connectWS(),
-	)
+	cmds := []tea.Cmd{m.fetchInitialData(), m.fetchStaking(), m.connectWS()}
+	if networkChanged {
+		cmds = append(cmds, m.fetchWalletVaults())
+	}
+	return tea.Batch(cmds...)
 }
 
 // resetViewScrolls resets per-wallet view state (scroll positions, etc.)
@@ -288,9 +825,21 @@ func (m *Model) resetViewScrolls() {
 	m.funding = funding.New(m.store)
 	m.portfolio = portfolio.New(m.store)
 	m.vaults = vaults.New(m.store)
+	m.syncVaultWallets()
+	m.vaultMgr = vaultmgr.New(m.store)
+	m.staking = staking.New(m.store)
+	m.applyPrefs()
 	// Preserve market view state (sort, scroll, filter)
 }
 
+// walletsEditable reports whether the wallet picker may switch, add and
+// delete wallets. Demo and replay sessions' configs hold none of the saved
+// wallets, so saving from them would overwrite wallets.json, and a
+// recording's header names the one wallet it follows.
+func (m Model) walletsEditable() bool {
+	return !m.cfg.IsDemo && m.replay == nil && m.recorder == nil
+}
+
 // initWalletForm sets up the add-wallet form text inputs.
 func (m *Model) initWalletForm() {
 	nameInput := textinput.New()
@@ -328,7 +877,7 @@ func (m *Model) focusWalletFormField() {
 // deleteWalletAtCursor deletes the wallet at the current cursor position.
 func (m *Model) deleteWalletAtCursor() {
 	idx := m.walletCursor
-	if idx < 0 || idx >= len(m.cfg.Wallets) {
+	if !m.walletsEditable() || idx < 0 || idx >= len(m.cfg.Wallets) {
 		return
 	}
 	// Can't delete the currently active wallet
@@ -363,10 +912,15 @@ func (m *Model) deleteWalletAtCursor() {
 		persistStart = 1
 	}
 	_ = config.SaveWallets(m.cfg.Wallets[persistStart:])
+	m.syncVaultWallets()
 }
 
 // submitWalletForm validates and saves a new wallet.
 func (m *Model) submitWalletForm() {
+	if !m.walletsEditable() {
+		m.walletFormActive = false
+		return
+	}
 	addr := strings.TrimSpace(m.walletFormAddr.Value())
 	name := strings.TrimSpace(m.walletFormName.Value())
 
@@ -402,6 +956,7 @@ func (m *Model) submitWalletForm() {
 	}
 
 	m.cfg.Wallets = append(m.cfg.Wallets, w)
+	m.syncVaultWallets()
 	m.walletFormActive = false
 	m.walletCursor = len(m.cfg.Wallets) - 1
 }
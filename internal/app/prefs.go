@@ -0,0 +1,36 @@
+package app
+
+import (
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// applyPrefs restores the saved table sort orders in every view, and the
+// market watchlist.
+func (m *Model) applyPrefs() {
+	m.market.SetWatchlist(m.cfg.Watchlist)
+	prefs := m.cfg.SortPrefs
+	m.market.SetSortPrefs(prefs)
+	m.positions.SetSortPrefs(prefs)
+	m.orders.SetSortPrefs(prefs)
+	m.fills.SetSortPrefs(prefs)
+	m.funding.SetSortPrefs(prefs)
+	m.vaults.SetSortPrefs(prefs)
+	m.vaultMgr.SetSortPrefs(prefs)
+	m.staking.SetSortPrefs(prefs)
+	m.scanner.SetSortPrefs(prefs)
+}
+
+// saveSortPrefs writes the sort orders off the UI goroutine.
+func saveSortPrefs(prefs config.SortPrefs) tea.Cmd {
+	return func() tea.Msg {
+		return sortPrefsSavedMsg{Err: config.SaveSortPrefs(prefs)}
+	}
+}
+
+// saveWatchlist writes the watchlist off the UI goroutine.
+func saveWatchlist(w config.Watchlist) tea.Cmd {
+	return func() tea.Msg {
+		return watchlistSavedMsg{Err: config.SaveWatchlist(w)}
+	}
+}
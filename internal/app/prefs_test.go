@@ -0,0 +1,87 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/views/market"
+)
+
+func TestSortChangeSaved(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := config.New("0x0000000000000000000000000000000000000001", false, false)
+	m := NewModel(cfg)
+
+	keys := []config.SortKey{{Column: "coin"}, {Column: "time", Desc: true}}
+	out, cmd := m.Update(table.SortChangedMsg{Table: "fills", Keys: keys})
+	m = out.(Model)
+	if !slices.Equal(m.cfg.SortPrefs["fills"], keys) {
+		t.Errorf("config prefs = %+v", m.cfg.SortPrefs)
+	}
+	if cmd == nil {
+		t.Fatal("no save command")
+	}
+	out, _ = m.Update(cmd())
+	m = out.(Model)
+	if m.errMsg != "" {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+
+	saved, err := config.LoadSortPrefs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.EqualFunc(saved, m.cfg.SortPrefs, slices.Equal) {
+		t.Errorf("saved %+v, want %+v", saved, m.cfg.SortPrefs)
+	}
+}
+
+func TestSortSaveError(t *testing.T) {
+	// A HOME that is a file can't hold the config directory
+	t.Setenv("HOME", "/dev/null")
+	m := NewModel(config.New("0x0000000000000000000000000000000000000001", false, false))
+
+	out, cmd := m.Update(table.SortChangedMsg{Table: "orders", Keys: []config.SortKey{{Column: "coin"}}})
+	m = out.(Model)
+	out, _ = m.Update(cmd())
+	m = out.(Model)
+	if !strings.HasPrefix(m.errMsg, "Saving sort order: ") {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+}
+
+func TestWatchlistSaved(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(config.New("0x0000000000000000000000000000000000000001", false, false))
+
+	want := config.Watchlist{Coins: []string{"BTC", "HYPE"}, Hidden: []string{"voloi"}}
+	out, cmd := m.Update(market.WatchlistChangedMsg{Watchlist: want})
+	m = out.(Model)
+	if !slices.Equal(m.cfg.Watchlist.Coins, want.Coins) {
+		t.Errorf("config watchlist = %+v", m.cfg.Watchlist)
+	}
+	out, _ = m.Update(cmd())
+	m = out.(Model)
+	if m.errMsg != "" {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+
+	saved, err := config.LoadWatchlist()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(saved.Coins, want.Coins) || !slices.Equal(saved.Hidden, want.Hidden) {
+		t.Errorf("saved %+v, want %+v", saved, want)
+	}
+
+	// A new model starts with the saved watchlist
+	cfg := config.New("0x0000000000000000000000000000000000000001", false, false)
+	cfg.Watchlist = saved
+	if got := NewModel(cfg).market.Watchlist(); !slices.Equal(got.Coins, want.Coins) {
+		t.Errorf("restored watchlist = %+v", got)
+	}
+}
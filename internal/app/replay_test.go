@@ -0,0 +1,61 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/record"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+)
+
+func TestReplayClock(t *testing.T) {
+	prev := util.Now
+	t.Cleanup(func() { util.Now = prev })
+
+	// Recorded a year before the pinned test clock
+	start := viewtest.Clock.AddDate(-1, 0, 0)
+	addr := "0x0000000000000000000000000000000000000001"
+	p := record.NewPlayer(record.Meta{Address: addr}, []record.Entry{{Time: start.UnixMilli(), Kind: record.KindWS}}, 1)
+	m := NewReplayModel(config.New(addr, false, false), p)
+	if got := util.Now(); !got.Equal(start) {
+		t.Fatalf("util.Now = %v during replay, want the recording's start %v", got, start)
+	}
+
+	// A lockup ending three hours into the recording counts down from the
+	// recorded time, not the wall clock
+	m.store.SetVaultEquities([]api.VaultEquity{{
+		VaultAddress:         "0x00000000000000000000000000000000000000aa",
+		Equity:               decimal.MustParse("1000"),
+		LockedUntilTimestamp: start.Add(3 * time.Hour).UnixMilli(),
+	}})
+	m.vaults.SetHeight(20)
+	if view := m.vaults.View(); !strings.Contains(view, "3h 00m") {
+		t.Errorf("lockup not counted down on the replay clock:\n%s", view)
+	}
+}
+
+func TestFetchTimesFollowClock(t *testing.T) {
+	prev := util.Now
+	t.Cleanup(func() { util.Now = prev })
+	at := viewtest.Clock.AddDate(-1, 0, 0)
+	util.Now = func() time.Time { return at }
+
+	// The errors panel ages sources against util.Now, so their fetch times
+	// must come from the same clock
+	m := NewModel(config.New("0x0000000000000000000000000000000000000001", false, false))
+	m.activeView = ViewStaking
+	out, _ := m.Update(StakingMsg{Summary: &api.DelegatorSummary{}})
+	m = out.(Model)
+	if got := m.store.Sources()[store.SourceStaking].LastSuccess; !got.Equal(at) {
+		t.Errorf("staking fetched at %v, want %v", got, at)
+	}
+	if asOf, _ := m.dataAsOf(); !asOf.Equal(at) {
+		t.Errorf("staking as of %v, want %v", asOf, at)
+	}
+}
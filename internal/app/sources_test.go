@@ -0,0 +1,100 @@
+package app
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/fakehl"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFailedSourceKeepsData(t *testing.T) {
+	f, err := fakehl.LoadFixture("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := fakehl.New(f, fakehl.Scenario{}, 1)
+	var failFills atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if failFills.Load() && bytes.Contains(body, []byte(`"userFills"`)) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		srv.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	m := NewModel(config.NewDemo(srv.User(), ts.URL))
+	out, _ := m.Update(m.fetchInitialData()())
+	m = out.(Model)
+	fills := len(m.store.Fills())
+	if fills == 0 {
+		t.Fatal("fixture has no fills")
+	}
+
+	failFills.Store(true)
+	out, _ = m.Update(m.fetchInitialData()())
+	m = out.(Model)
+	if len(m.store.Fills()) != fills {
+		t.Errorf("fills = %d after a failed refetch, want the %d loaded before", len(m.store.Fills()), fills)
+	}
+	if m.store.FetchError(store.SourceFills) == nil {
+		t.Error("fills failure not recorded")
+	}
+	if m.errMsg != "" {
+		t.Errorf("errMsg = %q, want only the account source to set it", m.errMsg)
+	}
+	failing := m.failingTabs()
+	for v, f := range failing {
+		if f != (v == ViewFills) {
+			t.Errorf("tab %d failing = %v", v, f)
+		}
+	}
+	out, _ = m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = out.(Model)
+	if !strings.Contains(m.View(), "Fills ⚠") {
+		t.Error("failing tab not badged")
+	}
+	out, _ = m.Update(viewtest.Key("!"))
+	if view := out.(Model).View(); !strings.Contains(view, "Data Source Errors") || !strings.Contains(view, "userFills") {
+		t.Error("errors panel doesn't show the fills failure")
+	}
+
+	failFills.Store(false)
+	out, _ = m.Update(m.fetchInitialData()())
+	m = out.(Model)
+	if m.failingTabs()[ViewFills] {
+		t.Error("fills tab still failing after recovery")
+	}
+}
+
+func TestCancelledFetchesRecordNothing(t *testing.T) {
+	m, _ := loadedModel(t)
+	coin := m.store.Market().Assets[0].Name
+
+	// Built before a wallet switch, run after it
+	cmds := []tea.Cmd{m.fetchPredictedFundings(), m.fetchFundingHistory(coin), m.fetchAssetDetail(coin), m.fetchVaultSummaries()}
+	m.cancel()
+	for _, cmd := range cmds {
+		out, _ := m.Update(cmd())
+		m = out.(Model)
+	}
+	if m.errMsg != "" {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+	for _, src := range []string{store.SourcePredictedFunding, store.SourceFundingHistory, store.SourceBook, store.SourceCandles, store.SourceVaultList} {
+		if err := m.store.FetchError(src); err != nil {
+			t.Errorf("%s: %v", src, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// allMids streams several updates a second while the connection is up
+	midsStaleAfter = 15 * time.Second
+	// Without the stream, account data refreshes every 30s; two missed
+	// refreshes is stale
+	restStaleAfter = 75 * time.Second
+	// webData2 pushes on every account or market change; this long without
+	// one means the stream has stalled
+	liveStaleAfter = 30 * time.Second
+	// While webData2 is live, everything is still refetched this often
+	reconcileInterval = 5 * time.Minute
+)
+
+// accountLive reports whether account state is streaming, so the periodic
+// REST refetch can be skipped.
+func (m Model) accountLive() bool {
+	return m.wsState == ws.StateConnected && !m.lastLive.IsZero() && util.Now().Sub(m.lastLive) < liveStaleAfter
+}
+
+// refetchDue reports whether the refresh tick should reload account data
+// over REST: always without the stream, otherwise once per reconcileInterval.
+func (m Model) refetchDue() bool {
+	return !m.accountLive() || util.Now().Sub(m.lastRefresh) >= reconcileInterval
+}
+
+// dataAsOf returns when the active view's data was last updated and how old
+// it may get before it is flagged stale; 0 means it is only loaded on
+// demand and never goes stale.
+func (m Model) dataAsOf() (time.Time, time.Duration) {
+	latest := func(ts ...time.Time) time.Time {
+		var t time.Time
+		for _, x := range ts {
+			if x.After(t) {
+				t = x
+			}
+		}
+		return t
+	}
+	switch m.activeView {
+	case ViewMarket:
+		var mids time.Time
+		if m.ws != nil {
+			mids = m.ws.LastMessage("allMids")
+		}
+		return latest(m.lastRefresh, mids), midsStaleAfter
+	case ViewPositions, ViewOrders, ViewFills, ViewFunding, ViewScanner:
+		// Streamed over webData2 and the user channels; webData2 carries
+		// the asset contexts the scanner reads
+		return latest(m.lastRefresh, m.lastLive), restStaleAfter
+	case ViewStaking:
+		return m.lastStaking, 0
+	}
+	// Portfolio and vaults only come with the full refetch
+	return m.lastRefresh, reconcileInterval + restStaleAfter
+}
+
+// noteWSStatus records a connection state change, raising an alert when
+// an established connection drops and when it comes back. Coming back
+// returns the backfill for the gap.
+func (m *Model) noteWSStatus(s ws.Status) tea.Cmd {
+	prev := m.wsState
+	m.wsState = s.State
+	switch {
+	case s.State == ws.StateReconnecting && prev == ws.StateConnected:
+		m.wsLostAt = s.Time
+		m.alerts.Push("ws", "WebSocket connection lost, reconnecting")
+	case s.State == ws.StateConnected && prev == ws.StateReconnecting:
+		m.alerts.Push("ws", "WebSocket reconnected")
+		if !m.wsLostAt.IsZero() {
+			return m.backfillGap()
+		}
+	}
+	return nil
+}
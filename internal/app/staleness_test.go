@@ -0,0 +1,52 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+func TestWebData2SkipsRefetch(t *testing.T) {
+	m, _ := loadedModel(t)
+	m.wsState = ws.StateConnected
+	if m.accountLive() {
+		t.Fatal("live before any webData2 arrived")
+	}
+
+	data, _ := json.Marshal(ws.WebData2{
+		User:               m.cfg.Address,
+		ClearinghouseState: &api.ClearinghouseState{Withdrawable: decimal.MustParse("123")},
+	})
+	out, _ := m.Update(WSMsg{Msgs: []ws.Message{{Channel: "webData2", Data: data}}})
+	m = out.(Model)
+	if got := m.store.ClearinghouseState().Withdrawable; got.String() != "123" {
+		t.Errorf("withdrawable = %q, want the streamed state", got)
+	}
+	if !m.accountLive() || m.refetchDue() {
+		t.Fatal("refetch due while streaming")
+	}
+	m.lastRefresh = time.Now().Add(-reconcileInterval)
+	if !m.refetchDue() {
+		t.Error("periodic reconcile not due")
+	}
+	m.lastRefresh = time.Now()
+	m.lastLive = time.Now().Add(-liveStaleAfter)
+	if !m.refetchDue() {
+		t.Error("refetch not due after the stream stalled")
+	}
+	m.lastLive = time.Now()
+
+	// Another wallet's snapshot is ignored
+	data, _ = json.Marshal(ws.WebData2{
+		User:               "0x0000000000000000000000000000000000000001",
+		ClearinghouseState: &api.ClearinghouseState{Withdrawable: decimal.MustParse("9")},
+	})
+	out, _ = m.Update(WSMsg{Msgs: []ws.Message{{Channel: "webData2", Data: data}}})
+	if got := out.(Model).store.ClearinghouseState().Withdrawable; got.String() != "123" {
+		t.Errorf("withdrawable = %q after another user's snapshot", got)
+	}
+}
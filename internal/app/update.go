@@ -1,6 +1,24 @@
 package app
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/born1337/hyperliquid-terminal/internal/anomaly"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
+	"github.com/born1337/hyperliquid-terminal/internal/views/funding"
+	"github.com/born1337/hyperliquid-terminal/internal/views/market"
+	"github.com/born1337/hyperliquid-terminal/internal/views/positions"
+	"github.com/born1337/hyperliquid-terminal/internal/views/scanner"
+	"github.com/born1337/hyperliquid-terminal/internal/views/vaults"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -20,65 +38,302 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.funding.SetHeight(viewHeight)
 		m.portfolio.SetHeight(viewHeight)
 		m.vaults.SetHeight(viewHeight)
+		m.vaultMgr.SetHeight(viewHeight)
+		m.staking.SetHeight(viewHeight)
+		m.scanner.SetHeight(viewHeight)
+		m.detail.SetSize(m.width, viewHeight)
 
 	case InitialDataMsg:
+		if errors.Is(msg.Err, context.Canceled) {
+			break // a previous wallet's load, abandoned on switch
+		}
 		m.loading = false
+		now := util.Now()
+		for source, err := range msg.Errs {
+			m.store.RecordFetch(source, err, now)
+		}
+		m.errMsg = ""
 		if msg.Err != nil {
 			m.errMsg = "Error: " + msg.Err.Error()
-			return m, refreshTick()
 		}
-		m.errMsg = ""
 
-		m.store.Lock()
-		m.store.ClearinghouseState = msg.State
-		if msg.Mids != nil {
-			m.store.AllMids = msg.Mids
+		// Sources that failed keep their previous data
+		loaded := func(source string) bool {
+			err, fetched := msg.Errs[source]
+			return fetched && err == nil
+		}
+		if loaded(store.SourceMids) && msg.Mids != nil {
+			m.store.SetAllMids(msg.Mids)
+		}
+		if loaded(store.SourceMeta) {
+			m.store.SetMetaAndAssetCtxs(msg.Meta)
+		}
+		if loaded(store.SourceOrders) {
+			m.store.SetOpenOrders(msg.Orders)
+		}
+		if loaded(store.SourceFills) {
+			m.store.SetFills(msg.Fills)
+		}
+		if loaded(store.SourceFunding) {
+			m.store.SetFundingPayments(msg.Funding)
+		}
+		if loaded(store.SourcePortfolio) {
+			m.store.SetPortfolio(msg.Portfolio)
+		}
+		if loaded(store.SourceFees) {
+			m.store.SetUserFees(msg.Fees)
+		}
+		if loaded(store.SourceVaults) {
+			m.store.SetVaultEquities(msg.Vaults)
+		}
+		if loaded(store.SourceVaultDetails) && msg.ManagedVault != nil {
+			m.store.SetManagedVault(msg.ManagedVault)
+		}
+		if loaded(store.SourceRateLimit) {
+			m.store.SetUserRateLimit(msg.RateLimit)
 		}
-		m.store.MetaAndAssetCtxs = msg.Meta
-		m.store.OpenOrders = msg.Orders
-		m.store.Fills = msg.Fills
-		m.store.FundingPayments = msg.Funding
-		m.store.Portfolio = msg.Portfolio
-		m.store.UserFees = msg.Fees
-		m.store.VaultEquities = msg.Vaults
-		m.store.Unlock()
 
+		// After the prices it is reconciled against
+		if loaded(store.SourceAccount) {
+			m.store.SetClearinghouseState(msg.State)
+			m.lastRefresh = now
+		}
 		m.store.UpdateFundingRates()
+		m.marks.sawFills(msg.Fills)
+		m.marks.sawFundings(msg.Funding)
 
 		// Fetch vault details
-		m.store.RLock()
-		vaultEquities := m.store.VaultEquities
-		m.store.RUnlock()
-		for _, ve := range vaultEquities {
+		for _, ve := range m.store.VaultEquities() {
 			cmds = append(cmds, m.fetchVaultDetails(ve.VaultAddress))
 		}
 
-		cmds = append(cmds, refreshTick())
+		// Replays refresh when the recording did, not on a timer
+		if m.replay == nil {
+			cmds = append(cmds, refreshTick())
+		}
 
-	case wsConnectedMsg:
+	case wsStartedMsg:
 		m.ws = msg.client
-		m.wsConnected = true
-		cmds = append(cmds, waitForWS(m.wsCh))
+		cmds = append(cmds, waitForWS(m.feed), waitForWSStatus(msg.client))
 
 	case WSMsg:
-		m.handleWSMessage(msg.Msg)
-		cmds = append(cmds, waitForWS(m.wsCh))
+		for _, wm := range msg.Msgs {
+			m.handleWSMessage(wm)
+		}
+		cmds = append(cmds, waitForWS(m.feed))
+
+	case replayStartedMsg:
+		m.wsState = ws.StateConnected
+		m.alerts.Push("replay", fmt.Sprintf("Replaying %s session at %gx", m.cfg.TruncatedAddress(), m.replay.Speed))
+		cmds = append(cmds, waitForWS(m.feed), waitForReplay(m.replayCh))
+
+	case replayRefreshMsg:
+		cmds = append(cmds, m.fetchInitialData(), waitForReplay(m.replayCh))
+
+	case replayDoneMsg:
+		m.alerts.Push("replay", "Replay finished")
 
 	case WSStatusMsg:
-		m.wsConnected = msg.Connected
+		if msg.client != m.ws {
+			break
+		}
+		cmds = append(cmds, m.noteWSStatus(msg.Status), waitForWSStatus(msg.client))
+
+	case gapBackfillMsg:
+		m.applyBackfill(msg)
+
+	case ageTickMsg:
+		// Nothing to update; the redraw moves the data age on
+		cmds = append(cmds, ageTick())
 
 	case RefreshTickMsg:
-		// Periodic refresh of account state
-		cmds = append(cmds, m.fetchInitialData())
-		if m.ws != nil {
-			m.wsConnected = m.ws.Connected()
+		// Account state streams over webData2. Refetch it all only when
+		// the stream has stalled, and now and then to pick up what it
+		// doesn't carry (portfolio, fees, vaults)
+		if m.refetchDue() {
+			cmds = append(cmds, m.fetchInitialData())
+		} else {
+			cmds = append(cmds, refreshTick())
+		}
+		if m.lockupAlerts {
+			m.checkLockupAlerts(util.Now())
+		}
+		if m.funding.RatesMode() || m.positions.CarryMode() {
+			cmds = append(cmds, m.fetchPredictedFundings())
+		}
+		if m.showDetail {
+			cmds = append(cmds, m.fetchAssetDetail(m.detail.Coin()))
 		}
 
 	case VaultDetailsMsg:
+		m.store.RecordFetch(store.SourceVaultDetails, msg.Err, util.Now())
+		if msg.Err == nil && msg.Details != nil {
+			m.store.SetVaultDetails(msg.Address, msg.Details)
+		}
+
+	case StakingMsg:
+		now := util.Now()
+		m.store.RecordFetch(store.SourceStaking, msg.Err, now)
+		m.store.RecordFetch(store.SourceValidators, msg.ValidatorsErr, now)
+		m.store.UpdateValidators(msg.Validators)
+		if msg.Err == nil {
+			m.lastStaking = now
+			m.store.SetStaking(store.Staking{
+				Summary:     msg.Summary,
+				Delegations: msg.Delegations,
+				Rewards:     msg.Rewards,
+				History:     msg.History,
+			})
+		}
+
+	case funding.RatesOpenedMsg:
+		cmds = append(cmds, m.fetchPredictedFundings())
+
+	case asset.OpenMsg:
+		m.showDetail = true
+		m.detail = asset.New(m.store, msg.Coin)
+		m.detail.SetSize(m.width, m.height-12)
+		cmds = append(cmds, m.fetchAssetDetail(msg.Coin), m.fetchFundingHistory(msg.Coin))
+
+	case AssetDetailMsg:
+		if errors.Is(msg.BookErr, context.Canceled) || errors.Is(msg.CandlesErr, context.Canceled) {
+			break // abandoned on a wallet switch
+		}
+		now := util.Now()
+		m.store.RecordFetch(store.SourceBook, msg.BookErr, now)
+		m.store.RecordFetch(store.SourceCandles, msg.CandlesErr, now)
+		if msg.BookErr == nil && msg.Book != nil {
+			m.store.SetL2Book(msg.Book)
+		}
+		if msg.CandlesErr == nil {
+			m.store.SetCandles(msg.Coin, msg.Candles)
+		}
+		if err := cmp.Or(msg.BookErr, msg.CandlesErr); err != nil {
+			m.errMsg = msg.Coin + " details: " + err.Error()
+		}
+
+	case positions.CarryOpenedMsg:
+		cmds = append(cmds, m.fetchPredictedFundings())
+		for _, coin := range msg.Coins {
+			if _, ok := m.store.FundingHistory(coin); !ok {
+				cmds = append(cmds, m.fetchFundingHistory(coin))
+			}
+		}
+
+	case PredictedFundingsMsg:
+		if errors.Is(msg.Err, context.Canceled) {
+			break
+		}
+		m.store.RecordFetch(store.SourcePredictedFunding, msg.Err, util.Now())
+		if msg.Err != nil {
+			m.errMsg = "Predicted fundings: " + msg.Err.Error()
+			break
+		}
+		m.store.SetPredictedFundings(msg.Fundings)
+
+	case FundingHistoryMsg:
+		if errors.Is(msg.Err, context.Canceled) {
+			break
+		}
+		m.store.RecordFetch(store.SourceFundingHistory, msg.Err, util.Now())
+		if msg.Err != nil {
+			m.errMsg = "Funding history: " + msg.Err.Error()
+			break
+		}
+		m.store.SetFundingHistory(msg.Coin, msg.Entries)
+
+	case vaults.ExplorerOpenedMsg:
+		if !m.store.VaultSummariesLoaded() {
+			cmds = append(cmds, m.fetchVaultSummaries())
+		} else if cmd := m.startVaultEnrichment(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case vaults.UnlocksOpenedMsg:
+		cmds = append(cmds, m.fetchWalletVaults())
+
+	case vaults.ToggleLockupAlertsMsg:
+		m.lockupAlerts = !m.lockupAlerts
+		m.vaults.SetLockupAlerts(m.lockupAlerts)
+		if m.lockupAlerts {
+			m.lastLockupCheck = util.Now()
+		}
+
+	case scanner.ToggleAlertsMsg:
+		m.scanAlerts = !m.scanAlerts
+		m.scanner.SetAlerts(m.scanAlerts)
+		if m.scanAlerts {
+			// Only flags raised from now on alert
+			m.scanFlagged = flagSet(anomaly.Scan(m.store, m.scanner.Rules()))
+		}
+
+	case WalletVaultsMsg:
+		m.store.SetWalletVaultEquities(msg.Equities)
+
+	case vaults.DetailsRequestMsg:
+		cmds = append(cmds, m.fetchVaultDetails(msg.Address))
+
+	case VaultSummariesMsg:
+		if errors.Is(msg.Err, context.Canceled) {
+			break
+		}
+		m.store.RecordFetch(store.SourceVaultList, msg.Err, util.Now())
+		if msg.Err != nil {
+			m.errMsg = "Vault list: " + msg.Err.Error()
+			break
+		}
+		m.store.SetVaultSummaries(msg.Summaries)
+		if cmd := m.startVaultEnrichment(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case vaultEnrichMsg:
+		if msg.User != m.cfg.Address {
+			break // stale chain from before a wallet switch
+		}
 		if msg.Err == nil && msg.Details != nil {
-			m.store.Lock()
-			m.store.VaultDetails[msg.Address] = msg.Details
-			m.store.Unlock()
+			m.store.SetVaultDetails(msg.Address, msg.Details)
+		}
+		if next := m.nextEnrichVault(); next != "" {
+			cmds = append(cmds, m.enrichVault(next, vaultEnrichDelay))
+		} else {
+			m.vaultEnriching = false
+		}
+
+	case table.SortChangedMsg:
+		if m.cfg.SortPrefs == nil {
+			m.cfg.SortPrefs = make(config.SortPrefs)
+		}
+		m.cfg.SortPrefs[msg.Table] = msg.Keys
+		cmds = append(cmds, saveSortPrefs(maps.Clone(m.cfg.SortPrefs)))
+
+	case sortPrefsSavedMsg:
+		if msg.Err != nil {
+			m.errMsg = "Saving sort order: " + msg.Err.Error()
+		}
+
+	case market.WatchlistChangedMsg:
+		m.cfg.Watchlist = msg.Watchlist
+		cmds = append(cmds, saveWatchlist(msg.Watchlist))
+
+	case midHistoryLoadedMsg:
+		if msg.Err != nil {
+			m.errMsg = "Loading mids history: " + msg.Err.Error()
+			break
+		}
+		m.store.RestoreMidHistory(msg.Mids)
+
+	case midHistoryTickMsg:
+		cmds = append(cmds, m.saveMidHistory(), midHistoryTick())
+
+	case midHistorySavedMsg:
+		if msg.Err != nil {
+			m.errMsg = "Saving mids history: " + msg.Err.Error()
+		}
+
+	case watchlistSavedMsg:
+		if msg.Err != nil {
+			m.errMsg = "Saving watchlist: " + msg.Err.Error()
 		}
 
 	case tea.KeyMsg:
@@ -147,6 +402,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Errors panel captures all keys
+		if m.showErrors {
+			if msg.String() == "!" || msg.String() == "esc" || msg.String() == "q" {
+				m.showErrors = false
+			}
+			return m, nil
+		}
+
 		// Help overlay captures all keys
 		if m.showHelp {
 			if msg.String() == ";" || msg.String() == "esc" || msg.String() == "q" {
@@ -155,21 +418,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Asset detail screen captures all keys
+		if m.showDetail {
+			switch msg.String() {
+			case "esc", "q":
+				m.showDetail = false
+			case "r":
+				cmds = append(cmds, m.fetchAssetDetail(m.detail.Coin()), m.fetchFundingHistory(m.detail.Coin()))
+			default:
+				var cmd tea.Cmd
+				m.detail, cmd = m.detail.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		// An open filter bar captures all keys
+		if m.viewFiltering() {
+			return m, m.updateView(msg)
+		}
+
 		switch {
 		case key.Matches(msg, Keys.Quit):
 			if m.ws != nil {
 				m.ws.Close()
 			}
+			if m.keepMids() {
+				// Nowhere left to report a failure
+				_ = writeMidHistory(m.store, m.cfg.IsTestnet)
+			}
 			return m, tea.Quit
 
 		case key.Matches(msg, Keys.Help):
 			m.showHelp = true
 
+		case key.Matches(msg, Keys.Errors):
+			m.showErrors = true
+
 		case key.Matches(msg, Keys.Tab), key.Matches(msg, Keys.NextView):
-			m.activeView = (m.activeView + 1) % 7
+			m.activeView = (m.activeView + 1) % numViews
 
 		case key.Matches(msg, Keys.ShiftTab), key.Matches(msg, Keys.PrevView):
-			m.activeView = (m.activeView + 6) % 7
+			m.activeView = (m.activeView + numViews - 1) % numViews
 
 		case key.Matches(msg, Keys.View0):
 			m.activeView = ViewMarket
@@ -185,49 +475,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.activeView = ViewPortfolio
 		case key.Matches(msg, Keys.View6):
 			m.activeView = ViewVaults
+		case key.Matches(msg, Keys.View7):
+			m.activeView = ViewStaking
+		case key.Matches(msg, Keys.View8):
+			m.activeView = ViewScanner
 
 		case key.Matches(msg, Keys.WalletPicker):
+			if !m.walletsEditable() {
+				m.errMsg = "Wallets can't be switched or edited in a demo, replay or recording"
+				break
+			}
 			m.showWalletPicker = true
 			m.walletCursor = m.cfg.ActiveWallet
 
 		case key.Matches(msg, Keys.Refresh):
 			m.loading = true
-			cmds = append(cmds, m.fetchInitialData())
+			cmds = append(cmds, m.fetchInitialData(), m.fetchStaking())
 
 		default:
-			// Dispatch to active sub-view
-			switch m.activeView {
-			case ViewMarket:
-				var cmd tea.Cmd
-				m.market, cmd = m.market.Update(msg)
-				cmds = append(cmds, cmd)
-			case ViewPositions:
-				var cmd tea.Cmd
-				m.positions, cmd = m.positions.Update(msg)
-				cmds = append(cmds, cmd)
-			case ViewOrders:
-				var cmd tea.Cmd
-				m.orders, cmd = m.orders.Update(msg)
-				cmds = append(cmds, cmd)
-			case ViewFills:
-				var cmd tea.Cmd
-				m.fills, cmd = m.fills.Update(msg)
-				cmds = append(cmds, cmd)
-			case ViewFunding:
-				var cmd tea.Cmd
-				m.funding, cmd = m.funding.Update(msg)
-				cmds = append(cmds, cmd)
-			case ViewPortfolio:
-				var cmd tea.Cmd
-				m.portfolio, cmd = m.portfolio.Update(msg)
-				cmds = append(cmds, cmd)
-			case ViewVaults:
-				var cmd tea.Cmd
-				m.vaults, cmd = m.vaults.Update(msg)
-				cmds = append(cmds, cmd)
-			}
+			cmds = append(cmds, m.updateView(msg))
 		}
 	}
 
+	// Asset contexts that landed while handling msg may trip scanner flags
+	if m.contextsUpdated.Swap(false) && m.scanAlerts {
+		m.checkScanAlerts()
+	}
+
 	return m, tea.Batch(cmds...)
 }
+
+// updateView passes msg to the active sub-view.
+func (m *Model) updateView(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.activeView {
+	case ViewMarket:
+		m.market, cmd = m.market.Update(msg)
+	case ViewPositions:
+		m.positions, cmd = m.positions.Update(msg)
+	case ViewOrders:
+		m.orders, cmd = m.orders.Update(msg)
+	case ViewFills:
+		m.fills, cmd = m.fills.Update(msg)
+	case ViewFunding:
+		m.funding, cmd = m.funding.Update(msg)
+	case ViewPortfolio:
+		m.portfolio, cmd = m.portfolio.Update(msg)
+	case ViewVaults:
+		if m.cfg.IsVault {
+			m.vaultMgr, cmd = m.vaultMgr.Update(msg)
+		} else {
+			m.vaults, cmd = m.vaults.Update(msg)
+			// Fetch the details of vaults scrolled or sorted into view
+			cmd = tea.Batch(cmd, m.startVaultEnrichment())
+		}
+	case ViewStaking:
+		m.staking, cmd = m.staking.Update(msg)
+	case ViewScanner:
+		m.scanner, cmd = m.scanner.Update(msg)
+	}
+	return cmd
+}
+
+// viewFiltering reports whether the active view's filter bar, or another
+// of its inputs such as the market column picker, is open.
+func (m Model) viewFiltering() bool {
+	switch m.activeView {
+	case ViewMarket:
+		return m.market.Filtering()
+	case ViewPositions:
+		return m.positions.Filtering()
+	case ViewOrders:
+		return m.orders.Filtering()
+	case ViewFills:
+		return m.fills.Filtering()
+	case ViewFunding:
+		return m.funding.Filtering()
+	case ViewVaults:
+		if m.cfg.IsVault {
+			return m.vaultMgr.Filtering()
+		}
+		return m.vaults.Filtering()
+	case ViewStaking:
+		return m.staking.Filtering()
+	case ViewScanner:
+		return m.scanner.Filtering()
+	}
+	return false
+}
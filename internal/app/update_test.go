@@ -0,0 +1,245 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/record"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
+	"github.com/born1337/hyperliquid-terminal/internal/views/scanner"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterBarCapturesKeys(t *testing.T) {
+	for _, view := range []int{ViewFills, ViewVaults, ViewStaking} {
+		m := NewModel(config.New("0x0000000000000000000000000000000000000001", false, false))
+		m.activeView = view
+
+		press := func(k tea.KeyMsg) tea.Cmd {
+			out, cmd := m.Update(k)
+			m = out.(Model)
+			return cmd
+		}
+		press(viewtest.Key("/"))
+		for _, k := range []string{"q", "2", "l", ";", "w"} {
+			press(viewtest.Key(k))
+		}
+		if m.activeView != view || m.showHelp || m.showWalletPicker {
+			t.Fatalf("global keys acted while filtering: view %d, help %v, picker %v", m.activeView, m.showHelp, m.showWalletPicker)
+		}
+		if !m.viewFiltering() {
+			t.Fatalf("view %d: filter bar closed", view)
+		}
+
+		press(tea.KeyMsg{Type: tea.KeyEnter})
+		press(viewtest.Key("2"))
+		if m.activeView != ViewOrders {
+			t.Errorf("view = %d after closing the bar, want orders", m.activeView)
+		}
+	}
+}
+
+func TestPinnedSessionsKeepWallets(t *testing.T) {
+	prev := util.Now
+	t.Cleanup(func() { util.Now = prev })
+	addr := "0x0000000000000000000000000000000000000001"
+	player := record.NewPlayer(record.Meta{Address: addr}, nil, 1)
+	recorder, err := record.Create(filepath.Join(t.TempDir(), "session.rec"), record.Meta{Address: addr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { recorder.Close() })
+
+	for name, m := range map[string]Model{
+		"demo":      NewModel(config.NewDemo(addr, "http://127.0.0.1:1")),
+		"replay":    NewReplayModel(config.New(addr, false, false), player),
+		"recording": NewModel(config.New(addr, false, false)).WithRecorder(recorder),
+	} {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+
+		out, _ := m.Update(viewtest.Key("w"))
+		m = out.(Model)
+		if m.showWalletPicker {
+			t.Fatalf("%s: wallet picker opened", name)
+		}
+
+		// Even reached directly, the form and delete save nothing
+		m.initWalletForm()
+		m.walletFormAddr.SetValue("0x0000000000000000000000000000000000000002")
+		m.submitWalletForm()
+		m.deleteWalletAtCursor()
+		if len(m.cfg.Wallets) != 0 {
+			t.Errorf("%s: wallets = %v", name, m.cfg.Wallets)
+		}
+		if _, err := os.Stat(filepath.Join(home, ".config", "hltui", "wallets.json")); !os.IsNotExist(err) {
+			t.Errorf("%s: wallets.json written (stat: %v)", name, err)
+		}
+	}
+
+	// Nor does a recording switch away from the wallet its header names
+	wallets := []config.Wallet{{Name: "main", Address: addr}, {Name: "alt", Address: "0x0000000000000000000000000000000000000002"}}
+	m := NewModel(config.NewWithWallets(wallets, 0, false, false)).WithRecorder(recorder)
+	if cmd := m.switchWallet(1); cmd != nil || m.cfg.Address != addr {
+		t.Errorf("recording switched to %s", m.cfg.Address)
+	}
+}
+
+func TestEnterOpensAssetDetail(t *testing.T) {
+	m, _ := loadedModel(t)
+	m.activeView = ViewMarket
+
+	out, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = out.(Model)
+	open, ok := runCmd(cmd).(asset.OpenMsg)
+	if !ok {
+		t.Fatalf("enter on market sent %T, want asset.OpenMsg", runCmd(cmd))
+	}
+	out, _ = m.Update(open)
+	m = out.(Model)
+	if !m.showDetail || m.detail.Coin() != open.Coin {
+		t.Fatalf("detail shown %v for %q, want %q", m.showDetail, m.detail.Coin(), open.Coin)
+	}
+
+	out, _ = m.Update(m.fetchAssetDetail(open.Coin)())
+	m = out.(Model)
+	if m.store.L2Book(open.Coin) == nil || len(m.store.Candles(open.Coin)) == 0 {
+		t.Error("book and candles not stored")
+	}
+
+	// Global keys go to the detail screen while it is open
+	out, _ = m.Update(viewtest.Key("2"))
+	m = out.(Model)
+	if m.activeView != ViewMarket {
+		t.Errorf("view = %d while the detail is open", m.activeView)
+	}
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = out.(Model)
+	if m.showDetail {
+		t.Error("esc did not close the detail")
+	}
+}
+
+func TestEnrichmentFollowsExplorer(t *testing.T) {
+	m := NewModel(config.New("0x0000000000000000000000000000000000000001", false, false))
+	var summaries []api.VaultSummary
+	for i := range 2 * maxEnrichVaults {
+		summaries = append(summaries, api.VaultSummary{
+			Name:         fmt.Sprintf("Vault %02d", i),
+			VaultAddress: fmt.Sprintf("0x%02d", i),
+			Tvl:          decimal.NewFromInt(int64(100-i) * 1_000_000),
+		})
+	}
+	m.store.SetVaultSummaries(summaries)
+
+	// Away from the explorer the largest vaults load first
+	if got := m.nextEnrichVault(); got != "0x00" {
+		t.Errorf("next vault = %q, want the largest", got)
+	}
+
+	// In it, the vault under the cursor does, even past the largest few
+	m.activeView = ViewVaults
+	m.vaults.SetHeight(10)
+	for _, k := range []string{"e", ">", "s"} {
+		m.vaults, _ = m.vaults.Update(viewtest.Key(k))
+	}
+	for range maxEnrichVaults + 5 {
+		m.vaults, _ = m.vaults.Update(viewtest.Key("j"))
+	}
+	want := fmt.Sprintf("0x%02d", maxEnrichVaults+5)
+	if got := m.nextEnrichVault(); got != want {
+		t.Errorf("next vault = %q, want the cursor row %q", got, want)
+	}
+	m.enrichVault(want, 0)
+	if got := m.nextEnrichVault(); got == want {
+		t.Errorf("next vault = %q again while its request is out", got)
+	}
+}
+
+func TestScanAlerts(t *testing.T) {
+	m, _ := loadedModel(t)
+	now := time.Now()
+	prev := util.Now
+	t.Cleanup(func() { util.Now = prev })
+
+	// An hour later webData2 streams contexts with SOL's open interest up
+	// a fifth
+	now = now.Add(time.Hour)
+	util.Now = func() time.Time { return now }
+	meta := &api.Meta{}
+	var ctxs []api.AssetCtx
+	for _, a := range m.store.Market().Assets {
+		ctx := a.Ctx
+		if a.Name == "SOL" {
+			ctx.OpenInterest = decimal.NewFromFloat(a.OpenInterest * 1.2)
+		}
+		meta.Universe = append(meta.Universe, a.AssetMeta)
+		ctxs = append(ctxs, ctx)
+	}
+	data, _ := json.Marshal(ws.WebData2{User: m.cfg.Address, Meta: meta, AssetCtxs: ctxs})
+	// Each delivery lands the contexts again and checks the flags
+	deliver := func() {
+		out, _ := m.Update(WSMsg{Msgs: []ws.Message{{Channel: "webData2", Data: data}}})
+		m = out.(Model)
+	}
+	deliver()
+	if a, ok := m.alerts.Latest(); !ok || a.Source != "scanner" || a.Message != "SOL open interest +20.0% in 1h" {
+		t.Fatalf("latest alert = %+v, %v", a, ok)
+	}
+	// Still flagged: no second alert. Nor does a refresh tick check
+	n := len(m.alerts.Recent(100))
+	deliver()
+	m.scanFlagged = nil
+	out, _ := m.Update(RefreshTickMsg{})
+	m = out.(Model)
+	if got := len(m.alerts.Recent(100)); got != n {
+		t.Errorf("%d alerts after a repeat check, want %d", got, n)
+	}
+
+	toggle := func() {
+		out, _ := m.Update(scanner.ToggleAlertsMsg{})
+		m = out.(Model)
+	}
+	// Turned back on, flags already raised stay quiet
+	toggle()
+	m.scanFlagged = nil
+	toggle()
+	deliver()
+	if got := len(m.alerts.Recent(100)); !m.scanAlerts || got != n {
+		t.Errorf("%d alerts after turning alerts back on, want %d", got, n)
+	}
+	// and while off nothing alerts
+	toggle()
+	m.scanFlagged = nil
+	deliver()
+	if got := len(m.alerts.Recent(100)); m.scanAlerts || got != n {
+		t.Errorf("%d alerts with scanner alerts off, want %d", got, n)
+	}
+}
+
+// runCmd runs cmd and, when it is a batch, its first command.
+func runCmd(cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c != nil {
+				return runCmd(c)
+			}
+		}
+		return nil
+	}
+	return msg
+}
@@ -1,10 +1,16 @@
 package app
 
 import (
+	"time"
+
 	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// alertNoticeDuration is how long a new alert stays in the status bar.
+const alertNoticeDuration = 30 * time.Second
+
 func (m Model) View() string {
 	if m.width == 0 {
 		return "Loading..."
@@ -25,19 +31,41 @@ func (m Model) View() string {
 		return ui.RenderHelp(m.width, m.height)
 	}
 
+	// Data source errors overlay
+	if m.showErrors {
+		panel := ui.RenderErrorsPanel(m.store.Sources(), m.store.FetchFailures(), m.width, m.height)
+		return panel
+	}
+
 	// Title bar
-	titleBar := ui.RenderTitleBar(m.width, m.cfg.IsTestnet, m.wsConnected, m.cfg.WalletName, m.cfg.TruncatedAddress())
+	titleBar := ui.RenderTitleBar(m.width, m.cfg.IsTestnet, m.cfg.IsVault, m.wsState, m.cfg.WalletName, m.cfg.TruncatedAddress())
 
 	// Account header
 	header := ui.RenderHeader(m.store, m.width)
 
 	// Tabs
-	tabs := ui.RenderTabs(m.activeView, m.width)
+	tabs := ui.RenderTabs(m.tabNames(), m.activeView, m.failingTabs(), m.width)
+
+	// Feed health and the age of the active view's data, in the gap under
+	// the tabs
+	feed, age := "", ""
+	if t := m.feed.Totals(); t.Received > 0 {
+		feed = ui.RenderFeedStats(t)
+	}
+	if !m.loading {
+		if asOf, staleAfter := m.dataAsOf(); !asOf.IsZero() {
+			d := util.Now().Sub(asOf)
+			age = ui.RenderDataAge(d, staleAfter > 0 && d > staleAfter)
+		}
+	}
+	info := ui.RenderInfoLine(m.width, feed, age)
 
 	// Active view
 	var viewContent string
 	if m.loading {
 		viewContent = "  Loading..."
+	} else if m.showDetail {
+		viewContent = m.detail.View()
 	} else {
 		switch m.activeView {
 		case ViewMarket:
@@ -53,12 +81,29 @@ func (m Model) View() string {
 		case ViewPortfolio:
 			viewContent = m.portfolio.View()
 		case ViewVaults:
-			viewContent = m.vaults.View()
+			if m.cfg.IsVault {
+				viewContent = m.vaultMgr.View()
+			} else {
+				viewContent = m.vaults.View()
+			}
+		case ViewStaking:
+			viewContent = m.staking.View()
+		case ViewScanner:
+			viewContent = m.scanner.View()
 		}
 	}
 
 	// Status bar
-	statusBar := ui.RenderStatusBar(m.width, m.errMsg)
+	notice := ""
+	if a, ok := m.alerts.Latest(); ok && time.Since(a.Time) < alertNoticeDuration {
+		notice = a.Message
+	}
+	budget := ""
+	if l := m.api.Limiter(); l != nil {
+		weight, weightCap := l.Available()
+		budget = ui.RenderRateBudget(weight, weightCap, m.store.UserRateLimit())
+	}
+	statusBar := ui.RenderStatusBar(m.width, m.errMsg, notice, budget)
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		titleBar,
@@ -66,7 +111,7 @@ func (m Model) View() string {
 		header,
 		"",
 		tabs,
-		"",
+		info,
 		viewContent,
 		"",
 		statusBar,
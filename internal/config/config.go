@@ -29,10 +29,34 @@ type Config struct {
 	WSBaseURL  string
 	IsTestnet  bool
 	IsVault    bool
+	IsDemo     bool
 
 	Wallets      []Wallet
 	ActiveWallet int
 	WalletName   string
+
+	// Saved table sort orders; nil when none were loaded
+	SortPrefs SortPrefs
+
+	// Starred coins and the Watchlist mode's hidden columns
+	Watchlist Watchlist
+
+	// Save the mids history on quit and restore it on the next run
+	KeepMids bool
+
+	// Moves the anomaly scanner flags, from the --scan-* flags; nil keeps
+	// the scanner's defaults
+	Scan *ScanThresholds
+}
+
+// ScanThresholds are the --scan-* flags: how far a market's asset context
+// has to move over the scanner's window to be flagged. The app hands them
+// to the scanner as its thresholds.
+type ScanThresholds struct {
+	OIJump       float64 // % rise in open interest, in coins
+	VolumeRatio  float64 // recent hourly volume over its 24h average
+	FundingSpike float64 // annualized % points funding moved from its average
+	BasisWiden   float64 // % points the mark/oracle basis widened by
 }
 
 func New(address string, testnet, vault bool) *Config {
@@ -45,6 +69,17 @@ func New(address string, testnet, vault bool) *Config {
 	return cfg
 }
 
+// NewDemo creates a Config pointed at a local fake server (see fakehl).
+func NewDemo(address, baseURL string) *Config {
+	return &Config{
+		Address:    address,
+		APIBaseURL: baseURL,
+		WSBaseURL:  "ws" + strings.TrimPrefix(baseURL, "http") + "/ws",
+		IsDemo:     true,
+		WalletName: "Demo",
+	}
+}
+
 // NewWithWallets creates a Config from a wallet list.
 func NewWithWallets(wallets []Wallet, initialIdx int, cliTestnet, cliVault bool) *Config {
 	w := wallets[initialIdx]
@@ -96,6 +131,9 @@ func TruncateAddress(addr string) string {
 }
 
 func (c *Config) setURLs() {
+	if c.IsDemo {
+		return
+	}
 	if c.IsTestnet {
 		c.APIBaseURL = "https://api.hyperliquid-testnet.xyz"
 		c.WSBaseURL = "wss://api.hyperliquid-testnet.xyz/ws"
@@ -115,13 +153,21 @@ func ValidateAddress(addr string) bool {
 	return re.MatchString(strings.TrimSpace(addr))
 }
 
+// Dir returns the config directory, ~/.config/hltui.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "hltui"), nil
+}
+
 // SaveWallets writes wallets to ~/.config/hltui/wallets.json.
 func SaveWallets(wallets []Wallet) error {
-	home, err := os.UserHomeDir()
+	dir, err := Dir()
 	if err != nil {
 		return err
 	}
-	dir := filepath.Join(home, ".config", "hltui")
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
@@ -136,11 +182,11 @@ func SaveWallets(wallets []Wallet) error {
 
 // LoadWallets reads the wallets config file from ~/.config/hltui/wallets.json.
 func LoadWallets() ([]Wallet, error) {
-	home, err := os.UserHomeDir()
+	dir, err := Dir()
 	if err != nil {
 		return nil, err
 	}
-	path := filepath.Join(home, ".config", "hltui", "wallets.json")
+	path := filepath.Join(dir, "wallets.json")
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestNewMainnet(t *testing.T) {
 	c := New("0xabc", false, false)
@@ -37,8 +40,64 @@ func TestNewTestnet(t *testing.T) {
 	}
 }
 
+func TestNewDemo(t *testing.T) {
+	c := NewDemo("0xabc", "http://127.0.0.1:4321")
+	if !c.IsDemo {
+		t.Error("IsDemo should be true")
+	}
+	if c.InfoURL() != "http://127.0.0.1:4321/info" {
+		t.Errorf("InfoURL() = %q", c.InfoURL())
+	}
+	if c.WSBaseURL != "ws://127.0.0.1:4321/ws" {
+		t.Errorf("WSBaseURL = %q", c.WSBaseURL)
+	}
+}
+
 func TestVersion(t *testing.T) {
 	if Version == "" {
 		t.Error("Version should not be empty")
 	}
 }
+
+func TestSortPrefsRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	prefs, err := LoadSortPrefs()
+	if err != nil || len(prefs) != 0 {
+		t.Fatalf("LoadSortPrefs without a file = %v, %v; want empty", prefs, err)
+	}
+
+	want := SortPrefs{"positions": {{Column: "value", Desc: true}, {Column: "coin"}}}
+	if err := SaveSortPrefs(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadSortPrefs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := got["positions"]
+	if len(keys) != 2 || keys[0] != want["positions"][0] || keys[1] != want["positions"][1] {
+		t.Errorf("loaded %v, want %v", got, want)
+	}
+}
+
+func TestWatchlistRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	w, err := LoadWatchlist()
+	if err != nil || len(w.Coins) != 0 || len(w.Hidden) != 0 {
+		t.Fatalf("LoadWatchlist without a file = %+v, %v; want empty", w, err)
+	}
+
+	want := Watchlist{Coins: []string{"BTC", "HYPE"}, Hidden: []string{"premium"}}
+	if err := SaveWatchlist(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadWatchlist()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Coins, want.Coins) || !slices.Equal(got.Hidden, want.Hidden) {
+		t.Errorf("loaded %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SortKey is one key of a table's sort order: a column and its direction.
+type SortKey struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// SortPrefs are the saved sort orders, keyed by table name.
+type SortPrefs map[string][]SortKey
+
+// LoadSortPrefs reads ~/.config/hltui/sort.json. A missing file is not an
+// error; it means every table starts with its default order.
+func LoadSortPrefs() (SortPrefs, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "sort.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return SortPrefs{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefs := SortPrefs{}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return nil, fmt.Errorf("parse sort.json: %w", err)
+	}
+	return prefs, nil
+}
+
+// SaveSortPrefs writes prefs to ~/.config/hltui/sort.json.
+func SaveSortPrefs(prefs SortPrefs) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "sort.json"), data, 0o644)
+}
+
+// Watchlist is the coins starred in the Market view and the optional
+// columns hidden in its Watchlist mode.
+type Watchlist struct {
+	Coins  []string `json:"coins"`
+	Hidden []string `json:"hidden,omitempty"`
+}
+
+// LoadWatchlist reads ~/.config/hltui/watchlist.json. A missing file is
+// not an error; it means an empty watchlist showing every column.
+func LoadWatchlist() (Watchlist, error) {
+	dir, err := Dir()
+	if err != nil {
+		return Watchlist{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "watchlist.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Watchlist{}, nil
+	}
+	if err != nil {
+		return Watchlist{}, err
+	}
+	var w Watchlist
+	if err := json.Unmarshal(data, &w); err != nil {
+		return Watchlist{}, fmt.Errorf("parse watchlist.json: %w", err)
+	}
+	return w, nil
+}
+
+// SaveWatchlist writes w to ~/.config/hltui/watchlist.json.
+func SaveWatchlist(w Watchlist) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "watchlist.json"), data, 0o644)
+}
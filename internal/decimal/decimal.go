@@ -0,0 +1,290 @@
+// Package decimal is an exact base-10 number for prices, sizes and USD
+// amounts. Hyperliquid sends these as decimal strings; summing thousands of
+// them as float64 drifts by cents against the exchange's own totals.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is coef × 10^-scale. The zero value is 0. Decimals are immutable:
+// every operation returns a new value, so they are safe to copy and share.
+type Decimal struct {
+	coef  *big.Int // nil: zero
+	scale int32    // digits after the point, never negative
+}
+
+// Zero is the zero Decimal.
+var Zero Decimal
+
+var (
+	bigZero = new(big.Int)
+	bigTen  = big.NewInt(10)
+)
+
+// maxExponent bounds exponents accepted by Parse, so a hostile input can't
+// make it allocate a huge power of ten.
+const maxExponent = 1000
+
+// New returns coef × 10^-scale.
+func New(coef int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(coef), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// NewFromInt returns n.
+func NewFromInt(n int64) Decimal {
+	return New(n, 0)
+}
+
+// NewFromFloat returns the shortest decimal that rounds to f. NaN and
+// infinities become zero.
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Zero
+	}
+	d, _ := Parse(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// Parse parses a plain or exponent decimal string such as "-1234.50" or
+// "1.5e-7". Trailing zeros are kept, so String returns the input as sent.
+func Parse(s string) (Decimal, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxExponent || e < -maxExponent {
+			return Zero, fmt.Errorf("decimal: can't parse %q", orig)
+		}
+		exp, s = e, s[:i]
+	}
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg, s = s[0] == '-', s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	digits := intPart + frac
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Zero, fmt.Errorf("decimal: can't parse %q", orig)
+	}
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+	scale := len(frac) - exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParse is Parse for constants; it panics if s isn't a decimal.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) c() *big.Int {
+	if d.coef == nil {
+		return bigZero
+	}
+	return d.coef
+}
+
+// rescale returns d's coefficient at a scale of at least d.scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.c()
+	}
+	return new(big.Int).Mul(d.c(), pow10(scale-d.scale))
+}
+
+// align returns the coefficients of d and e at their common scale.
+func align(d, e Decimal) (dc, ec *big.Int, scale int32) {
+	scale = max(d.scale, e.scale)
+	return d.rescale(scale), e.rescale(scale), scale
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	dc, ec, scale := align(d, e)
+	return Decimal{coef: new(big.Int).Add(dc, ec), scale: scale}
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	dc, ec, scale := align(d, e)
+	return Decimal{coef: new(big.Int).Sub(dc, ec), scale: scale}
+}
+
+// Mul returns d × e, exactly.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.c(), e.c()), scale: d.scale + e.scale}
+}
+
+// Div returns d / e rounded half away from zero to places digits after the
+// point. Dividing by zero returns zero, like the guards callers would
+// otherwise need.
+func (d Decimal) Div(e Decimal, places int32) Decimal {
+	if e.IsZero() {
+		return Zero
+	}
+	num, den := new(big.Int).Set(d.c()), new(big.Int).Set(e.c())
+	// d/e = (dc/ec) × 10^(e.scale-d.scale); shift so the quotient has places
+	if k := places + e.scale - d.scale; k >= 0 {
+		num.Mul(num, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+	return Decimal{coef: quoRound(num, den), scale: places}
+}
+
+// quoRound returns num / den rounded half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	r.Abs(r).Lsh(r, 1)
+	if r.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign() == den.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Round returns d rounded half away from zero to places digits after the
+// point. Values with no more digits than that are returned as is.
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	return Decimal{coef: quoRound(d.c(), pow10(d.scale-places)), scale: places}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.c()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.Sign() >= 0 {
+		return d
+	}
+	return d.Neg()
+}
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int {
+	return d.c().Sign()
+}
+
+// IsZero reports whether d is 0. It also lets `omitzero` drop zero fields
+// when encoding.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and e, returning -1, 0 or +1.
+func (d Decimal) Cmp(e Decimal) int {
+	dc, ec, _ := align(d, e)
+	return dc.Cmp(ec)
+}
+
+// Equal reports whether d and e are the same number, whatever their
+// trailing zeros.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// Float64 returns the nearest float64, for ratios, charts and other
+// display math where exactness doesn't matter.
+func (d Decimal) Float64() float64 {
+	c := d.c()
+	// Exact integer over an exact power of ten rounds once: correctly
+	if c.IsInt64() && d.scale <= 22 {
+		if n := c.Int64(); n > -1<<53 && n < 1<<53 {
+			return float64(n) / math.Pow10(int(d.scale))
+		}
+	}
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d in plain notation with its full precision.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.c()).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		n := len(digits) - int(d.scale)
+		digits = digits[:n] + "." + digits[n:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// StringFixed returns d rounded half away from zero to exactly places
+// digits after the point.
+func (d Decimal) StringFixed(places int32) string {
+	r := d.Round(places)
+	if places > r.scale {
+		r = Decimal{coef: r.rescale(places), scale: places}
+	}
+	return r.String()
+}
+
+// MarshalJSON encodes d as a JSON string, as Hyperliquid does.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts a decimal as a JSON string or number. null and ""
+// decode as zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Zero
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+		if s == "" {
+			*d = Zero
+			return nil
+		}
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return errors.New("decimal: invalid JSON value " + string(data))
+	}
+	*d = v
+	return nil
+}
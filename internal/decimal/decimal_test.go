@@ -0,0 +1,143 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"314096.11", "314096.11"},
+		{"-148.250", "-148.250"},
+		{"0.0000693858", "0.0000693858"},
+		{"+7", "7"},
+		{".5", "0.5"},
+		{"1.5e-7", "0.00000015"},
+		{"2.5E3", "2500"},
+		{"-0", "0"},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "-", ".", "1.2.3", "abc", "1e", "1e99999", "0x10"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := MustParse("1234.5"), MustParse("-0.015")
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"add", a.Add(b), "1234.485"},
+		{"sub", a.Sub(b), "1234.515"},
+		{"mul", a.Mul(b), "-18.5175"},
+		{"div", a.Div(MustParse("3"), 4), "411.5000"},
+		{"div rounds half away", MustParse("-1").Div(MustParse("8"), 2), "-0.13"},
+		{"div by zero", a.Div(Zero, 2), "0"},
+		{"neg", b.Neg(), "0.015"},
+		{"abs", b.Abs(), "0.015"},
+		{"zero value", Zero.Add(a), "1234.5"},
+	}
+	for _, tt := range tests {
+		if got := tt.got.String(); got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || !MustParse("1.50").Equal(MustParse("1.5")) {
+		t.Error("Cmp/Equal")
+	}
+	if b.Sign() != -1 || !Zero.IsZero() || !MustParse("0.000").IsZero() {
+		t.Error("Sign/IsZero")
+	}
+}
+
+// Summing many fills comes out exact, where float64 drifts.
+func TestSumIsExact(t *testing.T) {
+	fee := MustParse("0.01")
+	var sum Decimal
+	for i := 0; i < 100000; i++ {
+		sum = sum.Add(fee)
+	}
+	if got := sum.String(); got != "1000.00" {
+		t.Errorf("sum = %s, want 1000.00", got)
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		input  string
+		places int32
+		want   string
+	}{
+		{"1.005", 2, "1.01"},
+		{"-1.005", 2, "-1.01"},
+		{"1.004", 2, "1.00"},
+		{"2.5", 0, "3"},
+		{"12", 2, "12.00"},
+		{"0.0000001", 6, "0.000000"},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.input).StringFixed(tt.places); got != tt.want {
+			t.Errorf("StringFixed(%s, %d) = %s, want %s", tt.input, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"314096.11", 314096.11},
+		{"-0.046", -0.046},
+		{"0.0000693858", 0.0000693858},
+		{"123456789012345678901234567890", 123456789012345678901234567890},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.input).Float64(); got != tt.want {
+			t.Errorf("Float64(%s) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	if got := NewFromFloat(0.1).String(); got != "0.1" {
+		t.Errorf("NewFromFloat(0.1) = %s", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		A Decimal  `json:"a"`
+		B Decimal  `json:"b"`
+		C Decimal  `json:"c"`
+		D Decimal  `json:"d"`
+		E *Decimal `json:"e"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"1.50","b":2.25,"c":"","d":null,"e":"-3"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "1.50" || v.B.String() != "2.25" || !v.C.IsZero() || !v.D.IsZero() || v.E == nil || v.E.String() != "-3" {
+		t.Errorf("decoded %v %v %v %v %v", v.A, v.B, v.C, v.D, v.E)
+	}
+	out, _ := json.Marshal(v)
+	if want := `{"a":"1.50","b":"2.25","c":"0","d":"0","e":"-3"}`; string(out) != want {
+		t.Errorf("encoded %s, want %s", out, want)
+	}
+	if err := json.Unmarshal([]byte(`{"a":"x"}`), &v); err == nil {
+		t.Error("decoded an invalid decimal")
+	}
+}
@@ -0,0 +1,94 @@
+// Package fakehl is an in-process fake of the Hyperliquid info API and
+// WebSocket feed. It serves an account loaded from a fixture and mutates it
+// according to a scripted Scenario, so the TUI and tests can run offline.
+package fakehl
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed fixtures/*.json
+var fixtureFS embed.FS
+
+// DemoAddress is the account served by the bundled demo fixture.
+const DemoAddress = "0x000000000000000000000000000000000000de30"
+
+// Fixture is the starting state of the fake exchange. Numbers are plain
+// floats here; the server renders them as strings like the real API does.
+type Fixture struct {
+	User      string            `json:"user"`
+	Balance   float64           `json:"balance"` // USDC collateral before uPnL
+	Assets    []FixtureAsset    `json:"assets"`
+	Positions []FixturePosition `json:"positions"`
+	Orders    []FixtureOrder    `json:"orders"`
+	Vaults    []FixtureVault    `json:"vaults"`
+	Staking   FixtureStaking    `json:"staking"`
+}
+
+type FixtureAsset struct {
+	Name         string  `json:"name"`
+	SzDecimals   int     `json:"szDecimals"`
+	MaxLeverage  int     `json:"maxLeverage"`
+	Mid          float64 `json:"mid"`
+	PrevDayPx    float64 `json:"prevDayPx"`
+	DayNtlVlm    float64 `json:"dayNtlVlm"`
+	OpenInterest float64 `json:"openInterest"`
+	Funding      float64 `json:"funding"` // hourly
+}
+
+type FixturePosition struct {
+	Coin       string  `json:"coin"`
+	Szi        float64 `json:"szi"`
+	EntryPx    float64 `json:"entryPx"`
+	Leverage   int     `json:"leverage"`
+	Isolated   bool    `json:"isolated"`
+	CumFunding float64 `json:"cumFunding"`
+}
+
+type FixtureOrder struct {
+	Coin       string  `json:"coin"`
+	Side       string  `json:"side"` // "B" or "A"
+	LimitPx    float64 `json:"limitPx"`
+	Sz         float64 `json:"sz"`
+	OrderType  string  `json:"orderType"`
+	ReduceOnly bool    `json:"reduceOnly"`
+}
+
+type FixtureVault struct {
+	Name           string  `json:"name"`
+	VaultAddress   string  `json:"vaultAddress"`
+	Leader         string  `json:"leader"`
+	Equity         float64 `json:"equity"` // the user's deposit; 0 = not invested
+	Tvl            float64 `json:"tvl"`
+	APR            float64 `json:"apr"`
+	LockupDays     int     `json:"lockupDays"`
+	CreatedDaysAgo int     `json:"createdDaysAgo"`
+}
+
+type FixtureStaking struct {
+	Undelegated float64            `json:"undelegated"`
+	DailyReward float64            `json:"dailyReward"`
+	Validators  []FixtureValidator `json:"validators"`
+}
+
+type FixtureValidator struct {
+	Validator  string  `json:"validator"`
+	Name       string  `json:"name"`
+	Commission float64 `json:"commission"`
+	Amount     float64 `json:"amount"` // delegated by the user
+}
+
+// LoadFixture reads a bundled fixture by name (e.g. "demo").
+func LoadFixture(name string) (*Fixture, error) {
+	data, err := fixtureFS.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("fixture %q: %w", name, err)
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse fixture %q: %w", name, err)
+	}
+	return &f, nil
+}
@@ -0,0 +1,211 @@
+package fakehl
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+// Scenario scripts how the fake exchange evolves. Every Tick, mids take a
+// random step of Volatility percent plus any per-coin Trend; Steps run on
+// their own timeline, each After the previous one.
+type Scenario struct {
+	Name        string
+	Description string
+	Tick        time.Duration
+	Volatility  float64            // percent per tick
+	Trend       map[string]float64 // percent per tick
+	Steps       []Step
+	Loop        bool
+}
+
+// Step is one scripted event.
+type Step struct {
+	After  time.Duration
+	Action Action
+}
+
+// Action is a scripted change to the exchange. Actions are applied with the
+// state locked and return the WebSocket messages to broadcast.
+type Action interface {
+	apply(s *State) []event
+}
+
+// event is a WebSocket push, or a forced disconnect when channel is empty.
+type event struct {
+	channel string
+	data    any
+}
+
+// MoveMid moves one coin's mid by Pct percent.
+type MoveMid struct {
+	Coin string
+	Pct  float64
+}
+
+// Trade executes a market fill of Sz (positive buys, negative sells) at the
+// current mid.
+type Trade struct {
+	Coin string
+	Sz   float64
+}
+
+// PlaceOrder rests a new limit order Offset percent away from the mid.
+type PlaceOrder struct {
+	Coin   string
+	IsBuy  bool
+	Sz     float64
+	Offset float64
+}
+
+// FillOrder fills the oldest open order on Coin at its limit price.
+type FillOrder struct {
+	Coin string
+}
+
+// CancelOrder cancels the oldest open order on Coin.
+type CancelOrder struct {
+	Coin string
+}
+
+// Disconnect drops every WebSocket connection.
+type Disconnect struct{}
+
+func (a MoveMid) apply(s *State) []event {
+	s.moveMid(a.Coin, a.Pct)
+	return []event{midsEvent(s)}
+}
+
+func (a Trade) apply(s *State) []event {
+	fill := s.trade(a.Coin, a.Sz, s.mid(a.Coin))
+	return []event{fillsEvent(s, fill)}
+}
+
+func (a PlaceOrder) apply(s *State) []event {
+	side := "A"
+	offset := 1 + a.Offset/100
+	if a.IsBuy {
+		side = "B"
+		offset = 1 - a.Offset/100
+	}
+	o := s.addOrder(a.Coin, side, s.mid(a.Coin)*offset, a.Sz, "Limit", false)
+	return []event{orderEvent(s, o, "open")}
+}
+
+func (a FillOrder) apply(s *State) []event {
+	o, ok := s.takeOrder(a.Coin)
+	if !ok {
+		return nil
+	}
+	px := o.LimitPx.Float64()
+	sz := o.Sz.Float64()
+	if o.Side == "A" {
+		sz = -sz
+	}
+	fill := s.trade(o.Coin, sz, px)
+	o.Sz = decimal.MustParse("0.0")
+	return []event{orderEvent(s, o, "filled"), fillsEvent(s, fill)}
+}
+
+func (a CancelOrder) apply(s *State) []event {
+	o, ok := s.takeOrder(a.Coin)
+	if !ok {
+		return nil
+	}
+	return []event{orderEvent(s, o, "canceled")}
+}
+
+func (Disconnect) apply(*State) []event {
+	return []event{{}}
+}
+
+// Scenarios are the built-in scripts, keyed by name.
+var Scenarios = map[string]Scenario{
+	"demo": {
+		Name:        "demo",
+		Description: "Drifting prices with trades, order activity and an occasional disconnect",
+		Tick:        time.Second,
+		Volatility:  0.06,
+		Loop:        true,
+		Steps: []Step{
+			{After: 6 * time.Second, Action: PlaceOrder{Coin: "BTC", IsBuy: true, Sz: 0.1, Offset: 0.05}},
+			{After: 7 * time.Second, Action: Trade{Coin: "ETH", Sz: 1.5}},
+			{After: 8 * time.Second, Action: FillOrder{Coin: "BTC"}},
+			{After: 6 * time.Second, Action: PlaceOrder{Coin: "SOL", IsBuy: false, Sz: 40, Offset: 2}},
+			{After: 9 * time.Second, Action: Trade{Coin: "HYPE", Sz: -250}},
+			{After: 8 * time.Second, Action: CancelOrder{Coin: "SOL"}},
+			{After: 10 * time.Second, Action: Trade{Coin: "DOGE", Sz: 25000}},
+			{After: 12 * time.Second, Action: Trade{Coin: "DOGE", Sz: -25000}},
+			{After: 20 * time.Second, Action: Disconnect{}},
+			{After: 14 * time.Second, Action: Trade{Coin: "ETH", Sz: -1.5}},
+			{After: 6 * time.Second, Action: Trade{Coin: "HYPE", Sz: 250}},
+		},
+	},
+	"trending": {
+		Name:        "trending",
+		Description: "Positions move steadily: BTC and SOL rally, ETH and HYPE bleed",
+		Tick:        time.Second,
+		Volatility:  0.02,
+		Trend:       map[string]float64{"BTC": 0.04, "SOL": 0.07, "ETH": -0.05, "HYPE": -0.06},
+	},
+	"fills": {
+		Name:        "fills",
+		Description: "A burst of fills opening, adding to and closing positions",
+		Tick:        2 * time.Second,
+		Volatility:  0.03,
+		Loop:        true,
+		Steps: []Step{
+			{After: 2 * time.Second, Action: Trade{Coin: "BTC", Sz: 0.05}},
+			{After: 2 * time.Second, Action: Trade{Coin: "AVAX", Sz: 150}},
+			{After: 2 * time.Second, Action: Trade{Coin: "ETH", Sz: -2}},
+			{After: 2 * time.Second, Action: Trade{Coin: "AVAX", Sz: -150}},
+			{After: 2 * time.Second, Action: Trade{Coin: "BTC", Sz: -0.05}},
+			{After: 2 * time.Second, Action: Trade{Coin: "ETH", Sz: 2}},
+		},
+	},
+	"orders": {
+		Name:        "orders",
+		Description: "Orders placed, filled and canceled",
+		Tick:        2 * time.Second,
+		Volatility:  0.03,
+		Loop:        true,
+		Steps: []Step{
+			{After: 3 * time.Second, Action: PlaceOrder{Coin: "ARB", IsBuy: true, Sz: 5000, Offset: 1}},
+			{After: 3 * time.Second, Action: PlaceOrder{Coin: "AVAX", IsBuy: false, Sz: 80, Offset: 1.5}},
+			{After: 4 * time.Second, Action: FillOrder{Coin: "ARB"}},
+			{After: 3 * time.Second, Action: CancelOrder{Coin: "AVAX"}},
+			{After: 4 * time.Second, Action: Trade{Coin: "ARB", Sz: -5000}},
+		},
+	},
+	"disconnects": {
+		Name:        "disconnects",
+		Description: "The WebSocket drops every 10 seconds",
+		Tick:        time.Second,
+		Volatility:  0.05,
+		Loop:        true,
+		Steps: []Step{
+			{After: 10 * time.Second, Action: Disconnect{}},
+		},
+	},
+}
+
+// ScenarioNames lists the built-in scenarios in a stable order.
+func ScenarioNames() []string {
+	names := make([]string, 0, len(Scenarios))
+	for name := range Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupScenario returns the named built-in scenario.
+func LookupScenario(name string) (Scenario, error) {
+	sc, ok := Scenarios[name]
+	if !ok {
+		return Scenario{}, fmt.Errorf("unknown scenario %q (have %v)", name, ScenarioNames())
+	}
+	return sc, nil
+}
@@ -0,0 +1,455 @@
+package fakehl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	"github.com/gorilla/websocket"
+)
+
+// Server serves State over HTTP /info and WebSocket /ws and plays back a
+// Scenario against it.
+type Server struct {
+	state    *State
+	scenario Scenario
+	upgrader websocket.Upgrader
+
+	mu    sync.Mutex
+	conns map[*wsConn]struct{}
+
+	httpSrv *http.Server
+	done    chan struct{}
+	once    sync.Once
+}
+
+type wsConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+	subs map[string]bool // subscription type
+}
+
+// New creates a server for fixture f. Nothing is scripted until Start, so
+// tests can drive the state step by step with Apply.
+func New(f *Fixture, sc Scenario, seed uint64) *Server {
+	return NewWithClock(f, sc, seed, time.Now)
+}
+
+// NewWithClock is New with the exchange clock supplied, so timestamps in
+// responses are reproducible.
+func NewWithClock(f *Fixture, sc Scenario, seed uint64, now func() time.Time) *Server {
+	return &Server{
+		state:    NewState(f, seed, now),
+		scenario: sc,
+		conns:    make(map[*wsConn]struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// NewDemo creates a server for the bundled demo fixture.
+func NewDemo(scenario string) (*Server, error) {
+	f, err := LoadFixture("demo")
+	if err != nil {
+		return nil, err
+	}
+	sc, err := LookupScenario(scenario)
+	if err != nil {
+		return nil, err
+	}
+	return New(f, sc, uint64(time.Now().UnixNano())), nil
+}
+
+// User returns the account served by the fixture.
+func (s *Server) User() string {
+	return s.state.User()
+}
+
+// Handler routes /info and /ws.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/info", s.handleInfo)
+	mux.HandleFunc("/ws", s.handleWS)
+	return mux
+}
+
+// Start listens on addr (e.g. "127.0.0.1:0"), starts scenario playback
+// and returns the base URL to use as the API host.
+func (s *Server) Start(addr string) (string, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("listen: %w", err)
+	}
+	s.httpSrv = &http.Server{Handler: s.Handler()}
+	go s.httpSrv.Serve(ln)
+	go s.play()
+	return "http://" + ln.Addr().String(), nil
+}
+
+// Close stops playback, drops connections and shuts the listener.
+func (s *Server) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.dropConns()
+		if s.httpSrv != nil {
+			s.httpSrv.Close()
+		}
+	})
+}
+
+// Apply runs one action immediately and broadcasts its events, followed by
+// a webData2 snapshot of the changed account.
+func (s *Server) Apply(a Action) {
+	s.state.mu.Lock()
+	events := a.apply(s.state)
+	if len(events) > 0 && events[0].channel != "" {
+		events = append(events, webDataEvent(s.state))
+	}
+	s.state.mu.Unlock()
+	s.broadcast(events)
+}
+
+// Drift runs one scenario tick: a random step of the mids.
+func (s *Server) Drift() {
+	s.state.mu.Lock()
+	s.state.drift(s.scenario.Volatility, s.scenario.Trend)
+	events := []event{midsEvent(s.state), webDataEvent(s.state)}
+	s.state.mu.Unlock()
+	s.broadcast(events)
+}
+
+func (s *Server) play() {
+	var tick <-chan time.Time
+	if s.scenario.Tick > 0 {
+		t := time.NewTicker(s.scenario.Tick)
+		defer t.Stop()
+		tick = t.C
+	}
+
+	step := 0
+	var next <-chan time.Time
+	if len(s.scenario.Steps) > 0 {
+		next = time.After(s.scenario.Steps[0].After)
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-tick:
+			s.Drift()
+		case <-next:
+			s.Apply(s.scenario.Steps[step].Action)
+			step++
+			if step == len(s.scenario.Steps) {
+				if !s.scenario.Loop {
+					next = nil
+					continue
+				}
+				step = 0
+			}
+			next = time.After(s.scenario.Steps[step].After)
+		}
+	}
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		Type         string `json:"type"`
+		User         string `json:"user"`
+		Coin         string `json:"coin"`
+		VaultAddress string `json:"vaultAddress"`
+		StartTime    int64  `json:"startTime"`
+		Req          struct {
+			Coin      string `json:"coin"`
+			Interval  string `json:"interval"`
+			StartTime int64  `json:"startTime"`
+			EndTime   int64  `json:"endTime"`
+		} `json:"req"` // candleSnapshot
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Failed to deserialize the JSON body", http.StatusUnprocessableEntity)
+		return
+	}
+
+	st := s.state
+	st.mu.Lock()
+	isUser := strings.EqualFold(req.User, st.user)
+	var resp any
+	switch req.Type {
+	case "allMids":
+		resp = st.mids()
+	case "metaAndAssetCtxs":
+		resp = st.metaAndAssetCtxs()
+	case "predictedFundings":
+		resp = st.predictedFundings()
+	case "fundingHistory":
+		resp = st.fundingHistory(req.Coin, req.StartTime)
+	case "l2Book":
+		resp = st.l2Book(req.Coin)
+	case "candleSnapshot":
+		resp = st.candles(req.Req.Coin, req.Req.Interval, req.Req.StartTime, req.Req.EndTime)
+	case "vaultSummaries":
+		resp = st.vaultSummaries()
+	case "validatorSummaries":
+		resp = st.validatorSummaries()
+	case "vaultDetails":
+		resp = st.vaultDetails(req.VaultAddress, req.User)
+	case "clearinghouseState":
+		if isUser {
+			resp = st.clearinghouseState()
+		} else {
+			zero := decimal.MustParse("0.0")
+			resp = api.ClearinghouseState{MarginSummary: api.MarginSummary{AccountValue: zero, TotalNtlPos: zero, TotalRawUsd: zero, TotalMarginUsed: zero}, Withdrawable: zero, AssetPositions: []api.AssetPosition{}}
+		}
+	case "frontendOpenOrders":
+		resp = userOnly(isUser, st.orders)
+	case "userFills":
+		resp = userOnly(isUser, st.fills)
+	case "userFillsByTime":
+		resp = userOnly(isUser, fillsSince(st.fills, req.StartTime))
+	case "userFunding":
+		resp = userOnly(isUser, st.userFunding(req.StartTime))
+	case "portfolio":
+		resp = st.portfolio()
+	case "userFees":
+		resp = st.userFees()
+	case "userRateLimit":
+		resp = st.userRateLimit()
+	case "userVaultEquities":
+		resp = userOnly(isUser, st.vaultEquities())
+	case "delegatorSummary":
+		resp = st.delegatorSummary()
+	case "delegations":
+		resp = userOnly(isUser, st.delegations())
+	case "delegatorRewards":
+		resp = userOnly(isUser, st.delegatorRewards())
+	case "delegatorHistory":
+		resp = userOnly(isUser, st.delegatorHistory())
+	default:
+		st.mu.Unlock()
+		http.Error(w, "Failed to deserialize the JSON body", http.StatusUnprocessableEntity)
+		return
+	}
+	data, err := json.Marshal(resp)
+	st.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// userOnly returns v for the fixture user and an empty list for anyone else.
+func userOnly[T any](isUser bool, v []T) []T {
+	if !isUser || v == nil {
+		return []T{}
+	}
+	return v
+}
+
+// fillsSince returns fills at or after startTime, oldest first like
+// userFillsByTime.
+func fillsSince(fills []api.Fill, startTime int64) []api.Fill {
+	var out []api.Fill
+	for i := len(fills) - 1; i >= 0; i-- {
+		if fills[i].Time >= startTime {
+			out = append(out, fills[i])
+		}
+	}
+	return out
+}
+
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &wsConn{conn: conn, subs: make(map[string]bool)}
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req struct {
+			Method       string            `json:"method"`
+			Subscription map[string]string `json:"subscription"`
+		}
+		if err := json.Unmarshal(data, &req); err != nil {
+			continue
+		}
+		switch req.Method {
+		case "ping":
+			c.write(event{channel: "pong"})
+		case "subscribe":
+			s.subscribe(c, req.Subscription)
+		case "unsubscribe":
+			c.mu.Lock()
+			delete(c.subs, req.Subscription["type"])
+			c.mu.Unlock()
+		}
+	}
+}
+
+// subscribe acknowledges a subscription and sends the initial snapshot the
+// real feed sends for it.
+func (s *Server) subscribe(c *wsConn, sub map[string]string) {
+	typ := sub["type"]
+	c.mu.Lock()
+	c.subs[typ] = true
+	c.mu.Unlock()
+	c.write(event{channel: "subscriptionResponse", data: map[string]any{"method": "subscribe", "subscription": sub}})
+
+	st := s.state
+	st.mu.Lock()
+	var snapshot *event
+	switch typ {
+	case "allMids":
+		e := midsEvent(st)
+		snapshot = &e
+	case "userFills":
+		fills := make([]ws.UserFillWs, 0, len(st.fills))
+		for _, f := range st.fills {
+			fills = append(fills, wsFill(f))
+		}
+		snapshot = &event{channel: "userFills", data: map[string]any{"isSnapshot": true, "user": st.user, "fills": fills}}
+	case "userFundings":
+		snapshot = &event{channel: "userFundings", data: map[string]any{"isSnapshot": true, "user": st.user, "fundings": []ws.UserFundingWs{}}}
+	case "webData2":
+		if strings.EqualFold(sub["user"], st.user) {
+			e := webDataEvent(st)
+			snapshot = &e
+		}
+	}
+	st.mu.Unlock()
+	if snapshot != nil {
+		c.write(*snapshot)
+	}
+}
+
+func (c *wsConn) write(e event) error {
+	data, err := json.Marshal(map[string]any{"channel": e.channel, "data": e.data})
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *wsConn) subscribed(channel string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.subs[channel]
+}
+
+func (s *Server) broadcast(events []event) {
+	for _, e := range events {
+		if e.channel == "" {
+			s.dropConns()
+			continue
+		}
+		s.mu.Lock()
+		conns := make([]*wsConn, 0, len(s.conns))
+		for c := range s.conns {
+			conns = append(conns, c)
+		}
+		s.mu.Unlock()
+		for _, c := range conns {
+			if c.subscribed(e.channel) {
+				c.write(e)
+			}
+		}
+	}
+}
+
+// dropConns closes every WebSocket so clients exercise their reconnect path.
+func (s *Server) dropConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.conn.Close()
+		delete(s.conns, c)
+	}
+}
+
+func midsEvent(s *State) event {
+	return event{channel: "allMids", data: ws.AllMidsData{Mids: s.mids()}}
+}
+
+func webDataEvent(s *State) event {
+	state := s.clearinghouseState()
+	meta, ctxs := s.metaCtxs()
+	return event{channel: "webData2", data: ws.WebData2{
+		User:               s.user,
+		ClearinghouseState: &state,
+		OpenOrders:         append([]api.OpenOrder{}, s.orders...),
+		Meta:               &meta,
+		AssetCtxs:          ctxs,
+		ServerTime:         s.now().UnixMilli(),
+	}}
+}
+
+func fillsEvent(s *State, f api.Fill) event {
+	return event{channel: "userFills", data: map[string]any{
+		"user":  s.user,
+		"fills": []ws.UserFillWs{wsFill(f)},
+	}}
+}
+
+func orderEvent(s *State, o api.OpenOrder, status string) event {
+	return event{channel: "orderUpdates", data: []ws.OrderUpdate{{
+		Order: ws.OrderInfo{
+			Coin:       o.Coin,
+			Side:       o.Side,
+			LimitPx:    o.LimitPx,
+			Sz:         o.Sz,
+			Oid:        o.Oid,
+			Timestamp:  o.Timestamp,
+			OrigSz:     o.OrigSz,
+			OrderType:  o.OrderType,
+			ReduceOnly: o.ReduceOnly,
+		},
+		Status:          status,
+		StatusTimestamp: s.now().UnixMilli(),
+	}}}
+}
+
+func wsFill(f api.Fill) ws.UserFillWs {
+	return ws.UserFillWs{
+		Coin:      f.Coin,
+		Px:        f.Px,
+		Sz:        f.Sz,
+		Side:      f.Side,
+		Time:      f.Time,
+		ClosedPnl: f.ClosedPnl,
+		Hash:      f.Hash,
+		Fee:       f.Fee,
+		Tid:       f.Tid,
+		Oid:       f.Oid,
+		Dir:       f.Dir,
+	}
+}
@@ -0,0 +1,250 @@
+package fakehl
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	"github.com/gorilla/websocket"
+)
+
+func newTestServer(t *testing.T, sc Scenario) (*Server, *httptest.Server, *api.Client) {
+	t.Helper()
+	f, err := LoadFixture("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := New(f, sc, 1)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(func() {
+		srv.Close()
+		ts.Close()
+	})
+	return srv, ts, api.NewClient(ts.URL + "/info")
+}
+
+func dialWS(t *testing.T, ts *httptest.Server) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readChannel reads until a message on channel arrives.
+func readChannel(t *testing.T, conn *websocket.Conn, channel string) ws.Message {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var msg ws.Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("waiting for %s: %v", channel, err)
+		}
+		if msg.Channel == channel {
+			return msg
+		}
+	}
+}
+
+func TestTradeUpdatesPositionAndFills(t *testing.T) {
+	srv, _, client := newTestServer(t, Scenario{})
+
+	before, err := client.GetUserFills(t.Context(), DemoAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Apply(Trade{Coin: "AVAX", Sz: 100})
+
+	fills, err := client.GetUserFills(t.Context(), DemoAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != len(before)+1 || fills[0].Coin != "AVAX" || fills[0].Dir != "Open Long" {
+		t.Fatalf("newest fill = %+v (count %d -> %d)", fills[0], len(before), len(fills))
+	}
+
+	state, err := client.GetClearinghouseState(t.Context(), DemoAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, ap := range state.AssetPositions {
+		if ap.Position.Coin == "AVAX" {
+			found = true
+			if ap.Position.Szi.String() != "100.00" {
+				t.Errorf("AVAX szi = %s, want 100.00", ap.Position.Szi)
+			}
+		}
+	}
+	if !found {
+		t.Error("AVAX position not opened")
+	}
+
+	// Closing removes the position and realizes PnL
+	srv.Apply(MoveMid{Coin: "AVAX", Pct: 10})
+	srv.Apply(Trade{Coin: "AVAX", Sz: -100})
+	fills, _ = client.GetUserFills(t.Context(), DemoAddress)
+	if fills[0].Dir != "Close Long" || fills[0].ClosedPnl.Sign() <= 0 {
+		t.Errorf("closing fill = %+v", fills[0])
+	}
+	state, _ = client.GetClearinghouseState(t.Context(), DemoAddress)
+	for _, ap := range state.AssetPositions {
+		if ap.Position.Coin == "AVAX" {
+			t.Error("AVAX position still open after close")
+		}
+	}
+}
+
+func TestOtherUsersAreEmpty(t *testing.T) {
+	_, _, client := newTestServer(t, Scenario{})
+	orders, err := client.GetOpenOrders(t.Context(), "0x0000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 0 {
+		t.Errorf("orders for unknown user = %d, want 0", len(orders))
+	}
+}
+
+func TestFundingHistoryPages(t *testing.T) {
+	_, _, client := newTestServer(t, Scenario{})
+	start := time.Now().Add(-30 * 24 * time.Hour).UnixMilli()
+	page, err := client.GetFundingHistory(t.Context(), "BTC", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != pageLimit {
+		t.Fatalf("first page = %d entries, want %d", len(page), pageLimit)
+	}
+	if page[0].Time < start {
+		t.Errorf("first entry %d before start %d", page[0].Time, start)
+	}
+}
+
+func TestUnknownInfoType(t *testing.T) {
+	_, ts, _ := newTestServer(t, Scenario{})
+	resp, err := ts.Client().Post(ts.URL+"/info", "application/json", strings.NewReader(`{"type":"nope"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 422 {
+		t.Errorf("status = %d, want 422", resp.StatusCode)
+	}
+}
+
+func TestWSSubscriptionsAndBroadcasts(t *testing.T) {
+	srv, ts, _ := newTestServer(t, Scenario{Volatility: 0.5})
+	conn := dialWS(t, ts)
+
+	for _, sub := range []ws.SubRequest{ws.SubAllMids(), ws.SubOrderUpdates(DemoAddress), ws.SubUserFills(DemoAddress)} {
+		if err := conn.WriteJSON(sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mids ws.AllMidsData
+	if err := json.Unmarshal(readChannel(t, conn, "allMids").Data, &mids); err != nil {
+		t.Fatal(err)
+	}
+	if mids.Mids["BTC"].IsZero() {
+		t.Fatal("allMids snapshot missing BTC")
+	}
+	readChannel(t, conn, "userFills") // snapshot
+
+	srv.Drift()
+	var moved ws.AllMidsData
+	json.Unmarshal(readChannel(t, conn, "allMids").Data, &moved)
+	if moved.Mids["BTC"] == mids.Mids["BTC"] {
+		t.Error("drift did not move BTC")
+	}
+
+	srv.Apply(PlaceOrder{Coin: "ETH", IsBuy: true, Sz: 1, Offset: 1})
+	var updates []ws.OrderUpdate
+	json.Unmarshal(readChannel(t, conn, "orderUpdates").Data, &updates)
+	if len(updates) != 1 || updates[0].Status != "open" || updates[0].Order.Coin != "ETH" {
+		t.Fatalf("order update = %+v", updates)
+	}
+
+	srv.Apply(FillOrder{Coin: "ETH"})
+	json.Unmarshal(readChannel(t, conn, "orderUpdates").Data, &updates)
+	if updates[0].Status != "filled" {
+		t.Errorf("status = %s, want filled", updates[0].Status)
+	}
+	var fills struct {
+		Fills []ws.UserFillWs `json:"fills"`
+	}
+	json.Unmarshal(readChannel(t, conn, "userFills").Data, &fills)
+	if len(fills.Fills) != 1 || fills.Fills[0].Coin != "ETH" {
+		t.Errorf("fill push = %+v", fills)
+	}
+}
+
+func TestWebData2(t *testing.T) {
+	srv, ts, _ := newTestServer(t, Scenario{})
+	conn := dialWS(t, ts)
+	conn.WriteJSON(ws.SubWebData2(DemoAddress))
+
+	var snap ws.WebData2
+	if err := json.Unmarshal(readChannel(t, conn, "webData2").Data, &snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.ClearinghouseState == nil || len(snap.ClearinghouseState.AssetPositions) == 0 {
+		t.Fatal("snapshot has no positions")
+	}
+	if snap.Meta == nil || len(snap.Meta.Universe) != len(snap.AssetCtxs) {
+		t.Fatal("snapshot universe and contexts don't line up")
+	}
+	orders := len(snap.OpenOrders)
+
+	srv.Apply(PlaceOrder{Coin: "ETH", IsBuy: true, Sz: 1, Offset: 1})
+	var next ws.WebData2
+	json.Unmarshal(readChannel(t, conn, "webData2").Data, &next)
+	if len(next.OpenOrders) != orders+1 {
+		t.Errorf("orders after placing = %d, want %d", len(next.OpenOrders), orders+1)
+	}
+}
+
+func TestDisconnectDropsConnections(t *testing.T) {
+	srv, ts, _ := newTestServer(t, Scenario{})
+	conn := dialWS(t, ts)
+	conn.WriteJSON(ws.SubAllMids())
+	readChannel(t, conn, "allMids")
+
+	srv.Apply(Disconnect{})
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Fatal("expected the connection to be closed")
+	}
+}
+
+func TestScenariosAreWellFormed(t *testing.T) {
+	f, err := LoadFixture("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range ScenarioNames() {
+		sc := Scenarios[name]
+		if sc.Name != name {
+			t.Errorf("scenario %q has Name %q", name, sc.Name)
+		}
+		// Every step must apply cleanly to the demo fixture
+		st := NewState(f, 1, time.Now)
+		for i, step := range sc.Steps {
+			if step.After <= 0 {
+				t.Errorf("%s step %d: After must be positive", name, i)
+			}
+			step.Action.apply(st)
+		}
+	}
+	if _, err := LookupScenario("missing"); err == nil {
+		t.Error("LookupScenario accepted an unknown name")
+	}
+}
@@ -0,0 +1,797 @@
+package fakehl
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+const (
+	// pageLimit mirrors the real API's cap on time-ranged responses.
+	pageLimit = 500
+
+	takerFee = 0.00035
+)
+
+type position struct {
+	coin       string
+	szi        float64
+	entryPx    float64
+	leverage   int
+	isolated   bool
+	cumFunding float64
+}
+
+// State is the mutable exchange state behind the fake server.
+type State struct {
+	mu  sync.Mutex
+	now func() time.Time
+	rng *rand.Rand
+
+	user      string
+	balance   float64
+	assets    []FixtureAsset
+	positions []*position
+	orders    []api.OpenOrder // oldest first
+	fills     []api.Fill      // newest first
+	vaults    []FixtureVault
+	staking   FixtureStaking
+	nextOid   int64
+	nextTid   int64
+}
+
+// NewState builds exchange state from a fixture. seed makes fills, hashes
+// and price drift reproducible.
+func NewState(f *Fixture, seed uint64, now func() time.Time) *State {
+	s := &State{
+		now:     now,
+		rng:     rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+		user:    strings.ToLower(f.User),
+		balance: f.Balance,
+		assets:  append([]FixtureAsset(nil), f.Assets...),
+		vaults:  f.Vaults,
+		staking: f.Staking,
+		nextOid: 40_000_000_000,
+		nextTid: 900_000_000_000_000,
+	}
+	t := now()
+	for i, p := range f.Positions {
+		s.positions = append(s.positions, &position{
+			coin:       p.Coin,
+			szi:        p.Szi,
+			entryPx:    p.EntryPx,
+			leverage:   p.Leverage,
+			isolated:   p.Isolated,
+			cumFunding: p.CumFunding,
+		})
+		// Opening fill so the fills view isn't empty
+		side := "B"
+		if p.Szi < 0 {
+			side = "A"
+		}
+		s.fills = append([]api.Fill{s.newFill(p.Coin, side, p.EntryPx, math.Abs(p.Szi), 0, 0,
+			t.Add(-time.Duration(len(f.Positions)-i)*37*time.Hour))}, s.fills...)
+	}
+	for _, o := range f.Orders {
+		s.addOrder(o.Coin, o.Side, o.LimitPx, o.Sz, o.OrderType, o.ReduceOnly)
+	}
+	return s
+}
+
+// User returns the fixture account address.
+func (s *State) User() string {
+	return s.user
+}
+
+func (s *State) asset(coin string) *FixtureAsset {
+	for i := range s.assets {
+		if s.assets[i].Name == coin {
+			return &s.assets[i]
+		}
+	}
+	return nil
+}
+
+func (s *State) position(coin string) *position {
+	for _, p := range s.positions {
+		if p.coin == coin {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *State) mid(coin string) float64 {
+	if a := s.asset(coin); a != nil {
+		return a.Mid
+	}
+	return 0
+}
+
+// moveMid moves a coin's mid by pct percent.
+func (s *State) moveMid(coin string, pct float64) {
+	if a := s.asset(coin); a != nil {
+		a.Mid *= 1 + pct/100
+	}
+}
+
+// drift applies a random walk with the given per-step volatility (percent)
+// plus an optional per-coin trend.
+func (s *State) drift(vol float64, trend map[string]float64) {
+	for i := range s.assets {
+		a := &s.assets[i]
+		pct := s.rng.NormFloat64()*vol + trend[a.Name]
+		a.Mid *= 1 + pct/100
+	}
+}
+
+func (s *State) newFill(coin, side string, px, sz, closedPnl, startPos float64, t time.Time) api.Fill {
+	s.nextTid++
+	dir := "Open Long"
+	switch {
+	case side == "B" && startPos < 0:
+		dir = "Close Short"
+	case side == "A" && startPos > 0:
+		dir = "Close Long"
+	case side == "A":
+		dir = "Open Short"
+	}
+	return api.Fill{
+		Coin:          coin,
+		Px:            formatPx(px),
+		Sz:            s.formatSz(coin, sz),
+		Side:          side,
+		Time:          t.UnixMilli(),
+		StartPosition: s.formatSz(coin, startPos),
+		Dir:           dir,
+		ClosedPnl:     formatUSD(closedPnl),
+		Hash:          s.hash(),
+		Oid:           s.nextOid,
+		Crossed:       true,
+		Fee:           formatUSD(px * sz * takerFee),
+		Tid:           s.nextTid,
+		FeeToken:      "USDC",
+	}
+}
+
+// trade executes a signed size at px against the account, updating the
+// position, balance and fills. It returns the new fill.
+func (s *State) trade(coin string, signedSz, px float64) api.Fill {
+	p := s.position(coin)
+	start := 0.0
+	if p != nil {
+		start = p.szi
+	}
+	side := "B"
+	if signedSz < 0 {
+		side = "A"
+	}
+
+	closedPnl := 0.0
+	if p == nil {
+		lev := 5
+		if a := s.asset(coin); a != nil && a.MaxLeverage < lev {
+			lev = a.MaxLeverage
+		}
+		p = &position{coin: coin, leverage: lev}
+		s.positions = append(s.positions, p)
+	}
+	newSzi := p.szi + signedSz
+	switch {
+	case p.szi == 0 || math.Signbit(p.szi) == math.Signbit(signedSz):
+		// Opening or adding: size-weighted entry
+		p.entryPx = (p.entryPx*math.Abs(p.szi) + px*math.Abs(signedSz)) / math.Abs(newSzi)
+	default:
+		closed := math.Min(math.Abs(signedSz), math.Abs(p.szi))
+		closedPnl = closed * (px - p.entryPx) * sign(p.szi)
+		if math.Signbit(newSzi) != math.Signbit(p.szi) && newSzi != 0 {
+			// Flipped through zero: the remainder opens at px
+			p.entryPx = px
+		}
+	}
+	p.szi = newSzi
+
+	fill := s.newFill(coin, side, px, math.Abs(signedSz), closedPnl, start, s.now())
+	s.balance += closedPnl - px*math.Abs(signedSz)*takerFee
+	s.fills = append([]api.Fill{fill}, s.fills...)
+
+	if math.Abs(p.szi) < 1e-12 {
+		s.removePosition(coin)
+	}
+	return fill
+}
+
+func (s *State) removePosition(coin string) {
+	for i, p := range s.positions {
+		if p.coin == coin {
+			s.positions = append(s.positions[:i], s.positions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *State) addOrder(coin, side string, px, sz float64, orderType string, reduceOnly bool) api.OpenOrder {
+	s.nextOid++
+	o := api.OpenOrder{
+		Coin:       coin,
+		Side:       side,
+		LimitPx:    formatPx(px),
+		Sz:         s.formatSz(coin, sz),
+		Oid:        s.nextOid,
+		Timestamp:  s.now().UnixMilli(),
+		OrigSz:     s.formatSz(coin, sz),
+		OrderType:  orderType,
+		ReduceOnly: reduceOnly,
+	}
+	if strings.Contains(orderType, "Stop") || strings.Contains(orderType, "Take Profit") {
+		o.IsTrigger = true
+		o.TriggerPx = o.LimitPx
+		o.TriggerCondition = "Price crosses " + o.LimitPx.String()
+	}
+	s.orders = append(s.orders, o)
+	return o
+}
+
+// takeOrder removes and returns the oldest open order on coin.
+func (s *State) takeOrder(coin string) (api.OpenOrder, bool) {
+	for i, o := range s.orders {
+		if o.Coin == coin {
+			s.orders = append(s.orders[:i], s.orders[i+1:]...)
+			return o, true
+		}
+	}
+	return api.OpenOrder{}, false
+}
+
+// mids renders allMids.
+func (s *State) mids() api.AllMids {
+	mids := make(api.AllMids, len(s.assets))
+	for _, a := range s.assets {
+		mids[a.Name] = formatPx(a.Mid)
+	}
+	return mids
+}
+
+func (s *State) metaAndAssetCtxs() []any {
+	meta, ctxs := s.metaCtxs()
+	return []any{meta, ctxs}
+}
+
+func (s *State) metaCtxs() (api.Meta, []api.AssetCtx) {
+	meta := api.Meta{}
+	ctxs := make([]api.AssetCtx, len(s.assets))
+	for i, a := range s.assets {
+		meta.Universe = append(meta.Universe, api.AssetMeta{
+			Name:        a.Name,
+			SzDecimals:  a.SzDecimals,
+			MaxLeverage: a.MaxLeverage,
+		})
+		ctxs[i] = api.AssetCtx{
+			Funding:      decimal.NewFromFloat(a.Funding),
+			OpenInterest: decimal.NewFromFloat(a.OpenInterest),
+			PrevDayPx:    formatPx(a.PrevDayPx),
+			DayNtlVlm:    formatUSD(a.DayNtlVlm),
+			Premium:      decimal.MustParse("0.0"),
+			OraclePx:     formatPx(a.Mid),
+			MarkPx:       formatPx(a.Mid),
+			MidPx:        formatPx(a.Mid),
+			ImpactPxs:    []decimal.Decimal{formatPx(a.Mid * 0.9999), formatPx(a.Mid * 1.0001)},
+		}
+	}
+	return meta, ctxs
+}
+
+func (s *State) clearinghouseState() api.ClearinghouseState {
+	var st api.ClearinghouseState
+	var upnl, ntl, marginUsed, maint float64
+	for _, p := range s.positions {
+		mid := s.mid(p.coin)
+		value := math.Abs(p.szi) * mid
+		pnl := p.szi * (mid - p.entryPx)
+		margin := value / float64(p.leverage)
+		maxLev := 50
+		if a := s.asset(p.coin); a != nil {
+			maxLev = a.MaxLeverage
+		}
+
+		levType := "cross"
+		var liq *decimal.Decimal
+		if p.isolated {
+			levType = "isolated"
+			// Liquidation when the initial margin less maintenance is gone
+			buffer := 1/float64(p.leverage) - 1/float64(2*maxLev)
+			px := formatPx(p.entryPx * (1 - sign(p.szi)*buffer))
+			liq = &px
+		}
+
+		st.AssetPositions = append(st.AssetPositions, api.AssetPosition{
+			Type: "oneWay",
+			Position: api.Position{
+				Coin:           p.coin,
+				Szi:            s.formatSz(p.coin, p.szi),
+				EntryPx:        formatPx(p.entryPx),
+				PositionValue:  formatUSD(value),
+				UnrealizedPnl:  formatUSD(pnl),
+				ReturnOnEquity: formatFixed(pnl/(math.Abs(p.szi)*p.entryPx/float64(p.leverage)), 6),
+				Leverage:       api.Leverage{Type: levType, Value: float64(p.leverage)},
+				LiquidationPx:  liq,
+				MarginUsed:     formatUSD(margin),
+				MaxLeverage:    maxLev,
+				CumFunding: &api.CumFunding{
+					AllTime:     formatUSD(p.cumFunding),
+					SinceOpen:   formatUSD(p.cumFunding),
+					SinceChange: formatUSD(p.cumFunding),
+				},
+			},
+		})
+		upnl += pnl
+		ntl += value
+		marginUsed += margin
+		maint += value / float64(2*maxLev)
+	}
+
+	accountValue := s.balance + upnl
+	st.MarginSummary = api.MarginSummary{
+		AccountValue:    formatUSD(accountValue),
+		TotalNtlPos:     formatUSD(ntl),
+		TotalRawUsd:     formatUSD(s.balance),
+		TotalMarginUsed: formatUSD(marginUsed),
+	}
+	st.CrossMaintenanceMarginUsed = formatUSD(maint)
+	st.Withdrawable = formatUSD(math.Max(accountValue-marginUsed, 0))
+	return st
+}
+
+// userFunding returns hourly payments since startTime for the current
+// positions, oldest first and capped like the real endpoint.
+func (s *State) userFunding(startTime int64) []api.FundingPaymentRaw {
+	var out []api.FundingPaymentRaw
+	end := s.now().Truncate(time.Hour)
+	for t := time.UnixMilli(startTime).Truncate(time.Hour).Add(time.Hour); !t.After(end); t = t.Add(time.Hour) {
+		for _, p := range s.positions {
+			rate := s.fundingAt(p.coin, t)
+			out = append(out, api.FundingPaymentRaw{
+				Time: t.UnixMilli(),
+				Hash: "0x" + strings.Repeat("0", 64),
+				Delta: api.FundingPaymentData{
+					Type:        "funding",
+					Coin:        p.coin,
+					Usdc:        formatUSD(-p.szi * s.mid(p.coin) * rate),
+					Szi:         s.formatSz(p.coin, p.szi),
+					FundingRate: formatFixed(rate, 8),
+				},
+			})
+			if len(out) == pageLimit {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+// fundingAt is a deterministic hourly funding rate oscillating around the
+// asset's current rate.
+func (s *State) fundingAt(coin string, t time.Time) float64 {
+	a := s.asset(coin)
+	if a == nil {
+		return 0
+	}
+	hours := float64(t.Unix() / 3600)
+	return a.Funding * (1 + 0.6*math.Sin(hours/9) + 0.25*math.Cos(hours/2.3))
+}
+
+func (s *State) fundingHistory(coin string, startTime int64) []api.FundingHistoryEntry {
+	var out []api.FundingHistoryEntry
+	end := s.now()
+	t := time.UnixMilli(startTime).Truncate(time.Hour)
+	if t.UnixMilli() < startTime {
+		t = t.Add(time.Hour)
+	}
+	for ; !t.After(end) && len(out) < pageLimit; t = t.Add(time.Hour) {
+		rate := s.fundingAt(coin, t)
+		out = append(out, api.FundingHistoryEntry{
+			Coin:        coin,
+			FundingRate: formatFixed(rate, 8),
+			Premium:     formatFixed(rate*0.8, 8),
+			Time:        t.UnixMilli(),
+		})
+	}
+	return out
+}
+
+// bookLevels is how many levels each side of the l2Book response has, as on
+// the real API.
+const bookLevels = 20
+
+// l2Book renders a book around the coin's mid, one basis point per level,
+// deepening away from the touch. It is nil for unknown coins.
+func (s *State) l2Book(coin string) *api.L2Book {
+	a := s.asset(coin)
+	if a == nil {
+		return nil
+	}
+	book := &api.L2Book{Coin: coin, Time: s.now().UnixMilli()}
+	// About 1/2000 of a day's volume at the touch
+	touch := a.DayNtlVlm / a.Mid / 2000
+	for i := range bookLevels {
+		step := float64(i+1) * 0.0001
+		sz := touch * (1 + 0.35*float64(i)) * (1 + 0.3*math.Sin(float64(i)*1.7))
+		n := 1 + (i*7)%5
+		book.Levels[0] = append(book.Levels[0], api.L2Level{Px: formatPx(a.Mid * (1 - step)), Sz: s.formatSz(coin, sz), N: n})
+		book.Levels[1] = append(book.Levels[1], api.L2Level{Px: formatPx(a.Mid * (1 + step)), Sz: s.formatSz(coin, sz*1.1), N: n + 1})
+	}
+	return book
+}
+
+// candleIntervals are the candleSnapshot intervals the fake serves.
+var candleIntervals = map[string]time.Duration{
+	"1m": time.Minute, "5m": 5 * time.Minute, "15m": 15 * time.Minute,
+	"1h": time.Hour, "4h": 4 * time.Hour, "1d": 24 * time.Hour,
+}
+
+// maxCandles mirrors the real API's cap on candles per response.
+const maxCandles = 5000
+
+// candles renders a deterministic price path ending at the current mid.
+func (s *State) candles(coin, interval string, startTime, endTime int64) []api.Candle {
+	a := s.asset(coin)
+	step, ok := candleIntervals[interval]
+	if a == nil || !ok {
+		return []api.Candle{}
+	}
+	now := s.now()
+	if end := time.UnixMilli(endTime); end.Before(now) {
+		now = end
+	}
+	nowHours := float64(s.now().Unix()) / 3600
+	pxAt := func(t time.Time) float64 {
+		h := float64(t.Unix()) / 3600
+		return a.Mid * (1 + 0.02*(math.Sin(h/7)-math.Sin(nowHours/7)) + 0.006*(math.Cos(h/1.3)-math.Cos(nowHours/1.3)))
+	}
+
+	first := now.Truncate(step).Add(-(maxCandles - 1) * step)
+	if start := time.UnixMilli(startTime).Truncate(step); start.After(first) {
+		first = start
+	}
+	out := []api.Candle{}
+	for t := first; !t.After(now); t = t.Add(step) {
+		closeAt := t.Add(step - time.Millisecond)
+		if closeAt.After(now) {
+			closeAt = now
+		}
+		open, cls := pxAt(t), pxAt(closeAt)
+		hi, lo := math.Max(open, cls)*1.002, math.Min(open, cls)*0.998
+		vol := a.DayNtlVlm / a.Mid * step.Hours() / 24
+		out = append(out, api.Candle{
+			OpenTime:  t.UnixMilli(),
+			CloseTime: t.Add(step).UnixMilli() - 1,
+			Coin:      coin,
+			Interval:  interval,
+			Open:      formatPx(open),
+			Close:     formatPx(cls),
+			High:      formatPx(hi),
+			Low:       formatPx(lo),
+			Volume:    s.formatSz(coin, vol),
+			Trades:    int(vol) + 1,
+		})
+	}
+	return out
+}
+
+// predictedFundings renders [[coin, [[venue, {...}], ...]], ...]. CEX venues
+// quote 8h rates slightly off Hyperliquid's so the arb columns have data.
+func (s *State) predictedFundings() []any {
+	next := s.now().Truncate(time.Hour).Add(time.Hour).UnixMilli()
+	next8 := s.now().Truncate(8 * time.Hour).Add(8 * time.Hour).UnixMilli()
+	var out []any
+	for i, a := range s.assets {
+		venues := []any{
+			[]any{api.VenueHyperliquid, map[string]any{
+				"fundingRate": strconv.FormatFloat(a.Funding, 'f', 8, 64), "nextFundingTime": next, "fundingIntervalHours": 1}},
+			[]any{api.VenueBinance, map[string]any{
+				"fundingRate": strconv.FormatFloat(a.Funding*8*(0.4+0.3*float64(i%4)), 'f', 8, 64), "nextFundingTime": next8, "fundingIntervalHours": 8}},
+		}
+		if i%3 == 2 {
+			venues = append(venues, []any{api.VenueBybit, nil})
+		} else {
+			venues = append(venues, []any{api.VenueBybit, map[string]any{
+				"fundingRate": strconv.FormatFloat(a.Funding*8*1.1, 'f', 8, 64), "nextFundingTime": next8, "fundingIntervalHours": 8}})
+		}
+		out = append(out, []any{a.Name, venues})
+	}
+	return out
+}
+
+// portfolio renders the eight periods with a gentle account value history
+// ending at the current account value.
+func (s *State) portfolio() []any {
+	ch := s.clearinghouseState()
+	acct := ch.MarginSummary.AccountValue.Float64()
+	now := s.now()
+	periods := []struct {
+		name   string
+		span   time.Duration
+		points int
+		vlm    float64
+	}{
+		{"day", 24 * time.Hour, 48, 1.8e6},
+		{"week", 7 * 24 * time.Hour, 84, 1.1e7},
+		{"month", 30 * 24 * time.Hour, 120, 4.6e7},
+		{"allTime", 400 * 24 * time.Hour, 200, 3.9e8},
+	}
+	var out []any
+	for _, prefix := range []string{"", "perp"} {
+		for _, p := range periods {
+			name := p.name
+			if prefix != "" {
+				name = prefix + strings.ToUpper(name[:1]) + name[1:]
+			}
+			av, pnl := s.history(acct, now, p.span, p.points)
+			out = append(out, []any{name, map[string]any{
+				"accountValueHistory": av,
+				"pnlHistory":          pnl,
+				"vlm":                 formatUSD(p.vlm),
+			}})
+		}
+	}
+	return out
+}
+
+// history builds [[time, value], ...] series walking back from end. Fixed
+// waves keep it stable across requests.
+func (s *State) history(end float64, now time.Time, span time.Duration, points int) (av, pnl [][2]any) {
+	step := span / time.Duration(points)
+	start := end * (1 - 0.04*span.Hours()/(24*30))
+	for i := 0; i <= points; i++ {
+		frac := float64(i) / float64(points)
+		v := start + (end-start)*frac + end*0.01*math.Sin(frac*11)*(1-frac)
+		t := now.Add(-span + time.Duration(i)*step).UnixMilli()
+		av = append(av, [2]any{t, formatUSD(v)})
+		pnl = append(pnl, [2]any{t, formatUSD(v - start)})
+	}
+	return av, pnl
+}
+
+func (s *State) userFees() api.UserFees {
+	var daily []api.DailyVolume
+	for i := 13; i >= 0; i-- {
+		d := s.now().AddDate(0, 0, -i)
+		daily = append(daily, api.DailyVolume{
+			Date:  d.Format("2006-01-02"),
+			Cross: formatUSD(250_000 + 40_000*math.Sin(float64(i))),
+			Add:   formatUSD(90_000 + 15_000*math.Cos(float64(i))),
+		})
+	}
+	return api.UserFees{
+		ActiveReferralDiscount: decimal.MustParse("0.04"),
+		DailyUserVlm:           daily,
+		FeeSchedule:            api.FeeSchedule{Cross: decimal.MustParse("0.00045"), Add: decimal.MustParse("0.00015")},
+		UserCrossRate:          decimal.MustParse("0.00035"),
+		UserAddRate:            decimal.MustParse("0.0001"),
+	}
+}
+
+// userRateLimit grants the initial 10k requests plus one per USDC traded,
+// with one request used per order placed and per fill.
+func (s *State) userRateLimit() api.UserRateLimit {
+	var vlm float64
+	for _, f := range s.fills {
+		vlm += f.Px.Float64() * f.Sz.Float64()
+	}
+	return api.UserRateLimit{
+		CumVlm:        formatUSD(vlm),
+		NRequestsUsed: int64(len(s.fills) + len(s.orders)),
+		NRequestsCap:  10_000 + int64(vlm),
+	}
+}
+
+func (s *State) vaultEquities() []api.VaultEquity {
+	var out []api.VaultEquity
+	for _, v := range s.vaults {
+		if v.Equity <= 0 {
+			continue
+		}
+		out = append(out, api.VaultEquity{
+			VaultAddress:         v.VaultAddress,
+			Equity:               formatUSD(v.Equity),
+			LockedUntilTimestamp: s.lockup(v),
+		})
+	}
+	return out
+}
+
+// lockup places each deposit partway through its lockup so countdowns show.
+func (s *State) lockup(v FixtureVault) int64 {
+	return s.now().Add(time.Duration(v.LockupDays)*24*time.Hour/3 + 5*time.Hour).UnixMilli()
+}
+
+// vaultDetails renders details for address, or nil if the fixture has no
+// such vault (the real API returns null).
+func (s *State) vaultDetails(address, user string) *vaultDetailsResponse {
+	for _, v := range s.vaults {
+		if !strings.EqualFold(v.VaultAddress, address) {
+			continue
+		}
+		d := &vaultDetailsResponse{
+			Name:             v.Name,
+			VaultAddress:     v.VaultAddress,
+			Leader:           v.Leader,
+			Description:      "Fixture vault served by the hltui demo server.",
+			LeaderCommission: 0.1,
+			LeaderFraction:   0.12,
+			MaxDistributable: v.Tvl * 0.3,
+			MaxWithdrawable:  v.Tvl * 0.3,
+			APR:              v.APR,
+			AllowDeposits:    true,
+		}
+		now := s.now()
+		for _, p := range []struct {
+			name   string
+			span   time.Duration
+			points int
+		}{{"day", 24 * time.Hour, 24}, {"week", 7 * 24 * time.Hour, 42}, {"month", 30 * 24 * time.Hour, 60}, {"allTime", time.Duration(v.CreatedDaysAgo) * 24 * time.Hour, 120}} {
+			av, pnl := s.history(v.Tvl, now, p.span, p.points)
+			d.Portfolio = append(d.Portfolio, []any{p.name, map[string]any{
+				"accountValueHistory": av, "pnlHistory": pnl, "vlm": formatUSD(v.Tvl * 2),
+			}})
+		}
+		if v.Equity > 0 && strings.EqualFold(user, s.user) {
+			d.FollowerState = &api.FollowerState{
+				User:           s.user,
+				VaultEquity:    formatUSD(v.Equity),
+				Pnl:            formatUSD(v.Equity * v.APR / 12),
+				AllTimePnl:     formatUSD(v.Equity * v.APR / 4),
+				DaysFollowing:  90,
+				VaultEntryTime: now.AddDate(0, 0, -90).UnixMilli(),
+				LockupUntil:    s.lockup(v),
+			}
+		}
+		return d
+	}
+	return nil
+}
+
+// vaultDetailsResponse mirrors api.VaultDetails with a raw portfolio, since
+// api.PortfolioPeriods only decodes.
+type vaultDetailsResponse struct {
+	Name             string             `json:"name"`
+	VaultAddress     string             `json:"vaultAddress"`
+	Leader           string             `json:"leader"`
+	Description      string             `json:"description"`
+	LeaderCommission float64            `json:"leaderCommission"`
+	LeaderFraction   float64            `json:"leaderFraction"`
+	MaxDistributable float64            `json:"maxDistributable"`
+	MaxWithdrawable  float64            `json:"maxWithdrawable"`
+	APR              float64            `json:"apr"`
+	IsClosed         bool               `json:"isClosed"`
+	AllowDeposits    bool               `json:"allowDeposits"`
+	FollowerState    *api.FollowerState `json:"followerState"`
+	Portfolio        []any              `json:"portfolio"`
+}
+
+func (s *State) vaultSummaries() []api.VaultSummary {
+	var out []api.VaultSummary
+	for _, v := range s.vaults {
+		out = append(out, api.VaultSummary{
+			Name:             v.Name,
+			VaultAddress:     v.VaultAddress,
+			Leader:           v.Leader,
+			Tvl:              formatUSD(v.Tvl),
+			Relationship:     api.VaultRelationship{Type: "normal"},
+			CreateTimeMillis: s.now().AddDate(0, 0, -v.CreatedDaysAgo).UnixMilli(),
+		})
+	}
+	return out
+}
+
+func (s *State) delegatorSummary() api.DelegatorSummary {
+	var delegated float64
+	for _, v := range s.staking.Validators {
+		delegated += v.Amount
+	}
+	return api.DelegatorSummary{
+		Delegated:              formatUSD(delegated),
+		Undelegated:            formatUSD(s.staking.Undelegated),
+		TotalPendingWithdrawal: decimal.MustParse("0.0"),
+	}
+}
+
+func (s *State) delegations() []api.Delegation {
+	var out []api.Delegation
+	for i, v := range s.staking.Validators {
+		out = append(out, api.Delegation{
+			Validator:            v.Validator,
+			Amount:               formatUSD(v.Amount),
+			LockedUntilTimestamp: s.now().Add(time.Duration(i*20) * time.Hour).UnixMilli(),
+		})
+	}
+	return out
+}
+
+func (s *State) delegatorRewards() []api.DelegatorReward {
+	var out []api.DelegatorReward
+	day := s.now().Truncate(24 * time.Hour)
+	for i := 0; i < 30; i++ {
+		out = append(out, api.DelegatorReward{
+			Time:        day.AddDate(0, 0, -i).UnixMilli(),
+			Source:      "delegation",
+			TotalAmount: formatFixed(s.staking.DailyReward*(1+0.1*math.Sin(float64(i))), 8),
+		})
+	}
+	return out
+}
+
+func (s *State) delegatorHistory() []map[string]any {
+	var out []map[string]any
+	for i, v := range s.staking.Validators {
+		out = append(out, map[string]any{
+			"time": s.now().AddDate(0, 0, -30-10*i).UnixMilli(),
+			"hash": s.hash(),
+			"delta": map[string]any{"delegate": map[string]any{
+				"validator": v.Validator, "amount": formatUSD(v.Amount), "isUndelegate": false,
+			}},
+		})
+	}
+	return out
+}
+
+func (s *State) validatorSummaries() []api.ValidatorSummary {
+	var out []api.ValidatorSummary
+	for _, v := range s.staking.Validators {
+		out = append(out, api.ValidatorSummary{
+			Validator:  v.Validator,
+			Name:       v.Name,
+			Commission: decimal.NewFromFloat(v.Commission),
+			IsActive:   true,
+		})
+	}
+	return out
+}
+
+func (s *State) hash() string {
+	return fmt.Sprintf("0x%016x%016x%016x%016x", s.rng.Uint64(), s.rng.Uint64(), s.rng.Uint64(), s.rng.Uint64())
+}
+
+func (s *State) formatSz(coin string, sz float64) decimal.Decimal {
+	dec := 4
+	if a := s.asset(coin); a != nil {
+		dec = a.SzDecimals
+	}
+	return formatFixed(sz, dec)
+}
+
+// formatPx rounds to five significant figures like Hyperliquid prices.
+func formatPx(px float64) decimal.Decimal {
+	if px == 0 {
+		return decimal.MustParse("0.0")
+	}
+	digits := 5 - int(math.Floor(math.Log10(math.Abs(px)))) - 1
+	if digits < 0 {
+		p := math.Pow(10, float64(-digits))
+		return formatFixed(math.Round(px/p)*p, 1)
+	}
+	return formatFixed(px, digits)
+}
+
+func formatUSD(v float64) decimal.Decimal {
+	return formatFixed(v, 6)
+}
+
+// formatFixed rounds v to places digits after the point.
+func formatFixed(v float64, places int) decimal.Decimal {
+	return decimal.MustParse(strconv.FormatFloat(v, 'f', places, 64))
+}
+
+func sign(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
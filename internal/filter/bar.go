@@ -0,0 +1,101 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bar is the filter line a view shows above its table. `/` opens it; while
+// it is open every key edits the expression, which applies as it is typed.
+// Enter keeps the filter and closes the bar, Esc clears it.
+type Bar[R any] struct {
+	fields  []Field[R]
+	input   textinput.Model
+	editing bool
+	filter  Filter[R] // the last expression that parsed
+	err     error     // why the text being edited doesn't parse
+}
+
+// NewBar returns a closed bar over fields.
+func NewBar[R any](fields []Field[R]) Bar[R] {
+	in := textinput.New()
+	in.Prompt = "/"
+	in.Placeholder = "btc  side:short  pnl<0  time:24h"
+	in.CharLimit = 200
+	return Bar[R]{fields: fields, input: in}
+}
+
+// Editing reports whether the bar has the keyboard.
+func (b Bar[R]) Editing() bool {
+	return b.editing
+}
+
+// Shown reports whether the bar takes a line: while editing, or while a
+// filter applies.
+func (b Bar[R]) Shown() bool {
+	return b.editing || !b.filter.Empty()
+}
+
+// Update opens the bar on `/` and, while it is open, takes every key. It
+// reports whether it used msg.
+func (b Bar[R]) Update(msg tea.KeyMsg) (Bar[R], tea.Cmd, bool) {
+	if !b.editing {
+		if msg.String() != "/" {
+			return b, nil, false
+		}
+		b.editing = true
+		b.input.SetValue(b.filter.String())
+		b.input.CursorEnd()
+		return b, b.input.Focus(), true
+	}
+
+	switch msg.String() {
+	case "enter":
+		b.editing = false
+		b.input.Blur()
+		b.err = nil
+		return b, nil, true
+	case "esc":
+		b.editing = false
+		b.input.Blur()
+		b.input.SetValue("")
+		b.filter, b.err = Filter[R]{}, nil
+		return b, nil, true
+	}
+
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	if f, err := Parse(b.input.Value(), b.fields); err != nil {
+		b.err = err
+	} else {
+		b.filter, b.err = f, nil
+	}
+	return b, cmd, true
+}
+
+// Apply removes the rows the filter doesn't match.
+func (b Bar[R]) Apply(rows []R) []R {
+	return b.filter.Apply(rows)
+}
+
+// View renders the bar with how many of total rows are shown, or "" when
+// the bar is hidden.
+func (b Bar[R]) View(shown, total int) string {
+	if !b.Shown() {
+		return ""
+	}
+	line := style.Yellow.Render("/" + b.filter.String())
+	if b.editing {
+		line = b.input.View()
+	}
+	status := style.Dim.Render(fmt.Sprintf("  %d of %d", shown, total))
+	if b.err != nil {
+		status = style.Red.Render("  " + b.err.Error())
+	} else if !b.editing {
+		status += style.Dim.Render("  [/] edit")
+	}
+	return line + status
+}
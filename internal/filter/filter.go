@@ -0,0 +1,349 @@
+// Package filter parses the expressions typed into a view's `/` bar and
+// applies them to the view's rows. An expression is a list of terms, all of
+// which must match:
+//
+//	btc              any text field (coin, side, ...) contains "btc"
+//	coin:BTC,ETH     coin is BTC or ETH
+//	side:short       side is SHORT
+//	pnl<0  lev>=10   numbers compare with <, <=, >, >= and : or =
+//	time>7d          newer than 7 days ago
+//	time<2026-10-01  before that date
+//	time:24h         within the last 24 hours
+//	time:2026-10-01..2026-10-05
+//	!coin:BTC        ! negates a term
+//
+// Numbers may carry a $ or % and a k, m or b suffix. Dates are local, as
+// the views show them, and a date range includes its last day.
+package filter
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+type kind int
+
+const (
+	textKind kind = iota
+	numberKind
+	timeKind
+)
+
+// Field is a value of a row that terms can name.
+type Field[R any] struct {
+	Name string
+	kind kind
+	text func(R) string
+	num  func(R) float64
+	time func(R) int64
+}
+
+// Text is a field matched by name:value, ignoring case, and searched by
+// bare words.
+func Text[R any](name string, f func(R) string) Field[R] {
+	return Field[R]{Name: name, kind: textKind, text: f}
+}
+
+// Number is a field compared numerically. Fields a view shows as a
+// percentage should return the percentage, so terms match what is on
+// screen.
+func Number[R any](name string, f func(R) float64) Field[R] {
+	return Field[R]{Name: name, kind: numberKind, num: f}
+}
+
+// Decimal is a Number read from a decimal.
+func Decimal[R any](name string, f func(R) decimal.Decimal) Field[R] {
+	return Number(name, func(r R) float64 { return f(r).Float64() })
+}
+
+// Time is a field holding a unix millisecond timestamp.
+func Time[R any](name string, f func(R) int64) Field[R] {
+	return Field[R]{Name: name, kind: timeKind, time: f}
+}
+
+// pred reports whether a row matches one term. Relative times are taken
+// from now, so a saved filter keeps its window current.
+type pred[R any] func(r R, now time.Time) bool
+
+// Filter is a parsed expression. The zero Filter matches every row.
+type Filter[R any] struct {
+	expr  string
+	preds []pred[R]
+}
+
+// Parse parses expr against the fields a view offers.
+func Parse[R any](expr string, fields []Field[R]) (Filter[R], error) {
+	f := Filter[R]{expr: strings.TrimSpace(expr)}
+	for _, term := range strings.Fields(expr) {
+		p, err := parseTerm(term, fields)
+		if err != nil {
+			return Filter[R]{}, err
+		}
+		f.preds = append(f.preds, p)
+	}
+	return f, nil
+}
+
+// Empty reports whether the filter matches everything.
+func (f Filter[R]) Empty() bool {
+	return len(f.preds) == 0
+}
+
+// String returns the expression as typed, trimmed.
+func (f Filter[R]) String() string {
+	return f.expr
+}
+
+// Match reports whether r matches every term as of now.
+func (f Filter[R]) Match(r R, now time.Time) bool {
+	for _, p := range f.preds {
+		if !p(r, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply removes the rows that don't match, reusing rows' backing array.
+func (f Filter[R]) Apply(rows []R) []R {
+	if f.Empty() {
+		return rows
+	}
+	now := util.Now()
+	return slices.DeleteFunc(rows, func(r R) bool { return !f.Match(r, now) })
+}
+
+func parseTerm[R any](term string, fields []Field[R]) (pred[R], error) {
+	if rest, ok := strings.CutPrefix(term, "!"); ok {
+		if rest == "" {
+			return nil, fmt.Errorf("nothing after !")
+		}
+		p, err := parseTerm(rest, fields)
+		if err != nil {
+			return nil, err
+		}
+		return func(r R, now time.Time) bool { return !p(r, now) }, nil
+	}
+
+	i := strings.IndexAny(term, ":<>=")
+	if i < 0 {
+		return search(term, fields), nil
+	}
+	name, op, value := term[:i], term[i:i+1], term[i+1:]
+	if strings.HasPrefix(value, "=") && (op == "<" || op == ">") {
+		op, value = op+"=", value[1:]
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing field before %q", op)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value after %s%s", name, op)
+	}
+	if op == "=" {
+		op = ":"
+	}
+
+	fi := slices.IndexFunc(fields, func(f Field[R]) bool { return strings.EqualFold(f.Name, name) })
+	if fi < 0 {
+		return nil, fmt.Errorf("unknown field %q; try %s", name, fieldNames(fields))
+	}
+	switch field := fields[fi]; field.kind {
+	case numberKind:
+		return numberTerm(field, op, value)
+	case timeKind:
+		return timeTerm(field, op, value)
+	default:
+		return textTerm(field, op, value)
+	}
+}
+
+func fieldNames[R any](fields []Field[R]) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// search matches word anywhere in any text field.
+func search[R any](word string, fields []Field[R]) pred[R] {
+	word = strings.ToLower(word)
+	return func(r R, _ time.Time) bool {
+		for _, f := range fields {
+			if f.kind == textKind && strings.Contains(strings.ToLower(f.text(r)), word) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func textTerm[R any](field Field[R], op, value string) (pred[R], error) {
+	if op != ":" {
+		return nil, fmt.Errorf("%s is text; use %s:value", field.Name, field.Name)
+	}
+	values := strings.Split(value, ",")
+	return func(r R, _ time.Time) bool {
+		v := field.text(r)
+		return slices.ContainsFunc(values, func(want string) bool { return strings.EqualFold(v, want) })
+	}, nil
+}
+
+func numberTerm[R any](field Field[R], op, value string) (pred[R], error) {
+	if op == ":" {
+		var values []float64
+		for _, s := range strings.Split(value, ",") {
+			n, err := parseNumber(s)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, n)
+		}
+		return func(r R, _ time.Time) bool { return slices.Contains(values, field.num(r)) }, nil
+	}
+	n, err := parseNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	cmp := compare(op)
+	return func(r R, _ time.Time) bool {
+		v := field.num(r)
+		return !math.IsNaN(v) && cmp(v, n)
+	}, nil
+}
+
+func compare(op string) func(a, b float64) bool {
+	switch op {
+	case "<":
+		return func(a, b float64) bool { return a < b }
+	case "<=":
+		return func(a, b float64) bool { return a <= b }
+	case ">":
+		return func(a, b float64) bool { return a > b }
+	default:
+		return func(a, b float64) bool { return a >= b }
+	}
+}
+
+// parseNumber reads numbers like 10, -2.5, $1.5m or 20%.
+func parseNumber(s string) (float64, error) {
+	t := strings.ToLower(strings.NewReplacer("$", "", ",", "", "_", "").Replace(s))
+	t = strings.TrimSuffix(t, "%")
+	mult := 1.0
+	if t != "" {
+		switch t[len(t)-1] {
+		case 'k':
+			mult = 1e3
+		case 'm':
+			mult = 1e6
+		case 'b':
+			mult = 1e9
+		}
+		if mult != 1 {
+			t = t[:len(t)-1]
+		}
+	}
+	n, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %q", s)
+	}
+	return n * mult, nil
+}
+
+func timeTerm[R any](field Field[R], op, value string) (pred[R], error) {
+	if op != ":" {
+		at, err := parseInstant(value)
+		if err != nil {
+			return nil, err
+		}
+		cmp := compare(op)
+		return func(r R, now time.Time) bool {
+			return cmp(float64(field.time(r)), float64(at.resolve(now).UnixMilli()))
+		}, nil
+	}
+
+	// A duration is a window ending now; anything else is a span of dates
+	if d, ok := parseAgo(value); ok {
+		return func(r R, now time.Time) bool { return field.time(r) >= now.Add(-d).UnixMilli() }, nil
+	}
+	from, to, isRange := strings.Cut(value, "..")
+	if !isRange {
+		to = from
+	}
+	var start, end int64 = math.MinInt64, math.MaxInt64
+	if from != "" {
+		t, _, err := parseDate(from)
+		if err != nil {
+			return nil, err
+		}
+		start = t.UnixMilli()
+	}
+	if to != "" {
+		t, span, err := parseDate(to)
+		if err != nil {
+			return nil, err
+		}
+		end = t.Add(span).UnixMilli()
+	}
+	return func(r R, _ time.Time) bool {
+		ts := field.time(r)
+		return ts >= start && ts < end
+	}, nil
+}
+
+// instant is a fixed time or one a duration before now.
+type instant struct {
+	at  time.Time
+	ago time.Duration
+}
+
+func (i instant) resolve(now time.Time) time.Time {
+	if i.at.IsZero() {
+		return now.Add(-i.ago)
+	}
+	return i.at
+}
+
+func parseInstant(s string) (instant, error) {
+	if d, ok := parseAgo(s); ok {
+		return instant{ago: d}, nil
+	}
+	t, _, err := parseDate(s)
+	return instant{at: t}, err
+}
+
+// parseAgo reads durations like 30m, 24h, 7d or 2w.
+func parseAgo(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// parseDate reads a local date, optionally with a time, and returns the
+// span it covers: a day, or a minute.
+func parseDate(s string) (time.Time, time.Duration, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, 24 * time.Hour, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04", s, time.Local); err == nil {
+		return t, time.Minute, nil
+	}
+	return time.Time{}, 0, fmt.Errorf("bad time %q; use 2006-01-02, 2006-01-02T15:04 or 24h/7d", s)
+}
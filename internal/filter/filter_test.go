@@ -0,0 +1,204 @@
+package filter
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type pos struct {
+	coin string
+	side string
+	pnl  float64
+	lev  float64
+	time time.Time
+}
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+var fields = []Field[pos]{
+	Text("coin", func(p pos) string { return p.coin }),
+	Text("side", func(p pos) string { return p.side }),
+	Number("pnl", func(p pos) float64 { return p.pnl }),
+	Number("lev", func(p pos) float64 { return p.lev }),
+	Time("time", func(p pos) int64 { return p.time.UnixMilli() }),
+}
+
+var rows = []pos{
+	{"BTC", "LONG", 2500, 10, now.Add(-2 * time.Hour)},
+	{"ETH", "SHORT", -120.5, 8, now.Add(-3 * 24 * time.Hour)},
+	{"SOL", "LONG", 0, 5, time.Date(2026, 10, 1, 23, 59, 0, 0, time.UTC)},
+	{"HYPE", "SHORT", -1_500_000, 20, time.Date(2026, 9, 30, 8, 0, 0, 0, time.UTC)},
+	{"FARTCOIN", "LONG", math.NaN(), 3, now},
+}
+
+func coins(ps []pos) string {
+	names := make([]string, len(ps))
+	for i, p := range ps {
+		names[i] = p.coin
+	}
+	return strings.Join(names, ",")
+}
+
+func match(f Filter[pos]) string {
+	var got []pos
+	for _, r := range rows {
+		if f.Match(r, now) {
+			got = append(got, r)
+		}
+	}
+	return coins(got)
+}
+
+func useUTC(t *testing.T) {
+	prev := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = prev })
+}
+
+func TestParse(t *testing.T) {
+	useUTC(t)
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "BTC,ETH,SOL,HYPE,FARTCOIN"},
+		{"  ", "BTC,ETH,SOL,HYPE,FARTCOIN"},
+		{"btc", "BTC"},
+		{"short", "ETH,HYPE"}, // bare words search every text field
+		{"coin:BTC,eth", "BTC,ETH"},
+		{"COIN=sol", "SOL"},
+		{"side:short", "ETH,HYPE"},
+		{"pnl<0", "ETH,HYPE"},
+		{"pnl>=0", "BTC,SOL"},
+		{"pnl<-1m", "HYPE"},
+		{"pnl>$2.5k", ""},
+		{"pnl>=$2,500", "BTC"},
+		{"lev>10", "HYPE"},
+		{"lev<=8", "ETH,SOL,FARTCOIN"},
+		{"lev:5,20", "SOL,HYPE"},
+		{"side:long lev>4", "BTC,SOL"},
+		{"!coin:BTC !side:short", "SOL,FARTCOIN"},
+		{"time:24h", "BTC,FARTCOIN"},
+		{"time>7d", "BTC,ETH,FARTCOIN"},
+		{"time<7d", "SOL,HYPE"},
+		{"time:2026-10-01", "SOL"},
+		{"time:2026-09-30..2026-10-01", "SOL,HYPE"},
+		{"time:2026-10-02..", "BTC,ETH,FARTCOIN"},
+		{"time:..2026-09-30", "HYPE"},
+		{"time<2026-10-01T00:00", "HYPE"},
+		{"time>=2026-10-19T10:00", "BTC,FARTCOIN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := Parse(tt.expr, fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := match(f); got != tt.want {
+				t.Errorf("matched %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"size>1", `unknown field "size"; try coin, side, pnl, lev, time`},
+		{"coin>B", "coin is text; use coin:value"},
+		{"pnl<abc", `bad number "abc"`},
+		{"lev:5,x", `bad number "x"`},
+		{"time>yesterday", `bad time "yesterday"`},
+		{"time:2026-13-01", `bad time "2026-13-01"`},
+		{":BTC", `missing field before ":"`},
+		{"pnl<", "missing value after pnl<"},
+		{"!", "nothing after !"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr, fields)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyUsesClock(t *testing.T) {
+	prev := util.Now
+	t.Cleanup(func() { util.Now = prev })
+
+	f, err := Parse("time:24h", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The window moves with the clock rather than staying where it was
+	// when the filter was typed
+	util.Now = func() time.Time { return now.Add(23 * time.Hour) }
+	if got := coins(f.Apply(append([]pos(nil), rows...))); got != "FARTCOIN" {
+		t.Errorf("applied = %q, want FARTCOIN", got)
+	}
+}
+
+func typeKeys(b Bar[pos], keys ...string) Bar[pos] {
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		}
+		b, _, _ = b.Update(msg)
+	}
+	return b
+}
+
+func TestBar(t *testing.T) {
+	useUTC(t)
+	b := NewBar(fields)
+	if _, _, used := b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}); used {
+		t.Error("closed bar used a key")
+	}
+
+	b = typeKeys(b, "/", "l", "e", "v", ">", "9")
+	if !b.Editing() || match(b.filter) != "BTC,HYPE" {
+		t.Fatalf("editing = %v, matched %q", b.Editing(), match(b.filter))
+	}
+
+	// An expression that doesn't parse keeps the last one that did
+	b = typeKeys(b, " ", "!")
+	if b.err == nil || match(b.filter) != "BTC,HYPE" {
+		t.Errorf("err = %v, matched %q", b.err, match(b.filter))
+	}
+	if view := b.View(2, 5); !strings.Contains(view, "nothing after !") {
+		t.Errorf("view = %q, want the error", view)
+	}
+
+	b = typeKeys(b, "backspace", "backspace", "enter")
+	if b.Editing() || !b.Shown() || b.filter.String() != "lev>9" {
+		t.Errorf("after enter: editing = %v, shown = %v, filter = %q", b.Editing(), b.Shown(), b.filter)
+	}
+	if view := b.View(2, 5); !strings.Contains(view, "/lev>9") || !strings.Contains(view, "2 of 5") {
+		t.Errorf("view = %q", view)
+	}
+
+	// Reopening edits the applied expression; esc clears it
+	b = typeKeys(b, "/")
+	if b.input.Value() != "lev>9" {
+		t.Errorf("reopened with %q", b.input.Value())
+	}
+	b = typeKeys(b, "esc")
+	if b.Shown() || b.View(5, 5) != "" {
+		t.Error("bar still shown after esc")
+	}
+}
@@ -0,0 +1,212 @@
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+// Player replays a recording on a clock running Speed times faster than
+// real time. WebSocket entries are delivered through Run; REST responses
+// are served by Transport from whatever was recorded at the replay clock.
+type Player struct {
+	Meta    Meta
+	Entries []Entry
+	Speed   float64
+
+	mu      sync.Mutex
+	started time.Time
+	rest    map[string][]Entry // by request key, in time order
+}
+
+// NewPlayer prepares entries for replay.
+func NewPlayer(meta Meta, entries []Entry, speed float64) *Player {
+	if speed <= 0 {
+		speed = 1
+	}
+	p := &Player{Meta: meta, Entries: entries, Speed: speed, rest: make(map[string][]Entry)}
+	for _, e := range entries {
+		if e.Kind == KindREST {
+			k := requestKey(e.Request)
+			p.rest[k] = append(p.rest[k], e)
+		}
+	}
+	return p
+}
+
+// Open loads a recording file for replay.
+func Open(path string, speed float64) (*Player, error) {
+	meta, entries, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlayer(meta, entries, speed), nil
+}
+
+// Start is the recorded time of the first entry.
+func (p *Player) Start() int64 {
+	if len(p.Entries) == 0 {
+		return 0
+	}
+	return p.Entries[0].Time
+}
+
+// End is the recorded time of the last entry.
+func (p *Player) End() int64 {
+	if len(p.Entries) == 0 {
+		return 0
+	}
+	return p.Entries[len(p.Entries)-1].Time
+}
+
+// Now is the replay clock in recorded unix ms. It stays at Start until Run
+// is called.
+func (p *Player) Now() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started.IsZero() {
+		return p.Start()
+	}
+	return p.Start() + int64(float64(time.Since(p.started).Milliseconds())*p.Speed)
+}
+
+// Run plays entries in order, sleeping between them scaled by Speed.
+// onWS gets every WebSocket message; onREST gets each REST entry as the
+// clock passes it, so the caller can refetch. Run returns when the
+// recording ends or done is closed.
+func (p *Player) Run(done <-chan struct{}, onWS func(ws.Message), onREST func(Entry)) {
+	p.mu.Lock()
+	p.started = time.Now()
+	p.mu.Unlock()
+
+	for _, e := range p.Entries {
+		if wait := time.Duration(float64(e.Time-p.Now())/p.Speed) * time.Millisecond; wait > 0 {
+			select {
+			case <-done:
+				return
+			case <-time.After(wait):
+			}
+		}
+		switch e.Kind {
+		case KindWS:
+			var msg ws.Message
+			if err := json.Unmarshal(e.Data, &msg); err == nil {
+				onWS(msg)
+			}
+		case KindREST:
+			if onREST != nil {
+				onREST(e)
+			}
+		}
+	}
+}
+
+// RESTType returns the info request type ("clearinghouseState", ...) of a
+// REST entry.
+func (e Entry) RESTType() string {
+	var req struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(e.Request, &req)
+	return req.Type
+}
+
+// Transport serves info requests from the recording: the latest response to
+// the same request at or before the replay clock, or the first one recorded
+// if the clock hasn't reached it yet.
+func (p *Player) Transport() http.RoundTripper {
+	return roundTripper{p}
+}
+
+type roundTripper struct {
+	p *Player
+}
+
+func (rt roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	e, ok := rt.p.lookup(body)
+	if !ok {
+		return response(req, http.StatusNotFound, []byte("not in recording")), nil
+	}
+	data := []byte(e.Data)
+	var text string
+	if json.Unmarshal(e.Data, &text) == nil {
+		data = []byte(text)
+	}
+	return response(req, e.Status, data), nil
+}
+
+func (p *Player) lookup(req []byte) (Entry, bool) {
+	candidates := p.rest[requestKey(req)]
+	if len(candidates) == 0 {
+		return Entry{}, false
+	}
+	now := p.Now()
+	i := sort.Search(len(candidates), func(i int) bool { return candidates[i].Time > now })
+	if i == 0 {
+		return candidates[0], true
+	}
+	return candidates[i-1], true
+}
+
+func response(req *http.Request, status int, body []byte) *http.Response {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// requestKey normalizes a request body for matching. Time bounds are
+// dropped since they are derived from the wall clock at request time.
+func requestKey(req []byte) string {
+	var m map[string]any
+	if err := json.Unmarshal(req, &m); err != nil {
+		return string(req)
+	}
+	delete(m, "startTime")
+	delete(m, "endTime")
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			m[k] = strings.ToLower(s)
+		}
+	}
+	data, _ := json.Marshal(m) // map keys marshal sorted
+	return string(data)
+}
+
+func compact(data []byte) []byte {
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		return data
+	}
+	return b.Bytes()
+}
+
+// ParseSpeed parses a replay speed like "10x", "0.5x" or "4".
+func ParseSpeed(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "x"), 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid speed %q: want a positive multiplier like 10x", s)
+	}
+	return v, nil
+}
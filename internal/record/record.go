@@ -0,0 +1,166 @@
+// Package record writes and reads session recordings: every raw WebSocket
+// message and REST response, timestamped, in a gzip-compressed JSON-lines
+// file that the replay command plays back.
+package record
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+// Entry kinds
+const (
+	KindMeta = "meta"
+	KindWS   = "ws"
+	KindREST = "rest"
+)
+
+// Entry is one line of a recording.
+type Entry struct {
+	Time    int64           `json:"t"` // unix ms
+	Kind    string          `json:"k"`
+	Request json.RawMessage `json:"req,omitempty"`    // REST request body
+	Status  int             `json:"status,omitempty"` // REST HTTP status
+	Data    json.RawMessage `json:"data"`             // ws.Message, response body or Meta
+}
+
+// Meta describes the session a recording was made from. It is always the
+// first entry.
+type Meta struct {
+	Version    string `json:"version"`
+	Address    string `json:"address"`
+	WalletName string `json:"walletName,omitempty"`
+	Testnet    bool   `json:"testnet,omitempty"`
+	Vault      bool   `json:"vault,omitempty"`
+}
+
+// Writer appends entries to a recording. It is safe for concurrent use by
+// the REST and WebSocket goroutines.
+type Writer struct {
+	mu     sync.Mutex
+	f      *os.File
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	err    error
+	closed bool
+	now    func() time.Time
+}
+
+// Create starts a new recording at path, truncating any existing file.
+func Create(path string, meta Meta) (*Writer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(f)
+	w := &Writer{f: f, gz: gz, enc: json.NewEncoder(gz), now: time.Now}
+	data, _ := json.Marshal(meta)
+	w.write(Entry{Kind: KindMeta, Data: data})
+	if w.err != nil {
+		f.Close()
+		return nil, w.err
+	}
+	return w, nil
+}
+
+// WS records a raw WebSocket message.
+func (w *Writer) WS(msg ws.Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	w.write(Entry{Kind: KindWS, Data: data})
+}
+
+// REST records an info request and the response body it got.
+func (w *Writer) REST(req []byte, status int, body []byte) {
+	e := Entry{Kind: KindREST, Request: compact(req), Status: status}
+	if json.Valid(body) {
+		e.Data = body
+	} else {
+		// Error bodies are plain text
+		e.Data, _ = json.Marshal(string(body))
+	}
+	w.write(e)
+}
+
+func (w *Writer) write(e Entry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil || w.closed {
+		return
+	}
+	e.Time = w.now().UnixMilli()
+	w.err = w.enc.Encode(e)
+}
+
+// Close flushes and closes the file, returning the first write error.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	if err := w.gz.Close(); err != nil && w.err == nil {
+		w.err = err
+	}
+	if err := w.f.Close(); err != nil && w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// ReadFile loads a recording.
+func ReadFile(path string) (Meta, []Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Meta{}, nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read decodes a recording from r.
+func Read(r io.Reader) (Meta, []Entry, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return Meta{}, nil, fmt.Errorf("not a recording: %w", err)
+	}
+	defer gz.Close()
+
+	var meta Meta
+	var entries []Entry
+	dec := json.NewDecoder(gz)
+	for {
+		var e Entry
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			// A session killed mid-write still replays up to the last full line
+			break
+		}
+		if err != nil {
+			return meta, entries, fmt.Errorf("decode entry %d: %w", len(entries)+1, err)
+		}
+		if e.Kind == KindMeta {
+			if err := json.Unmarshal(e.Data, &meta); err != nil {
+				return meta, nil, fmt.Errorf("decode meta: %w", err)
+			}
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if meta.Address == "" {
+		return meta, nil, errors.New("recording has no session header")
+	}
+	return meta, entries, nil
+}
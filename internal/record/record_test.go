@@ -0,0 +1,161 @@
+package record
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+func writeRecording(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "session.rec")
+	w, err := Create(path, Meta{Version: "test", Address: "0xabc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := time.UnixMilli(1_000_000)
+	w.now = func() time.Time { return clock }
+
+	w.REST([]byte(`{"type": "allMids"}`), 200, []byte(`{"BTC":"100"}`))
+	clock = clock.Add(time.Second)
+	w.WS(ws.Message{Channel: "allMids", Data: json.RawMessage(`{"mids":{"BTC":"101"}}`)})
+	clock = clock.Add(time.Second)
+	w.REST([]byte(`{"type":"allMids"}`), 200, []byte(`{"BTC":"102"}`))
+	w.REST([]byte(`{"type":"userFunding","user":"0xABC","startTime":5}`), 429, []byte("rate limited"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Writes after Close are dropped rather than corrupting the file
+	w.WS(ws.Message{Channel: "allMids"})
+	return path
+}
+
+func TestWriteRead(t *testing.T) {
+	meta, entries, err := ReadFile(writeRecording(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Address != "0xabc" || meta.Version != "test" {
+		t.Errorf("meta = %+v", meta)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("entries = %d, want 4", len(entries))
+	}
+	if entries[1].Kind != KindWS || entries[1].Time != 1_001_000 {
+		t.Errorf("ws entry = %+v", entries[1])
+	}
+	if entries[0].RESTType() != "allMids" || string(entries[0].Request) != `{"type":"allMids"}` {
+		t.Errorf("rest entry request = %s", entries[0].Request)
+	}
+}
+
+func TestReadTruncated(t *testing.T) {
+	path := writeRecording(t)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cut := filepath.Join(t.TempDir(), "cut.rec")
+	os.WriteFile(cut, data[:len(data)-12], 0o644)
+	if _, entries, err := ReadFile(cut); err != nil || len(entries) == 0 {
+		t.Errorf("truncated recording: %d entries, err %v", len(entries), err)
+	}
+}
+
+func TestReadRejectsOtherFiles(t *testing.T) {
+	if _, _, err := Read(strings.NewReader("hello")); err == nil {
+		t.Error("expected an error for a non-gzip file")
+	}
+}
+
+func get(t *testing.T, p *Player, body string) (int, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, "http://replay/info", strings.NewReader(body))
+	resp, err := p.Transport().RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestTransportFollowsClock(t *testing.T) {
+	meta, entries, err := ReadFile(writeRecording(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPlayer(meta, entries, 1)
+
+	// Before Run the clock sits at the first entry
+	if _, body := get(t, p, `{"type":"allMids"}`); body != `{"BTC":"100"}` {
+		t.Errorf("at start = %s", body)
+	}
+
+	// Jump the clock past the second response
+	p.started = time.Now().Add(-5 * time.Second)
+	if _, body := get(t, p, `{"type":"allMids"}`); body != `{"BTC":"102"}` {
+		t.Errorf("later = %s", body)
+	}
+
+	// startTime is ignored and address case doesn't matter; status and
+	// plain-text bodies replay as recorded
+	status, body := get(t, p, `{"type":"userFunding","user":"0xabc","startTime":99}`)
+	if status != 429 || body != "rate limited" {
+		t.Errorf("userFunding = %d %q", status, body)
+	}
+
+	if status, _ := get(t, p, `{"type":"portfolio","user":"0xabc"}`); status != http.StatusNotFound {
+		t.Errorf("unrecorded request status = %d, want 404", status)
+	}
+}
+
+func TestRunDeliversInOrder(t *testing.T) {
+	meta, entries, err := ReadFile(writeRecording(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPlayer(meta, entries, 1000)
+
+	var got []string
+	start := time.Now()
+	p.Run(nil, func(msg ws.Message) {
+		got = append(got, "ws:"+msg.Channel)
+	}, func(e Entry) {
+		got = append(got, "rest:"+e.RESTType())
+	})
+	if time.Since(start) > time.Second {
+		t.Errorf("2s of recording took %v at 1000x", time.Since(start))
+	}
+	want := "rest:allMids ws:allMids rest:allMids rest:userFunding"
+	if strings.Join(got, " ") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+}
+
+func TestParseSpeed(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"10x", 10, true},
+		{"0.5X", 0.5, true},
+		{"4", 4, true},
+		{"0x", 0, false},
+		{"-2x", 0, false},
+		{"fast", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseSpeed(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseSpeed(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"math"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+// predictedHorizon is how far ahead the venue's predicted rate is trusted;
+// projections beyond it fall back to the trailing average.
+const predictedHorizon = 8 * time.Hour
+
+// carryTrailingWindow is the lookback for the trailing average funding rate.
+const carryTrailingWindow = 7 * 24 * time.Hour
+
+// PositionCarry is the funding outlook for one open position. Rates are
+// hourly; projections are signed USD from the holder's point of view
+// (negative = funding paid).
+type PositionCarry struct {
+	Coin          string
+	Szi           decimal.Decimal
+	MarkPx        decimal.Decimal
+	Notional      decimal.Decimal
+	PredictedRate float64
+	TrailingRate  float64
+	HasTrailing   bool
+
+	Proj8h  decimal.Decimal
+	Proj24h decimal.Decimal
+	Proj7d  decimal.Decimal
+
+	// FundingPaid is CumFunding.SinceOpen: positive when the position has
+	// paid funding since it was opened.
+	FundingPaid   decimal.Decimal
+	UnrealizedPnl decimal.Decimal
+}
+
+// PaidShareOfPnl is funding paid as a percentage of the absolute uPnL.
+// ok is false when uPnL is zero.
+func (c PositionCarry) PaidShareOfPnl() (pct float64, ok bool) {
+	if c.UnrealizedPnl.IsZero() {
+		return 0, false
+	}
+	return c.FundingPaid.Float64() / c.UnrealizedPnl.Abs().Float64() * 100, true
+}
+
+// ProjectFunding returns the funding a position of szi at markPx receives
+// over hours (negative = paid). The first predictedHorizon uses the predicted
+// hourly rate and the remainder the trailing hourly rate.
+func ProjectFunding(szi, markPx decimal.Decimal, predicted, trailing, hours float64) decimal.Decimal {
+	predHours := math.Min(hours, predictedHorizon.Hours())
+	trailHours := math.Max(hours-predictedHorizon.Hours(), 0)
+	rate := decimal.NewFromFloat(predicted*predHours + trailing*trailHours)
+	// Positive funding: longs pay shorts
+	return szi.Mul(markPx).Mul(rate).Neg().Round(6)
+}
+
+// PositionCarry computes the funding outlook for every open position using
+// the predicted Hyperliquid rate (falling back to the current rate) and the
+// 7-day trailing average (falling back to the predicted rate).
+func (s *Store) PositionCarry(now time.Time) []PositionCarry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.clearinghouseState == nil {
+		return nil
+	}
+
+	predicted := make(map[string]float64)
+	for _, pf := range s.predictedFundings {
+		if v, ok := pf.Venue(api.VenueHyperliquid); ok {
+			predicted[pf.Coin] = v.FundingRate.Float64() / float64(v.IntervalHours())
+		}
+	}
+
+	var carries []PositionCarry
+	for _, ap := range s.clearinghouseState.AssetPositions {
+		p := ap.Position
+		c := PositionCarry{
+			Coin:          p.Coin,
+			Szi:           p.Szi,
+			UnrealizedPnl: p.UnrealizedPnl,
+		}
+		if a, ok := s.market.Asset(p.Coin); ok {
+			c.MarkPx = a.Ctx.MarkPx
+		}
+		if c.MarkPx.IsZero() {
+			c.MarkPx = s.allMids[p.Coin]
+		}
+		if p.CumFunding != nil {
+			c.FundingPaid = p.CumFunding.SinceOpen
+		}
+		if rate, ok := predicted[p.Coin]; ok {
+			c.PredictedRate = rate
+		} else {
+			c.PredictedRate = s.fundingRates[p.Coin]
+		}
+		c.TrailingRate = c.PredictedRate
+		if avg, ok := s.fundingHistoryAverage(p.Coin, carryTrailingWindow, now); ok {
+			c.TrailingRate = avg
+			c.HasTrailing = true
+		}
+		c.Notional = c.Szi.Abs().Mul(c.MarkPx)
+		c.Proj8h = ProjectFunding(c.Szi, c.MarkPx, c.PredictedRate, c.TrailingRate, 8)
+		c.Proj24h = ProjectFunding(c.Szi, c.MarkPx, c.PredictedRate, c.TrailingRate, 24)
+		c.Proj7d = ProjectFunding(c.Szi, c.MarkPx, c.PredictedRate, c.TrailingRate, 7*24)
+		carries = append(carries, c)
+	}
+	return carries
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+func TestProjectFunding(t *testing.T) {
+	tests := []struct {
+		name      string
+		szi, mark string
+		pred, trl float64
+		hours     float64
+		want      string
+	}{
+		// Long 1 BTC at $100k, +0.001%/h: pays $1/h
+		{"long pays positive funding", "1", "100000", 0.00001, 0.00001, 8, "-8"},
+		{"short receives positive funding", "-1", "100000", 0.00001, 0.00001, 8, "8"},
+		// 8h at predicted ($1/h) + 16h at trailing ($2/h)
+		{"blends predicted then trailing", "1", "100000", 0.00001, 0.00002, 24, "-40"},
+		{"short horizon uses only predicted", "1", "100000", 0.00001, 0.00005, 1, "-1"},
+	}
+	for _, tt := range tests {
+		got := ProjectFunding(decimal.MustParse(tt.szi), decimal.MustParse(tt.mark), tt.pred, tt.trl, tt.hours)
+		if !eq(got, tt.want) {
+			t.Errorf("%s: ProjectFunding = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPositionCarry(t *testing.T) {
+	s := New()
+	now := time.UnixMilli(1_770_000_000_000)
+	s.clearinghouseState = &api.ClearinghouseState{
+		AssetPositions: []api.AssetPosition{
+			{Position: api.Position{Coin: "BTC", Szi: decimal.MustParse("-2"), UnrealizedPnl: decimal.MustParse("500"), CumFunding: &api.CumFunding{SinceOpen: decimal.MustParse("-50")}}},
+			{Position: api.Position{Coin: "ETH", Szi: decimal.MustParse("10"), UnrealizedPnl: decimal.MustParse("-200")}},
+		},
+	}
+	s.SetMetaAndAssetCtxs(&api.MetaAndAssetCtxs{
+		Meta:      api.Meta{Universe: []api.AssetMeta{{Name: "BTC"}, {Name: "ETH"}}},
+		AssetCtxs: []api.AssetCtx{{MarkPx: decimal.MustParse("100000")}, {MarkPx: decimal.Zero}},
+	})
+	s.UpdateMids(api.AllMids{"ETH": decimal.MustParse("3000")})
+	s.fundingRates["ETH"] = 0.00002
+	s.predictedFundings = api.PredictedFundings{
+		{Coin: "BTC", Venues: []api.VenueFunding{{Venue: api.VenueHyperliquid, FundingRate: decimal.MustParse("0.00001")}}},
+	}
+	s.fundingHistory["BTC"] = []api.FundingHistoryEntry{
+		{FundingRate: decimal.MustParse("0.00003"), Time: now.Add(-time.Hour).UnixMilli()},
+	}
+
+	carries := s.PositionCarry(now)
+	if len(carries) != 2 {
+		t.Fatalf("len = %d, want 2", len(carries))
+	}
+
+	btc := carries[0]
+	if !eq(btc.Notional, "200000") {
+		t.Errorf("BTC notional = %v, want 200000", btc.Notional)
+	}
+	if !btc.HasTrailing || btc.TrailingRate != 0.00003 {
+		t.Errorf("BTC trailing = %v (%v), want 0.00003", btc.TrailingRate, btc.HasTrailing)
+	}
+	// Short 2 BTC: 8h * $2/h predicted + 16h * $6/h trailing
+	if !eq(btc.Proj24h, "112") {
+		t.Errorf("BTC Proj24h = %v, want 112", btc.Proj24h)
+	}
+	if pct, ok := btc.PaidShareOfPnl(); !ok || pct != -10 {
+		t.Errorf("BTC PaidShareOfPnl = %v, %v; want -10, true", pct, ok)
+	}
+
+	// ETH: mark falls back to mid, predicted falls back to current rate
+	eth := carries[1]
+	if !eq(eth.MarkPx, "3000") || eth.PredictedRate != 0.00002 || eth.HasTrailing {
+		t.Errorf("ETH carry = %+v", eth)
+	}
+	if !eq(eth.Proj8h, "-4.8") {
+		t.Errorf("ETH Proj8h = %v", eth.Proj8h)
+	}
+}
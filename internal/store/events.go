@@ -0,0 +1,99 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+)
+
+// Event is a change to the store's data. Subscribers receive it after the
+// store has applied it and released its lock, so they may read the store.
+type Event interface {
+	storeEvent()
+}
+
+// MidsUpdated is sent when mid prices arrive. The market snapshot and live
+// positions have already been revalued at them.
+type MidsUpdated struct {
+	Mids api.AllMids // the mids that arrived; not the full set after a tick
+}
+
+// ContextsUpdated is sent when a snapshot replaces the asset contexts. The
+// market snapshot and the context histories already hold them.
+type ContextsUpdated struct{}
+
+// PositionsChanged is sent when a clearinghouse snapshot replaces the
+// positions and margin summary.
+type PositionsChanged struct{}
+
+// FillAdded is sent once for each fill the store hadn't held before.
+type FillAdded struct {
+	Fill api.Fill
+}
+
+// OrderChanged is sent when an open order appears, is modified or leaves
+// the book.
+type OrderChanged struct {
+	Order api.OpenOrder
+	Open  bool // false once it has filled or been canceled
+}
+
+// FundingAdded is sent once for each funding payment the store hadn't held
+// before.
+type FundingAdded struct {
+	Payment api.FundingPayment
+}
+
+func (MidsUpdated) storeEvent()      {}
+func (ContextsUpdated) storeEvent()  {}
+func (PositionsChanged) storeEvent() {}
+func (FillAdded) storeEvent()        {}
+func (OrderChanged) storeEvent()     {}
+func (FundingAdded) storeEvent()     {}
+
+type subscriber struct {
+	id int
+	fn func(Event)
+}
+
+// bus delivers events to subscribers in the order they subscribed.
+type bus struct {
+	mu     sync.Mutex
+	subs   []subscriber
+	nextID int
+}
+
+// Subscribe calls fn with every event from now on, on the goroutine that
+// changed the store, until the returned func is called. fn must not block.
+func (s *Store) Subscribe(fn func(Event)) (unsubscribe func()) {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	id := s.bus.nextID
+	s.bus.nextID++
+	s.bus.subs = append(s.bus.subs, subscriber{id: id, fn: fn})
+	return func() {
+		s.bus.mu.Lock()
+		defer s.bus.mu.Unlock()
+		for i, sub := range s.bus.subs {
+			if sub.id == id {
+				s.bus.subs = append(s.bus.subs[:i:i], s.bus.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publish delivers events. Callers must not hold s.mu.
+func (s *Store) publish(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+	s.bus.mu.Lock()
+	subs := s.bus.subs
+	s.bus.mu.Unlock()
+	for _, e := range events {
+		for _, sub := range subs {
+			sub.fn(e)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+// record subscribes to s and returns the events received so far.
+func record(t *testing.T, s *Store) *[]Event {
+	t.Helper()
+	var events []Event
+	t.Cleanup(s.Subscribe(func(e Event) { events = append(events, e) }))
+	return &events
+}
+
+func TestSubscribe(t *testing.T) {
+	s := New()
+	var a, b int
+	unsubA := s.Subscribe(func(Event) { a++ })
+	s.Subscribe(func(Event) { b++ })
+
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("90000")})
+	unsubA()
+	unsubA() // a second call is harmless
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("90001")})
+	if a != 1 || b != 2 {
+		t.Errorf("deliveries a=%d b=%d, want 1 and 2", a, b)
+	}
+
+	// Nothing is published when nothing changed
+	s.MergeFills(nil)
+	if b != 2 {
+		t.Errorf("empty merge published %d events", b-2)
+	}
+}
+
+func TestSubscriberCanReadStore(t *testing.T) {
+	s := New()
+	var mid decimal.Decimal
+	s.Subscribe(func(e Event) {
+		if _, ok := e.(MidsUpdated); ok {
+			mid = s.MidPrice("BTC") // deadlocks if published under the lock
+		}
+	})
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("90000")})
+	if !eq(mid, "90000") {
+		t.Errorf("mid seen by subscriber = %v", mid)
+	}
+}
+
+func TestMidsAndPositionsEvents(t *testing.T) {
+	s := New()
+	events := record(t, s)
+	mids := api.AllMids{"ETH": decimal.MustParse("3100")}
+	s.UpdateMids(mids)
+	mids["ETH"] = decimal.MustParse("1") // the event holds its own copy
+	s.SetClearinghouseState(revalueState())
+
+	if len(*events) != 2 {
+		t.Fatalf("events = %+v", *events)
+	}
+	if e, ok := (*events)[0].(MidsUpdated); !ok || !eq(e.Mids["ETH"], "3100") {
+		t.Errorf("first event = %+v, want MidsUpdated ETH 3100", (*events)[0])
+	}
+	if _, ok := (*events)[1].(PositionsChanged); !ok {
+		t.Errorf("second event = %+v, want PositionsChanged", (*events)[1])
+	}
+}
+
+func TestContextsEvents(t *testing.T) {
+	s := New()
+	events := record(t, s)
+	meta := marketMeta()
+	s.SetMetaAndAssetCtxs(meta)
+	s.ApplyWebData2(ws.WebData2{Meta: &meta.Meta, AssetCtxs: meta.AssetCtxs})
+	s.ApplyWebData2(ws.WebData2{}) // no contexts, no event
+
+	if len(*events) != 2 {
+		t.Fatalf("events = %+v, want two ContextsUpdated", *events)
+	}
+	for _, e := range *events {
+		if _, ok := e.(ContextsUpdated); !ok {
+			t.Errorf("event = %+v, want ContextsUpdated", e)
+		}
+	}
+}
+
+func TestFillAndFundingEvents(t *testing.T) {
+	s := New()
+	s.SetFills([]api.Fill{{Coin: "BTC", Time: 100, Tid: 1}})
+	s.SetFundingPayments([]api.FundingPayment{{Time: 1, Coin: "BTC"}})
+	events := record(t, s)
+
+	s.MergeFills([]api.Fill{{Coin: "BTC", Time: 100, Tid: 1}, {Coin: "ETH", Time: 200, Tid: 2}})
+	s.SetFills([]api.Fill{{Coin: "ETH", Time: 200, Tid: 2}, {Coin: "BTC", Time: 100, Tid: 1}})
+	s.MergeFundingPayments([]api.FundingPayment{{Time: 1, Coin: "BTC"}, {Time: 2, Coin: "BTC"}})
+
+	if len(*events) != 2 {
+		t.Fatalf("events = %+v, want one fill and one payment", *events)
+	}
+	if e, ok := (*events)[0].(FillAdded); !ok || e.Fill.Tid != 2 {
+		t.Errorf("first event = %+v, want FillAdded tid 2", (*events)[0])
+	}
+	if e, ok := (*events)[1].(FundingAdded); !ok || e.Payment.Time != 2 {
+		t.Errorf("second event = %+v, want FundingAdded at 2", (*events)[1])
+	}
+}
+
+func TestOrderEvents(t *testing.T) {
+	s := New()
+	s.SetOpenOrders([]api.OpenOrder{{Coin: "BTC", Oid: 1}, {Coin: "ETH", Oid: 2}})
+	events := record(t, s)
+
+	s.ApplyOrderUpdates([]ws.OrderUpdate{
+		{Order: ws.OrderInfo{Coin: "SOL", Oid: 3}, Status: "open"},
+		{Order: ws.OrderInfo{Coin: "BTC", Oid: 1}, Status: "filled"},
+		{Order: ws.OrderInfo{Coin: "DOGE", Oid: 9}, Status: "canceled"}, // never held
+	})
+	// A snapshot that drops ETH and repeats SOL
+	s.ReconcileOpenOrders([]api.OpenOrder{{Coin: "SOL", Oid: 3}})
+
+	want := []OrderChanged{
+		{Order: api.OpenOrder{Coin: "SOL", Oid: 3}, Open: true},
+		{Order: api.OpenOrder{Coin: "BTC", Oid: 1}},
+		{Order: api.OpenOrder{Coin: "ETH", Oid: 2}},
+	}
+	if len(*events) != len(want) {
+		t.Fatalf("events = %+v", *events)
+	}
+	for i, w := range want {
+		e, ok := (*events)[i].(OrderChanged)
+		if !ok || e.Order.Oid != w.Order.Oid || e.Order.Coin != w.Order.Coin || e.Open != w.Open {
+			t.Errorf("event %d = %+v, want %+v", i, (*events)[i], w)
+		}
+	}
+}
+
+func TestAccessorsReturnCopies(t *testing.T) {
+	s := New()
+	s.SetOpenOrders([]api.OpenOrder{{Coin: "BTC", Oid: 1}})
+	s.SetClearinghouseState(revalueState())
+
+	orders := s.OpenOrders()
+	orders[0].Coin = "ETH"
+	st := s.ClearinghouseState()
+	st.AssetPositions[0].Position.Coin = "DOGE"
+	details := s.VaultDetails()
+	details["0xabc"] = &api.VaultDetails{}
+	s.SetL2Book(&api.L2Book{Coin: "BTC", Levels: [2][]api.L2Level{{{N: 1}}, {{N: 2}}}})
+	s.L2Book("BTC").Levels[0][0].N = 9
+
+	if s.OpenOrders()[0].Coin != "BTC" {
+		t.Error("OpenOrders shares its slice")
+	}
+	if s.ClearinghouseState().AssetPositions[0].Position.Coin == "DOGE" {
+		t.Error("ClearinghouseState shares its positions")
+	}
+	if len(s.VaultDetails()) != 0 {
+		t.Error("VaultDetails shares its map")
+	}
+	if s.L2Book("BTC").Levels[0][0].N != 1 {
+		t.Error("L2Book shares its levels")
+	}
+}
@@ -0,0 +1,216 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+const (
+	// historySpan is how far back the mid and asset context histories
+	// reach, and sampleEvery the least time between two samples of a coin.
+	// Mids tick about once a second; keeping every tick for hundreds of
+	// coins would cost far more than the change windows need.
+	historySpan = 6 * time.Hour
+	sampleEvery = 15 * time.Second
+)
+
+// Sample is one value of a coin's history.
+type Sample struct {
+	Time  int64   `json:"t"` // unix ms
+	Value float64 `json:"v"`
+}
+
+// series is a coin's samples, oldest first.
+type series []Sample
+
+// Metric is an asset context value the store keeps a history of.
+type Metric int
+
+const (
+	MetricOpenInterest Metric = iota // in coins
+	MetricVolume                     // 24h notional volume, USD
+	MetricFunding                    // hourly rate
+	MetricBasis                      // mark less oracle, % of oracle
+
+	numMetrics
+)
+
+func newCtxHistory() [numMetrics]map[string]series {
+	var h [numMetrics]map[string]series
+	for i := range h {
+		h[i] = make(map[string]series)
+	}
+	return h
+}
+
+// add appends v at t unless the last sample is under sampleEvery old, in
+// which case it replaces that sample's value, and drops samples that have
+// aged out of historySpan.
+func (s series) add(t int64, v float64) series {
+	if n := len(s); n > 0 {
+		last := s[n-1]
+		if t < last.Time {
+			return s
+		}
+		if t-last.Time < sampleEvery.Milliseconds() {
+			s[n-1].Value = v
+			return s
+		}
+	}
+	s = append(s, Sample{Time: t, Value: v})
+	cutoff := t - historySpan.Milliseconds()
+	if i := sort.Search(len(s), func(i int) bool { return s[i].Time >= cutoff }); i > 0 {
+		s = append(s[:0:0], s[i:]...)
+	}
+	return s
+}
+
+// at returns the last sample at or before t.
+func (s series) at(t int64) (Sample, bool) {
+	i := sort.Search(len(s), func(i int) bool { return s[i].Time > t })
+	if i == 0 {
+		return Sample{}, false
+	}
+	return s[i-1], true
+}
+
+// change is the % change from the sample window before the latest to the
+// latest. ok is false until the history reaches back that far.
+func (s series) change(window time.Duration) (pct float64, ok bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+	last := s[len(s)-1]
+	then, ok := s.at(last.Time - window.Milliseconds())
+	if !ok || then.Value == 0 {
+		return 0, false
+	}
+	return (last.Value - then.Value) / then.Value * 100, true
+}
+
+// recordMids adds a sample of each mid of a market in the universe. Spot
+// pairs (@N) are skipped, as are mids that arrive before the universe has
+// loaded. Callers hold the lock.
+func (s *Store) recordMids(mids api.AllMids) {
+	if s.market == nil {
+		return
+	}
+	t := util.Now().UnixMilli()
+	for coin, mid := range mids {
+		if _, ok := s.market.index[coin]; !ok {
+			continue
+		}
+		if v := mid.Float64(); v > 0 {
+			s.midHistory[coin] = s.midHistory[coin].add(t, v)
+		}
+	}
+}
+
+// recordAssetCtxs adds a sample of each asset's context metrics. Basis is
+// skipped for assets without an oracle price. Callers hold the lock.
+func (s *Store) recordAssetCtxs(m *Market) {
+	if m == nil {
+		return
+	}
+	t := util.Now().UnixMilli()
+	record := func(metric Metric, coin string, v float64) {
+		s.ctxHistory[metric][coin] = s.ctxHistory[metric][coin].add(t, v)
+	}
+	for _, a := range m.Assets {
+		record(MetricOpenInterest, a.Name, a.OpenInterest)
+		record(MetricVolume, a.Name, a.Volume24h)
+		record(MetricFunding, a.Name, a.Funding)
+		if a.OraclePx > 0 {
+			record(MetricBasis, a.Name, (a.Ctx.MarkPx.Float64()-a.OraclePx)/a.OraclePx*100)
+		}
+	}
+}
+
+// MidChange returns coin's % mid change over the window ending at its
+// latest mid. ok is false until the history covers the window.
+func (s *Store) MidChange(coin string, window time.Duration) (pct float64, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.midHistory[coin].change(window)
+}
+
+// OpenInterestChange returns coin's % open interest change, in coins, over
+// the window ending at its latest asset context. ok is false until the
+// history covers the window.
+func (s *Store) OpenInterestChange(coin string, window time.Duration) (pct float64, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ctxHistory[MetricOpenInterest][coin].change(window)
+}
+
+// ContextSeries returns coin's samples of metric over the window ending at
+// its latest asset context, oldest first. The first sample is the last one
+// at or before the window's start, so the series covers the window when
+// that sample is old enough; ok is false until then.
+func (s *Store) ContextSeries(coin string, metric Metric, window time.Duration) (samples []Sample, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h := s.ctxHistory[metric][coin]
+	if len(h) == 0 {
+		return nil, false
+	}
+	since := h[len(h)-1].Time - window.Milliseconds()
+	i := sort.Search(len(h), func(i int) bool { return h[i].Time > since })
+	if i == 0 {
+		return append([]Sample(nil), h...), false
+	}
+	return append([]Sample(nil), h[i-1:]...), true
+}
+
+// MidSeries returns coin's sampled mids over the window ending at its
+// latest mid, oldest first.
+func (s *Store) MidSeries(coin string, window time.Duration) []Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h := s.midHistory[coin]
+	if len(h) == 0 {
+		return nil
+	}
+	since := h[len(h)-1].Time - window.Milliseconds()
+	i := sort.Search(len(h), func(i int) bool { return h[i].Time >= since })
+	return append([]Sample(nil), h[i:]...)
+}
+
+// MidHistory returns a copy of every coin's sampled mids, for saving.
+func (s *Store) MidHistory() map[string][]Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string][]Sample, len(s.midHistory))
+	for coin, h := range s.midHistory {
+		out[coin] = append([]Sample(nil), h...)
+	}
+	return out
+}
+
+// RestoreMidHistory puts back mids saved by an earlier run. Samples older
+// than the history's span are dropped, as are any at or after the first
+// mid this run has already sampled.
+func (s *Store) RestoreMidHistory(saved map[string][]Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := util.Now().Add(-historySpan).UnixMilli()
+	for coin, samples := range saved {
+		cur := s.midHistory[coin]
+		var h series
+		for _, x := range samples {
+			if x.Time < cutoff || x.Value <= 0 || len(h) > 0 && x.Time <= h[len(h)-1].Time {
+				continue
+			}
+			if len(cur) > 0 && x.Time >= cur[0].Time {
+				break
+			}
+			h = append(h, x)
+		}
+		if len(h) > 0 {
+			s.midHistory[coin] = append(h, cur...)
+		}
+	}
+}
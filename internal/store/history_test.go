@@ -0,0 +1,163 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+// setClock points util.Now at *now for the test.
+func setClock(t *testing.T, now *time.Time) {
+	t.Helper()
+	prev := util.Now
+	util.Now = func() time.Time { return *now }
+	t.Cleanup(func() { util.Now = prev })
+}
+
+func TestSeriesAdd(t *testing.T) {
+	var s series
+	s = s.add(0, 1)
+	s = s.add(5_000, 2) // within sampleEvery: replaces the value
+	if len(s) != 1 || s[0] != (Sample{0, 2}) {
+		t.Fatalf("series = %v", s)
+	}
+	s = s.add(sampleEvery.Milliseconds(), 3)
+	s = s.add(1_000, 9) // out of order: dropped
+	if len(s) != 2 || s[1].Value != 3 {
+		t.Fatalf("series = %v", s)
+	}
+
+	span := historySpan.Milliseconds()
+	s = s.add(span+sampleEvery.Milliseconds(), 4)
+	if len(s) != 2 || s[0].Value != 3 || s[1].Value != 4 {
+		t.Errorf("after aging out: %v", s)
+	}
+}
+
+func TestMidChange(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	setClock(t, &now)
+	s := New()
+
+	// Mids from before the universe loads are sampled when it does
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("100")})
+	s.SetMetaAndAssetCtxs(marketMeta())
+	mid := func(px string) {
+		s.UpdateMids(api.AllMids{"BTC": decimal.MustParse(px), "@107": decimal.MustParse(px)})
+	}
+	if _, ok := s.MidChange("BTC", time.Hour); ok {
+		t.Error("change without an hour of history")
+	}
+	now = now.Add(30 * time.Minute)
+	mid("104")
+	now = now.Add(30 * time.Minute)
+	mid("110")
+
+	if pct, ok := s.MidChange("BTC", time.Hour); !ok || !near(pct, 10) {
+		t.Errorf("1h change = %v, %v; want 10", pct, ok)
+	}
+	if pct, ok := s.MidChange("BTC", 5*time.Minute); !ok || !near(pct, (110-104)/104.0*100) {
+		t.Errorf("5m change = %v, %v", pct, ok)
+	}
+	if _, ok := s.MidChange("ETH", time.Hour); ok {
+		t.Error("change for a coin without mids")
+	}
+	if _, ok := s.MidHistory()["@107"]; ok {
+		t.Error("spot pair outside the universe sampled")
+	}
+
+	s.ClearAll()
+	if _, ok := s.MidChange("BTC", time.Hour); ok {
+		t.Error("history survived ClearAll")
+	}
+}
+
+func TestOpenInterestChange(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	setClock(t, &now)
+	s := New()
+
+	meta := marketMeta()
+	s.SetMetaAndAssetCtxs(meta)
+	now = now.Add(time.Hour)
+	next := marketMeta()
+	next.AssetCtxs[0].OpenInterest = decimal.MustParse("12")
+	s.SetMetaAndAssetCtxs(next)
+
+	if pct, ok := s.OpenInterestChange("BTC", time.Hour); !ok || !near(pct, 20) {
+		t.Errorf("BTC OI change = %v, %v; want 20", pct, ok)
+	}
+	if pct, ok := s.OpenInterestChange("ETH", time.Hour); !ok || pct != 0 {
+		t.Errorf("ETH OI change = %v, %v; want 0", pct, ok)
+	}
+}
+
+func TestContextSeries(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	setClock(t, &now)
+	s := New()
+
+	for i, funding := range []string{"0.00001", "0.00002", "0.00005"} {
+		if i > 0 {
+			now = now.Add(30 * time.Minute)
+		}
+		meta := marketMeta()
+		meta.AssetCtxs[0].Funding = decimal.MustParse(funding)
+		meta.AssetCtxs[0].OraclePx = decimal.MustParse("90000")
+		s.SetMetaAndAssetCtxs(meta)
+	}
+
+	// The first sample is the last one at or before the window's start
+	got, ok := s.ContextSeries("BTC", MetricFunding, 45*time.Minute)
+	if !ok || len(got) != 3 || !near(got[0].Value, 0.00001) || !near(got[2].Value, 0.00005) {
+		t.Errorf("45m funding = %v, %v", got, ok)
+	}
+	if got, ok := s.ContextSeries("BTC", MetricFunding, 30*time.Minute); !ok || len(got) != 2 {
+		t.Errorf("30m funding = %v, %v", got, ok)
+	}
+	if got, ok := s.ContextSeries("BTC", MetricBasis, time.Hour); !ok || !near(got[0].Value, 0.01/0.9) {
+		t.Errorf("basis = %v, %v", got, ok)
+	}
+	if _, ok := s.ContextSeries("BTC", MetricVolume, 2*time.Hour); ok {
+		t.Error("2h volume covered by an hour of history")
+	}
+	// No oracle price, no basis
+	if got, ok := s.ContextSeries("ETH", MetricBasis, time.Hour); ok || got != nil {
+		t.Errorf("ETH basis = %v, %v", got, ok)
+	}
+}
+
+func TestRestoreMidHistory(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	setClock(t, &now)
+	s := New()
+	s.SetMetaAndAssetCtxs(marketMeta())
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("110")})
+
+	at := func(d time.Duration) int64 { return now.Add(d).UnixMilli() }
+	s.RestoreMidHistory(map[string][]Sample{
+		"BTC": {
+			{at(-historySpan - time.Minute), 1}, // too old
+			{at(-4 * time.Hour), 80},
+			{at(-time.Hour), 100},
+			{at(0), 999}, // overlaps this run's samples
+		},
+		"ETH": {{at(-2 * time.Hour), 3000}},
+	})
+
+	if pct, ok := s.MidChange("BTC", time.Hour); !ok || !near(pct, 10) {
+		t.Errorf("1h change after restore = %v, %v; want 10", pct, ok)
+	}
+	if got := s.MidSeries("BTC", 4*time.Hour); len(got) != 3 || got[0].Value != 80 || got[2].Value != 110 {
+		t.Errorf("4h series = %v", got)
+	}
+	if got := s.MidSeries("BTC", time.Hour); len(got) != 2 {
+		t.Errorf("1h series = %v", got)
+	}
+	if h := s.MidHistory(); len(h["ETH"]) != 1 || len(h["BTC"]) != 3 {
+		t.Errorf("history = %v", h)
+	}
+}
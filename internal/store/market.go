@@ -0,0 +1,159 @@
+package store
+
+import (
+	"maps"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+// Asset is one perp as of the latest asset contexts and mids, with the
+// values views sort, filter and draw derived once per update rather than
+// per frame.
+type Asset struct {
+	api.AssetMeta
+	Ctx   api.AssetCtx
+	Index int             // asset id: position in the universe
+	Mid   decimal.Decimal // zero until a mid arrives
+
+	// Derived as float64 for ranking and display math
+	Price           float64 // mid, or mark before the first mid
+	PrevDayPx       float64
+	Change24h       float64 // % since PrevDayPx; 0 without one
+	Funding         float64 // hourly rate
+	Volume24h       float64 // USD
+	OpenInterest    float64 // in coins
+	OpenInterestUSD float64 // at Price
+	OraclePx        float64
+}
+
+func newAsset(i int, meta api.AssetMeta, ctx api.AssetCtx, mid decimal.Decimal) Asset {
+	a := Asset{
+		AssetMeta:    meta,
+		Ctx:          ctx,
+		Index:        i,
+		PrevDayPx:    ctx.PrevDayPx.Float64(),
+		Funding:      ctx.Funding.Float64(),
+		Volume24h:    ctx.DayNtlVlm.Float64(),
+		OpenInterest: ctx.OpenInterest.Float64(),
+		OraclePx:     ctx.OraclePx.Float64(),
+	}
+	a.setMid(mid)
+	return a
+}
+
+// setMid updates the mid and everything derived from it.
+func (a *Asset) setMid(mid decimal.Decimal) {
+	a.Mid = mid
+	a.Price = mid.Float64()
+	if a.Price == 0 {
+		a.Price = a.Ctx.MarkPx.Float64()
+	}
+	a.Change24h = 0
+	if a.PrevDayPx > 0 {
+		a.Change24h = (a.Price - a.PrevDayPx) / a.PrevDayPx * 100
+	}
+	a.OpenInterestUSD = a.OpenInterest * a.Price
+}
+
+// LivePrice is the price positions are revalued at: the mid, which streams
+// on every tick, falling back to the mark.
+func (a Asset) LivePrice() decimal.Decimal {
+	if a.Mid.Sign() > 0 {
+		return a.Mid
+	}
+	return a.Ctx.MarkPx
+}
+
+// Market is an immutable snapshot of every perp. The store replaces it on
+// each update instead of changing it, so a view can hold on to one without
+// the store lock.
+type Market struct {
+	Assets []Asset // universe order
+	index  map[string]int
+}
+
+// buildMarket joins the universe, its contexts and the mids. It returns nil
+// until the contexts have loaded.
+func buildMarket(meta *api.MetaAndAssetCtxs, mids api.AllMids) *Market {
+	if meta == nil || len(meta.AssetCtxs) == 0 {
+		return nil
+	}
+	n := min(len(meta.Meta.Universe), len(meta.AssetCtxs))
+	m := &Market{
+		Assets: make([]Asset, n),
+		index:  make(map[string]int, n),
+	}
+	for i, asset := range meta.Meta.Universe[:n] {
+		m.Assets[i] = newAsset(i, asset, meta.AssetCtxs[i], mids[asset.Name])
+		m.index[asset.Name] = i
+	}
+	return m
+}
+
+// withMids returns a copy of m with the given mids applied, sharing the
+// index. Coins outside the universe are ignored.
+func (m *Market) withMids(mids api.AllMids) *Market {
+	next := &Market{Assets: make([]Asset, len(m.Assets)), index: m.index}
+	copy(next.Assets, m.Assets)
+	for coin, mid := range mids {
+		if i, ok := m.index[coin]; ok {
+			next.Assets[i].setMid(mid)
+		}
+	}
+	return next
+}
+
+// Asset returns the named perp. It is safe to call on a nil Market.
+func (m *Market) Asset(coin string) (Asset, bool) {
+	if m == nil {
+		return Asset{}, false
+	}
+	i, ok := m.index[coin]
+	if !ok {
+		return Asset{}, false
+	}
+	return m.Assets[i], true
+}
+
+// Market returns the latest market snapshot, or nil before the asset
+// contexts have loaded. It must not be modified.
+func (s *Store) Market() *Market {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.market
+}
+
+// SetAllMids replaces every mid, as after a full refetch.
+func (s *Store) SetAllMids(mids api.AllMids) {
+	s.mu.Lock()
+	s.allMids = make(api.AllMids, len(mids))
+	maps.Copy(s.allMids, mids)
+	s.market = buildMarket(s.metaAndAssetCtxs, s.allMids)
+	s.recordMids(mids)
+	s.revalueBook()
+	s.mu.Unlock()
+
+	s.publish(MidsUpdated{Mids: maps.Clone(mids)})
+}
+
+// SetMetaAndAssetCtxs replaces the universe and asset contexts.
+func (s *Store) SetMetaAndAssetCtxs(meta *api.MetaAndAssetCtxs) {
+	s.mu.Lock()
+	s.setMetaAndAssetCtxs(meta)
+	s.revalueBook()
+	s.mu.Unlock()
+
+	s.publish(ContextsUpdated{})
+}
+
+func (s *Store) setMetaAndAssetCtxs(meta *api.MetaAndAssetCtxs) {
+	first := s.market == nil
+	s.metaAndAssetCtxs = meta
+	s.market = buildMarket(meta, s.allMids)
+	s.recordAssetCtxs(s.market)
+	if first {
+		// Mids that came before the universe couldn't be sampled then
+		s.recordMids(s.allMids)
+	}
+}
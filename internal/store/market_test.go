@@ -0,0 +1,83 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+func marketMeta() *api.MetaAndAssetCtxs {
+	return &api.MetaAndAssetCtxs{
+		Meta: api.Meta{Universe: []api.AssetMeta{{Name: "BTC", MaxLeverage: 40}, {Name: "ETH", MaxLeverage: 25}}},
+		AssetCtxs: []api.AssetCtx{
+			{MarkPx: decimal.MustParse("90010"), PrevDayPx: decimal.MustParse("88000"), OpenInterest: decimal.MustParse("10"), Funding: decimal.MustParse("0.0000125")},
+			{MarkPx: decimal.MustParse("3000"), PrevDayPx: decimal.MustParse("3200"), OpenInterest: decimal.MustParse("100")},
+		},
+	}
+}
+
+func TestMarketSnapshot(t *testing.T) {
+	s := New()
+	if s.Market() != nil {
+		t.Fatal("market before contexts loaded")
+	}
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("90000")})
+	s.SetMetaAndAssetCtxs(marketMeta())
+
+	m := s.Market()
+	if len(m.Assets) != 2 {
+		t.Fatalf("assets = %d, want 2", len(m.Assets))
+	}
+	btc, ok := m.Asset("BTC")
+	if !ok || btc.Index != 0 || btc.MaxLeverage != 40 {
+		t.Fatalf("BTC = %+v", btc)
+	}
+	if btc.Price != 90000 || !near(btc.Change24h, 2000.0/88000*100) || btc.OpenInterestUSD != 900000 || btc.Funding != 0.0000125 {
+		t.Errorf("BTC price %v chg %v oi %v funding %v", btc.Price, btc.Change24h, btc.OpenInterestUSD, btc.Funding)
+	}
+	// No mid yet: priced at the mark
+	if eth, _ := m.Asset("ETH"); eth.Price != 3000 || !eth.LivePrice().Equal(decimal.MustParse("3000")) {
+		t.Errorf("ETH price %v live %v", eth.Price, eth.LivePrice())
+	}
+	if _, ok := m.Asset("DOGE"); ok {
+		t.Error("DOGE found")
+	}
+
+	// A tick replaces the snapshot; the one a view holds is untouched
+	s.UpdateMids(api.AllMids{"ETH": decimal.MustParse("3100"), "DOGE": decimal.MustParse("0.1")})
+	if eth, _ := m.Asset("ETH"); eth.Price != 3000 {
+		t.Errorf("held snapshot changed: ETH = %v", eth.Price)
+	}
+	eth, _ := s.Market().Asset("ETH")
+	if eth.Price != 3100 || !near(eth.Change24h, -100.0/3200*100) || eth.OpenInterestUSD != 310000 {
+		t.Errorf("ETH price %v chg %v oi %v", eth.Price, eth.Change24h, eth.OpenInterestUSD)
+	}
+
+	s.ClearAll()
+	if s.Market() != nil {
+		t.Error("market survived ClearAll")
+	}
+}
+
+func TestNilMarketAsset(t *testing.T) {
+	var m *Market
+	if _, ok := m.Asset("BTC"); ok {
+		t.Error("nil market found an asset")
+	}
+}
+
+func TestLivePositionsFollowContexts(t *testing.T) {
+	s := New()
+	s.SetClearinghouseState(revalueState())
+	s.SetMetaAndAssetCtxs(marketMeta())
+
+	// Revalued at the marks until mids arrive
+	positions, _ := s.LivePositions(true)
+	if len(positions) != 2 || positions[0].Position.Coin != "ETH" {
+		t.Fatalf("ascending positions = %+v", positions)
+	}
+	if !eq(positions[1].MarkPx, "90010") || !eq(positions[1].UnrealizedPnl, "20") {
+		t.Errorf("BTC mark %v pnl %v", positions[1].MarkPx, positions[1].UnrealizedPnl)
+	}
+}
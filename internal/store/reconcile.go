@@ -0,0 +1,180 @@
+package store
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+)
+
+// MaxFills caps the fills kept in memory, newest first.
+const MaxFills = 5000
+
+// fillKey identifies a fill. Tid is unique per fill; hash plus oid covers
+// the rare fill that arrives without one.
+func fillKey(f api.Fill) string {
+	if f.Tid != 0 {
+		return "t" + strconv.FormatInt(f.Tid, 10)
+	}
+	return "h" + f.Hash + "/" + strconv.FormatInt(f.Oid, 10) + "/" + f.Sz.String()
+}
+
+// MergeFills adds fills not already held, keeping them newest first and
+// capped at MaxFills. It returns how many were new.
+func (s *Store) MergeFills(fills []api.Fill) int {
+	s.mu.Lock()
+	added := newFills(s.fills, fills)
+	if len(added) > 0 {
+		merged := append(s.fills, added...)
+		sort.SliceStable(merged, func(i, j int) bool {
+			if merged[i].Time != merged[j].Time {
+				return merged[i].Time > merged[j].Time
+			}
+			return merged[i].Tid > merged[j].Tid
+		})
+		if len(merged) > MaxFills {
+			merged = merged[:MaxFills]
+		}
+		s.fills = merged
+	}
+	s.mu.Unlock()
+
+	s.publish(fillEvents(added)...)
+	return len(added)
+}
+
+// newFills returns those of fills not in held, once each.
+func newFills(held, fills []api.Fill) []api.Fill {
+	seen := make(map[string]bool, len(held))
+	for _, f := range held {
+		seen[fillKey(f)] = true
+	}
+	var added []api.Fill
+	for _, f := range fills {
+		k := fillKey(f)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		added = append(added, f)
+	}
+	return added
+}
+
+func fillEvents(fills []api.Fill) []Event {
+	events := make([]Event, len(fills))
+	for i, f := range fills {
+		events[i] = FillAdded{Fill: f}
+	}
+	return events
+}
+
+// paymentKey identifies a funding payment: a coin is paid at most once per
+// funding time.
+type paymentKey struct {
+	time int64
+	coin string
+}
+
+// MergeFundingPayments adds payments not already held, keeping them oldest
+// first. It returns how many were new.
+func (s *Store) MergeFundingPayments(payments []api.FundingPayment) int {
+	s.mu.Lock()
+	added := newPayments(s.fundingPayments, payments)
+	if len(added) > 0 {
+		merged := append(s.fundingPayments, added...)
+		sort.SliceStable(merged, func(i, j int) bool { return merged[i].Time < merged[j].Time })
+		s.fundingPayments = merged
+	}
+	s.mu.Unlock()
+
+	s.publish(paymentEvents(added)...)
+	return len(added)
+}
+
+// newPayments returns those of payments not in held, once each.
+func newPayments(held, payments []api.FundingPayment) []api.FundingPayment {
+	seen := make(map[paymentKey]bool, len(held))
+	for _, p := range held {
+		seen[paymentKey{p.Time, p.Coin}] = true
+	}
+	var added []api.FundingPayment
+	for _, p := range payments {
+		k := paymentKey{p.Time, p.Coin}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		added = append(added, p)
+	}
+	return added
+}
+
+func paymentEvents(payments []api.FundingPayment) []Event {
+	events := make([]Event, len(payments))
+	for i, p := range payments {
+		events[i] = FundingAdded{Payment: p}
+	}
+	return events
+}
+
+// ReconcileOpenOrders replaces the open orders with a fresh snapshot,
+// keeping the existing order of orders that are still open. It returns how
+// many orders appeared and disappeared relative to what was held.
+func (s *Store) ReconcileOpenOrders(orders []api.OpenOrder) (opened, closed int) {
+	s.mu.Lock()
+	fresh := make(map[int64]api.OpenOrder, len(orders))
+	for _, o := range orders {
+		fresh[o.Oid] = o
+	}
+	held := make(map[int64]bool, len(s.openOrders))
+	result := make([]api.OpenOrder, 0, len(orders))
+	for _, o := range s.openOrders {
+		held[o.Oid] = true
+		if f, ok := fresh[o.Oid]; ok {
+			result = append(result, f)
+		}
+	}
+	for _, o := range orders {
+		if !held[o.Oid] {
+			result = append(result, o)
+			held[o.Oid] = true
+		}
+	}
+	events := orderEvents(s.openOrders, result)
+	s.openOrders = result
+	s.mu.Unlock()
+
+	for _, e := range events {
+		if e.(OrderChanged).Open {
+			opened++
+		} else {
+			closed++
+		}
+	}
+	s.publish(events...)
+	return opened, closed
+}
+
+// orderEvents lists the orders in next but not held as opened, and those
+// held but not in next as closed.
+func orderEvents(held, next []api.OpenOrder) []Event {
+	inNext := make(map[int64]bool, len(next))
+	for _, o := range next {
+		inNext[o.Oid] = true
+	}
+	inHeld := make(map[int64]bool, len(held))
+	var events []Event
+	for _, o := range held {
+		inHeld[o.Oid] = true
+		if !inNext[o.Oid] {
+			events = append(events, OrderChanged{Order: o})
+		}
+	}
+	for _, o := range next {
+		if !inHeld[o.Oid] {
+			events = append(events, OrderChanged{Order: o, Open: true})
+		}
+	}
+	return events
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+func TestMergeFills(t *testing.T) {
+	s := New()
+	s.fills = []api.Fill{
+		{Coin: "BTC", Time: 300, Tid: 3},
+		{Coin: "BTC", Time: 100, Tid: 1},
+	}
+
+	// A backfill overlapping what WS already delivered, out of order
+	added := s.MergeFills([]api.Fill{
+		{Coin: "ETH", Time: 200, Tid: 2},
+		{Coin: "BTC", Time: 300, Tid: 3},
+		{Coin: "SOL", Time: 400, Tid: 4},
+		{Coin: "SOL", Time: 400, Tid: 4},
+	})
+	if added != 2 {
+		t.Errorf("added = %d, want 2", added)
+	}
+	var tids []int64
+	for _, f := range s.fills {
+		tids = append(tids, f.Tid)
+	}
+	if len(tids) != 4 || tids[0] != 4 || tids[1] != 3 || tids[2] != 2 || tids[3] != 1 {
+		t.Errorf("tids = %v, want [4 3 2 1]", tids)
+	}
+
+	// Fills without a tid fall back to hash and oid
+	s.MergeFills([]api.Fill{{Hash: "0xa", Oid: 9, Sz: decimal.MustParse("1"), Time: 50}})
+	if added := s.MergeFills([]api.Fill{{Hash: "0xa", Oid: 9, Sz: decimal.MustParse("1"), Time: 50}}); added != 0 {
+		t.Errorf("tid-less duplicate added %d", added)
+	}
+}
+
+func TestMergeFillsCap(t *testing.T) {
+	s := New()
+	fills := make([]api.Fill, MaxFills+10)
+	for i := range fills {
+		fills[i] = api.Fill{Time: int64(i), Tid: int64(i + 1)}
+	}
+	s.MergeFills(fills)
+	if len(s.fills) != MaxFills {
+		t.Fatalf("len = %d, want %d", len(s.fills), MaxFills)
+	}
+	if s.fills[0].Tid != int64(MaxFills+10) {
+		t.Errorf("newest kept = %d, want the newest fill", s.fills[0].Tid)
+	}
+}
+
+func TestMergeFundingPayments(t *testing.T) {
+	s := New()
+	s.fundingPayments = []api.FundingPayment{{Time: 1, Coin: "BTC"}, {Time: 2, Coin: "BTC"}}
+	added := s.MergeFundingPayments([]api.FundingPayment{
+		{Time: 2, Coin: "BTC"},
+		{Time: 2, Coin: "ETH"},
+		{Time: 3, Coin: "BTC"},
+	})
+	if added != 2 || len(s.fundingPayments) != 4 {
+		t.Fatalf("added %d, len %d", added, len(s.fundingPayments))
+	}
+	for i := 1; i < len(s.fundingPayments); i++ {
+		if s.fundingPayments[i].Time < s.fundingPayments[i-1].Time {
+			t.Errorf("payments not oldest first: %+v", s.fundingPayments)
+		}
+	}
+}
+
+func TestReconcileOpenOrders(t *testing.T) {
+	s := New()
+	s.openOrders = []api.OpenOrder{
+		{Oid: 1, Sz: decimal.MustParse("1")},
+		{Oid: 2, Sz: decimal.MustParse("1")},
+		{Oid: 3, Sz: decimal.MustParse("1")},
+	}
+	// During the outage 2 filled, 3 was partly filled and 4 was placed
+	opened, closed := s.ReconcileOpenOrders([]api.OpenOrder{
+		{Oid: 4, Sz: decimal.MustParse("2")},
+		{Oid: 3, Sz: decimal.MustParse("0.5")},
+		{Oid: 1, Sz: decimal.MustParse("1")},
+	})
+	if opened != 1 || closed != 1 {
+		t.Errorf("opened %d closed %d, want 1 and 1", opened, closed)
+	}
+	want := []struct {
+		oid int64
+		sz  string
+	}{{1, "1"}, {3, "0.5"}, {4, "2"}}
+	if len(s.openOrders) != len(want) {
+		t.Fatalf("orders = %+v", s.openOrders)
+	}
+	for i, w := range want {
+		if o := s.openOrders[i]; o.Oid != w.oid || o.Sz.String() != w.sz {
+			t.Errorf("order %d = %d %s, want %d %s", i, o.Oid, o.Sz, w.oid, w.sz)
+		}
+	}
+}
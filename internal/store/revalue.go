@@ -0,0 +1,204 @@
+package store
+
+import (
+	"sort"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+// Server and local uPnL are expected to differ by the gap between the mid
+// and the mark. A difference beyond this share of the position value, or
+// minDrift USD if larger, means the snapshot and our revaluation disagree
+// about the position itself.
+var (
+	driftTolerance = decimal.MustParse("0.005")
+	minDrift       = decimal.NewFromInt(1)
+)
+
+// LivePosition is a position revalued at the latest price. Value, PnL and
+// ROE follow the price between clearinghouse snapshots; everything else is
+// as of the last snapshot.
+type LivePosition struct {
+	Position       api.Position
+	MarkPx         decimal.Decimal // price it was revalued at; zero when none is known
+	Value          decimal.Decimal
+	UnrealizedPnl  decimal.Decimal
+	ReturnOnEquity float64 // fraction of initial margin
+	// Drift is the server's uPnL less ours when the last snapshot arrived,
+	// set only when it was beyond tolerance
+	Drift decimal.Decimal
+}
+
+// LiqDistance is how far the price must move to reach the liquidation
+// price, as a % of the price revalued at. ok is false without either.
+func (lp LivePosition) LiqDistance() (pct float64, ok bool) {
+	liq := lp.Position.LiquidationPx
+	if liq == nil || liq.Sign() <= 0 || lp.MarkPx.Sign() <= 0 {
+		return 0, false
+	}
+	return liq.Sub(lp.MarkPx).Abs().Float64() / lp.MarkPx.Float64() * 100, true
+}
+
+// LiveAccount is the margin summary revalued at the latest prices.
+type LiveAccount struct {
+	AccountValue decimal.Decimal
+	TotalNtlPos  decimal.Decimal
+	MarginUsed   decimal.Decimal
+	MaintMargin  decimal.Decimal
+	Withdrawable decimal.Decimal
+	MarginRatio  float64 // maintenance margin / account value, in %
+}
+
+// livePrice is the price positions are revalued at; see Asset.LivePrice.
+// Before the asset contexts load it is the mid alone.
+func (s *Store) livePrice(coin string) decimal.Decimal {
+	if a, ok := s.market.Asset(coin); ok {
+		return a.LivePrice()
+	}
+	return s.allMids[coin]
+}
+
+// revalue marks p to px. Without a price the server's numbers stand.
+func revalue(p api.Position, px decimal.Decimal) LivePosition {
+	lp := LivePosition{
+		Position:       p,
+		Value:          p.PositionValue,
+		UnrealizedPnl:  p.UnrealizedPnl,
+		ReturnOnEquity: p.ReturnOnEquity.Float64(),
+	}
+	if px.Sign() <= 0 {
+		return lp
+	}
+	lp.MarkPx = px
+	lp.Value = p.Szi.Abs().Mul(px)
+	lp.UnrealizedPnl = p.Szi.Mul(px.Sub(p.EntryPx))
+	if p.Leverage.Value > 0 && p.EntryPx.Sign() > 0 {
+		margin := p.Szi.Abs().Mul(p.EntryPx).Float64() / p.Leverage.Value
+		lp.ReturnOnEquity = lp.UnrealizedPnl.Float64() / margin
+	}
+	return lp
+}
+
+// SetClearinghouseState replaces the clearinghouse state and reconciles it
+// against our revaluation at the current prices, recording drift per coin.
+func (s *Store) SetClearinghouseState(st *api.ClearinghouseState) {
+	s.mu.Lock()
+	s.setClearinghouseState(st)
+	s.mu.Unlock()
+
+	s.publish(PositionsChanged{})
+}
+
+func (s *Store) setClearinghouseState(st *api.ClearinghouseState) {
+	s.clearinghouseState = st
+	s.pnlDrift = make(map[string]decimal.Decimal)
+	if st != nil {
+		for _, ap := range st.AssetPositions {
+			lp := revalue(ap.Position, s.livePrice(ap.Position.Coin))
+			drift := ap.Position.UnrealizedPnl.Sub(lp.UnrealizedPnl)
+			tolerance := lp.Value.Mul(driftTolerance)
+			if tolerance.Cmp(minDrift) < 0 {
+				tolerance = minDrift
+			}
+			if drift.Abs().Cmp(tolerance) > 0 {
+				s.pnlDrift[ap.Position.Coin] = drift
+			}
+		}
+	}
+	s.revalueBook()
+}
+
+// book is the open positions and margin summary revalued at the latest
+// prices. It is rebuilt whenever the clearinghouse state or a price changes,
+// so reading it per frame costs a copy.
+type book struct {
+	positions []LivePosition // by live uPnL, highest first
+	account   LiveAccount
+}
+
+// revalueBook rebuilds s.book; callers hold the write lock.
+func (s *Store) revalueBook() {
+	st := s.clearinghouseState
+	if st == nil {
+		s.book = nil
+		return
+	}
+	b := &book{positions: make([]LivePosition, 0, len(st.AssetPositions))}
+	for _, ap := range st.AssetPositions {
+		lp := revalue(ap.Position, s.livePrice(ap.Position.Coin))
+		lp.Drift = s.pnlDrift[ap.Position.Coin]
+		b.positions = append(b.positions, lp)
+	}
+	sort.SliceStable(b.positions, func(i, j int) bool {
+		return b.positions[i].UnrealizedPnl.Cmp(b.positions[j].UnrealizedPnl) > 0
+	})
+	b.account = liveAccount(st, b.positions)
+	s.book = b
+}
+
+// LivePositions returns the open positions revalued at the latest prices,
+// sorted by live uPnL, along with a copy of the funding rates.
+func (s *Store) LivePositions(ascending bool) ([]LivePosition, map[string]float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.book == nil {
+		return nil, nil
+	}
+	n := len(s.book.positions)
+	positions := make([]LivePosition, n)
+	for i, lp := range s.book.positions {
+		if ascending {
+			positions[n-1-i] = lp
+		} else {
+			positions[i] = lp
+		}
+	}
+	fundingRates := make(map[string]float64, len(s.fundingRates))
+	for k, v := range s.fundingRates {
+		fundingRates[k] = v
+	}
+	return positions, fundingRates
+}
+
+// LiveAccount returns the margin summary revalued at the latest prices.
+// ok is false before the first snapshot.
+func (s *Store) LiveAccount() (acct LiveAccount, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.book == nil {
+		return LiveAccount{}, false
+	}
+	return s.book.account, true
+}
+
+// liveAccount revalues st's margin summary given its revalued positions:
+// account value moves with uPnL, and cross maintenance margin with cross
+// notional.
+func liveAccount(st *api.ClearinghouseState, positions []LivePosition) LiveAccount {
+	acct := LiveAccount{
+		AccountValue: st.MarginSummary.AccountValue,
+		TotalNtlPos:  st.MarginSummary.TotalNtlPos,
+		MarginUsed:   st.MarginSummary.TotalMarginUsed,
+		MaintMargin:  st.CrossMaintenanceMarginUsed,
+		Withdrawable: st.Withdrawable,
+	}
+	var crossThen, crossNow decimal.Decimal
+	for _, lp := range positions {
+		serverValue := lp.Position.PositionValue
+		acct.AccountValue = acct.AccountValue.Add(lp.UnrealizedPnl.Sub(lp.Position.UnrealizedPnl))
+		acct.TotalNtlPos = acct.TotalNtlPos.Add(lp.Value.Sub(serverValue))
+		if lp.Position.Leverage.Type != "isolated" {
+			crossThen = crossThen.Add(serverValue)
+			crossNow = crossNow.Add(lp.Value)
+		}
+	}
+	if crossThen.Sign() > 0 {
+		// Margin is reported to the micro-dollar
+		acct.MaintMargin = acct.MaintMargin.Mul(crossNow).Div(crossThen, 6)
+	}
+	if acct.AccountValue.Sign() > 0 {
+		acct.MarginRatio = acct.MaintMargin.Float64() / acct.AccountValue.Float64() * 100
+	}
+	return acct
+}
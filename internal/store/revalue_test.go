@@ -0,0 +1,125 @@
+package store
+
+import (
+	"math"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+// eq reports whether d is exactly want.
+func eq(d decimal.Decimal, want string) bool { return d.Equal(decimal.MustParse(want)) }
+
+// A 2 BTC long from 90000 at 10x and a 10 ETH short from 3000 at 5x,
+// snapshotted with BTC at 91000 and ETH at 3100.
+func revalueState() *api.ClearinghouseState {
+	return &api.ClearinghouseState{
+		MarginSummary:              api.MarginSummary{AccountValue: decimal.MustParse("50000"), TotalNtlPos: decimal.MustParse("213000"), TotalMarginUsed: decimal.MustParse("24400")},
+		CrossMaintenanceMarginUsed: decimal.MustParse("5000"),
+		Withdrawable:               decimal.MustParse("25600"),
+		AssetPositions: []api.AssetPosition{
+			{Position: api.Position{Coin: "BTC", Szi: decimal.MustParse("2"), EntryPx: decimal.MustParse("90000"), PositionValue: decimal.MustParse("182000"), UnrealizedPnl: decimal.MustParse("2000"), ReturnOnEquity: decimal.MustParse("0.111111"), Leverage: api.Leverage{Type: "cross", Value: 10}}},
+			{Position: api.Position{Coin: "ETH", Szi: decimal.MustParse("-10"), EntryPx: decimal.MustParse("3000"), PositionValue: decimal.MustParse("31000"), UnrealizedPnl: decimal.MustParse("-1000"), ReturnOnEquity: decimal.MustParse("-0.166667"), Leverage: api.Leverage{Type: "cross", Value: 5}}},
+		},
+	}
+}
+
+func TestLivePositionsFollowMids(t *testing.T) {
+	s := New()
+	s.allMids = api.AllMids{"BTC": decimal.MustParse("91000"), "ETH": decimal.MustParse("3100")}
+	s.SetClearinghouseState(revalueState())
+	if len(s.pnlDrift) != 0 {
+		t.Fatalf("drift on a consistent snapshot: %v", s.pnlDrift)
+	}
+
+	// BTC rallies, ETH drops: the short is now in profit
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("92000"), "ETH": decimal.MustParse("2900")})
+	positions, _ := s.LivePositions(false)
+	if len(positions) != 2 || positions[0].Position.Coin != "BTC" {
+		t.Fatalf("positions = %+v", positions)
+	}
+	btc, eth := positions[0], positions[1]
+	if !eq(btc.UnrealizedPnl, "4000") || !eq(btc.Value, "184000") || !eq(btc.MarkPx, "92000") {
+		t.Errorf("BTC pnl %v value %v mark %v", btc.UnrealizedPnl, btc.Value, btc.MarkPx)
+	}
+	// 4000 on 18000 initial margin
+	if !near(btc.ReturnOnEquity, 4000.0/18000) {
+		t.Errorf("BTC roe = %v", btc.ReturnOnEquity)
+	}
+	if !eq(eth.UnrealizedPnl, "1000") || !eq(eth.Value, "29000") {
+		t.Errorf("ETH pnl %v value %v", eth.UnrealizedPnl, eth.Value)
+	}
+
+	acct, ok := s.LiveAccount()
+	if !ok {
+		t.Fatal("no live account")
+	}
+	// uPnL went from 1000 to 5000
+	if !eq(acct.AccountValue, "54000") || !eq(acct.TotalNtlPos, "213000") {
+		t.Errorf("account value %v ntl %v", acct.AccountValue, acct.TotalNtlPos)
+	}
+	if !near(acct.MarginRatio, 5000.0/54000*100) {
+		t.Errorf("margin ratio = %v", acct.MarginRatio)
+	}
+}
+
+func TestLivePositionsWithoutPrice(t *testing.T) {
+	s := New()
+	s.SetClearinghouseState(revalueState())
+	positions, _ := s.LivePositions(false)
+	if !eq(positions[0].UnrealizedPnl, "2000") || !positions[0].MarkPx.IsZero() {
+		t.Errorf("without a price the server's numbers should stand: %+v", positions[0])
+	}
+	if acct, _ := s.LiveAccount(); !eq(acct.AccountValue, "50000") {
+		t.Errorf("account value = %v, want the server's", acct.AccountValue)
+	}
+}
+
+func TestSetClearinghouseStateDrift(t *testing.T) {
+	s := New()
+	s.allMids = api.AllMids{"BTC": decimal.MustParse("91000"), "ETH": decimal.MustParse("3101")}
+	st := revalueState()
+	// The server thinks the BTC position is worth far more than its size
+	// and entry say
+	st.AssetPositions[0].Position.UnrealizedPnl = decimal.MustParse("3500")
+	s.SetClearinghouseState(st)
+
+	if d := s.pnlDrift["BTC"]; !eq(d, "1500") {
+		t.Errorf("BTC drift = %v, want 1500", d)
+	}
+	// ETH is off by the $10 mid/mark gap, well within tolerance
+	if _, ok := s.pnlDrift["ETH"]; ok {
+		t.Error("ETH flagged for a mid/mark difference")
+	}
+	positions, _ := s.LivePositions(false)
+	for _, p := range positions {
+		if (!p.Drift.IsZero()) != (p.Position.Coin == "BTC") {
+			t.Errorf("%s drift = %v", p.Position.Coin, p.Drift)
+		}
+	}
+
+	// The next snapshot agrees again
+	s.SetClearinghouseState(revalueState())
+	if len(s.pnlDrift) != 0 {
+		t.Errorf("drift not cleared: %v", s.pnlDrift)
+	}
+}
+
+func TestLiqDistance(t *testing.T) {
+	liq := decimal.MustParse("81000")
+	lp := LivePosition{Position: api.Position{LiquidationPx: &liq}, MarkPx: decimal.MustParse("90000")}
+	if pct, ok := lp.LiqDistance(); !ok || !near(pct, 10) {
+		t.Errorf("LiqDistance = %v, %v; want 10", pct, ok)
+	}
+	lp.MarkPx = decimal.Zero
+	if _, ok := lp.LiqDistance(); ok {
+		t.Error("distance without a price")
+	}
+	lp = LivePosition{MarkPx: decimal.MustParse("90000")}
+	if _, ok := lp.LiqDistance(); ok {
+		t.Error("distance without a liquidation price")
+	}
+}
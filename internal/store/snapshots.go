@@ -0,0 +1,326 @@
+package store
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+)
+
+// Accessors return copies the caller may keep and modify. Values reached
+// through pointers (vault details, the clearinghouse state's positions) are
+// replaced whole on update, never changed in place, so they are shared.
+
+// ClearinghouseState returns the last clearinghouse snapshot, or nil before
+// the first.
+func (s *Store) ClearinghouseState() *api.ClearinghouseState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.clearinghouseState == nil {
+		return nil
+	}
+	st := *s.clearinghouseState
+	st.AssetPositions = slices.Clone(st.AssetPositions)
+	return &st
+}
+
+// PositionCoins returns the coins with an open position, in snapshot order.
+func (s *Store) PositionCoins() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.clearinghouseState == nil {
+		return nil
+	}
+	coins := make([]string, 0, len(s.clearinghouseState.AssetPositions))
+	for _, ap := range s.clearinghouseState.AssetPositions {
+		coins = append(coins, ap.Position.Coin)
+	}
+	return coins
+}
+
+// OpenOrders returns the open orders.
+func (s *Store) OpenOrders() []api.OpenOrder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.openOrders)
+}
+
+// SetOpenOrders replaces the open orders with a fresh snapshot.
+func (s *Store) SetOpenOrders(orders []api.OpenOrder) {
+	s.mu.Lock()
+	events := orderEvents(s.openOrders, orders)
+	s.openOrders = orders
+	s.mu.Unlock()
+
+	s.publish(events...)
+}
+
+// Fills returns the fills held, newest first.
+func (s *Store) Fills() []api.Fill {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.fills)
+}
+
+// SetFills replaces the fills with a fresh snapshot, newest first.
+func (s *Store) SetFills(fills []api.Fill) {
+	s.mu.Lock()
+	added := newFills(s.fills, fills)
+	s.fills = fills
+	s.mu.Unlock()
+
+	s.publish(fillEvents(added)...)
+}
+
+// FundingPayments returns the funding payments held, oldest first.
+func (s *Store) FundingPayments() []api.FundingPayment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.fundingPayments)
+}
+
+// SetFundingPayments replaces the funding payments with a fresh snapshot,
+// oldest first.
+func (s *Store) SetFundingPayments(payments []api.FundingPayment) {
+	s.mu.Lock()
+	added := newPayments(s.fundingPayments, payments)
+	s.fundingPayments = payments
+	s.mu.Unlock()
+
+	s.publish(paymentEvents(added)...)
+}
+
+// Portfolio returns the portfolio history periods.
+func (s *Store) Portfolio() []api.PortfolioPeriod {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.portfolio)
+}
+
+func (s *Store) SetPortfolio(periods []api.PortfolioPeriod) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.portfolio = periods
+}
+
+// UserFees returns the wallet's fee schedule, or nil before it loads.
+func (s *Store) UserFees() *api.UserFees {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clonePtr(s.userFees)
+}
+
+func (s *Store) SetUserFees(fees *api.UserFees) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userFees = fees
+}
+
+// UserRateLimit returns the wallet's request budget, or nil before it
+// loads.
+func (s *Store) UserRateLimit() *api.UserRateLimit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clonePtr(s.userRateLimit)
+}
+
+func (s *Store) SetUserRateLimit(limit *api.UserRateLimit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userRateLimit = limit
+}
+
+// VaultEquities returns the active wallet's vault stakes.
+func (s *Store) VaultEquities() []api.VaultEquity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.vaultEquities)
+}
+
+func (s *Store) SetVaultEquities(equities []api.VaultEquity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vaultEquities = equities
+}
+
+// SetWalletVaultEquities records other wallets' vault stakes for the
+// unlock timeline, keyed by wallet address.
+func (s *Store) SetWalletVaultEquities(equities map[string][]api.VaultEquity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	maps.Copy(s.walletVaultEquities, equities)
+}
+
+// VaultDetails returns the details loaded so far, keyed by vault address.
+func (s *Store) VaultDetails() map[string]*api.VaultDetails {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.vaultDetails)
+}
+
+func (s *Store) SetVaultDetails(addr string, d *api.VaultDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vaultDetails[addr] = d
+	s.vaultRevision++
+}
+
+// ManagedVault returns the details of the vault being monitored in vault
+// mode, or nil.
+func (s *Store) ManagedVault() *api.VaultDetails {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.managedVault
+}
+
+func (s *Store) SetManagedVault(d *api.VaultDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.managedVault = d
+}
+
+// VaultSummaries returns the public vault list, or nil before it loads.
+func (s *Store) VaultSummaries() []api.VaultSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.vaultSummaries)
+}
+
+// VaultSummariesLoaded reports whether the public vault list has loaded.
+func (s *Store) VaultSummariesLoaded() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.vaultSummaries != nil
+}
+
+// SetVaultSummaries replaces the public vault list. An empty list still
+// counts as loaded.
+func (s *Store) SetVaultSummaries(summaries []api.VaultSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vaultSummaries = summaries
+	if s.vaultSummaries == nil {
+		s.vaultSummaries = []api.VaultSummary{}
+	}
+	s.vaultRevision++
+}
+
+// VaultRevision changes whenever the vault list or any vault's details do,
+// so views can keep what they derive from them until it does.
+func (s *Store) VaultRevision() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.vaultRevision
+}
+
+// Staking is the wallet's HYPE staking state.
+type Staking struct {
+	Summary     *api.DelegatorSummary // nil before it loads
+	Delegations []api.Delegation
+	Rewards     []api.DelegatorReward
+	History     []api.DelegatorEvent
+}
+
+func (s *Store) Staking() Staking {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Staking{
+		Summary:     clonePtr(s.stakingSummary),
+		Delegations: slices.Clone(s.delegations),
+		Rewards:     slices.Clone(s.delegatorRewards),
+		History:     slices.Clone(s.delegatorHistory),
+	}
+}
+
+func (s *Store) SetStaking(st Staking) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stakingSummary = st.Summary
+	s.delegations = st.Delegations
+	s.delegatorRewards = st.Rewards
+	s.delegatorHistory = st.History
+}
+
+// Validators returns the validator summaries keyed by lowercase address.
+func (s *Store) Validators() map[string]api.ValidatorSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.validators)
+}
+
+// UpdateValidators adds or replaces validator summaries.
+func (s *Store) UpdateValidators(validators []api.ValidatorSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range validators {
+		s.validators[strings.ToLower(v.Validator)] = v
+	}
+}
+
+// PredictedFundings returns the predicted funding rates across venues.
+func (s *Store) PredictedFundings() api.PredictedFundings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.predictedFundings)
+}
+
+func (s *Store) SetPredictedFundings(fundings api.PredictedFundings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.predictedFundings = fundings
+}
+
+// FundingHistory returns coin's funding rate history, oldest first, and
+// whether it has been fetched.
+func (s *Store) FundingHistory(coin string) ([]api.FundingHistoryEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries, ok := s.fundingHistory[coin]
+	return slices.Clone(entries), ok
+}
+
+func (s *Store) SetFundingHistory(coin string, entries []api.FundingHistoryEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fundingHistory[coin] = entries
+}
+
+// L2Book returns coin's order book, or nil if it hasn't been fetched.
+func (s *Store) L2Book(coin string) *api.L2Book {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b := clonePtr(s.l2Books[coin])
+	if b != nil {
+		b.Levels = [2][]api.L2Level{slices.Clone(b.Levels[0]), slices.Clone(b.Levels[1])}
+	}
+	return b
+}
+
+func (s *Store) SetL2Book(book *api.L2Book) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.l2Books[book.Coin] = book
+}
+
+// Candles returns coin's price candles, oldest first.
+func (s *Store) Candles(coin string) []api.Candle {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.candles[coin])
+}
+
+func (s *Store) SetCandles(coin string, candles []api.Candle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.candles[coin] = candles
+}
+
+// clonePtr returns a pointer to a copy of *p, or nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+)
+
+// REST data sources, as tracked by RecordFetch
+const (
+	SourceAccount          = "account"
+	SourceMids             = "mids"
+	SourceMeta             = "meta"
+	SourceOrders           = "orders"
+	SourceFills            = "fills"
+	SourceFunding          = "funding"
+	SourcePortfolio        = "portfolio"
+	SourceFees             = "fees"
+	SourceVaults           = "vaults"
+	SourceVaultDetails     = "vault details"
+	SourceVaultList        = "vault list"
+	SourceRateLimit        = "rate limit"
+	SourceStaking          = "staking"
+	SourceValidators       = "validators"
+	SourcePredictedFunding = "predicted funding"
+	SourceFundingHistory   = "funding history"
+	SourceBook             = "book"
+	SourceCandles          = "candles"
+)
+
+// userSources hold per-wallet data; their status is reset on a wallet
+// switch.
+var userSources = []string{
+	SourceAccount, SourceOrders, SourceFills, SourceFunding, SourcePortfolio,
+	SourceFees, SourceVaults, SourceVaultDetails, SourceRateLimit, SourceStaking,
+}
+
+// maxFetchFailures caps the failure log.
+const maxFetchFailures = 50
+
+// SourceStatus is the fetch health of one source.
+type SourceStatus struct {
+	LastSuccess time.Time
+	LastError   error // nil once a fetch succeeds again
+	ErrorAt     time.Time
+	Failures    int // consecutive
+}
+
+// Failing reports whether the latest fetch failed.
+func (s SourceStatus) Failing() bool {
+	return s.LastError != nil
+}
+
+// FetchFailure is one failed fetch, for the errors panel.
+type FetchFailure struct {
+	Source string
+	Err    error
+	Time   time.Time
+}
+
+// RecordFetch notes the outcome of fetching source at t. Fetches abandoned
+// on a wallet switch aren't failures and are ignored.
+func (s *Store) RecordFetch(source string, err error, t time.Time) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sources == nil {
+		s.sources = make(map[string]SourceStatus)
+	}
+	st := s.sources[source]
+	if err == nil {
+		st.LastSuccess, st.LastError, st.Failures = t, nil, 0
+		s.sources[source] = st
+		return
+	}
+	st.LastError, st.ErrorAt = err, t
+	st.Failures++
+	s.sources[source] = st
+	s.fetchFailures = append(s.fetchFailures, FetchFailure{Source: source, Err: err, Time: t})
+	if n := len(s.fetchFailures); n > maxFetchFailures {
+		s.fetchFailures = s.fetchFailures[n-maxFetchFailures:]
+	}
+}
+
+// FetchError returns the error of the latest fetch of source, or nil if it
+// succeeded or hasn't run.
+func (s *Store) FetchError(source string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sources[source].LastError
+}
+
+// Failing returns those of sources whose latest fetch failed.
+func (s *Store) Failing(sources ...string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []string
+	for _, src := range sources {
+		if s.sources[src].Failing() {
+			out = append(out, src)
+		}
+	}
+	return out
+}
+
+// Sources returns the fetch health of every source fetched so far.
+func (s *Store) Sources() map[string]SourceStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.sources)
+}
+
+// FetchFailures returns the recent fetch failures, oldest first.
+func (s *Store) FetchFailures() []FetchFailure {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.fetchFailures)
+}
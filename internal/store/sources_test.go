@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecordFetch(t *testing.T) {
+	s := New()
+	t0 := time.Unix(1000, 0)
+	boom := errors.New("http 500")
+
+	s.RecordFetch(SourceFills, nil, t0)
+	s.RecordFetch(SourceFills, boom, t0.Add(time.Minute))
+	s.RecordFetch(SourceFills, boom, t0.Add(2*time.Minute))
+	s.RecordFetch(SourceOrders, context.Canceled, t0)
+
+	st := s.sources[SourceFills]
+	if !st.Failing() || st.Failures != 2 || !st.LastSuccess.Equal(t0) {
+		t.Errorf("fills status = %+v", st)
+	}
+	if !errors.Is(s.FetchError(SourceFills), boom) {
+		t.Errorf("FetchError = %v", s.FetchError(SourceFills))
+	}
+	if _, ok := s.sources[SourceOrders]; ok {
+		t.Error("a canceled fetch was recorded")
+	}
+	if got := s.Failing(SourceOrders, SourceFills); len(got) != 1 || got[0] != SourceFills {
+		t.Errorf("Failing = %v", got)
+	}
+	if len(s.fetchFailures) != 2 {
+		t.Errorf("failures = %d, want 2", len(s.fetchFailures))
+	}
+
+	s.RecordFetch(SourceFills, nil, t0.Add(3*time.Minute))
+	if s.FetchError(SourceFills) != nil || s.sources[SourceFills].Failures != 0 {
+		t.Errorf("status after recovery = %+v", s.sources[SourceFills])
+	}
+	if len(s.fetchFailures) != 2 {
+		t.Error("recovery should leave the failure log alone")
+	}
+
+	for i := 0; i < maxFetchFailures+5; i++ {
+		s.RecordFetch(SourceMeta, boom, t0)
+	}
+	if len(s.fetchFailures) != maxFetchFailures {
+		t.Errorf("log len = %d, want %d", len(s.fetchFailures), maxFetchFailures)
+	}
+}
+
+func TestClearUserDataKeepsGlobalSources(t *testing.T) {
+	s := New()
+	boom := errors.New("boom")
+	s.RecordFetch(SourceFills, boom, time.Now())
+	s.RecordFetch(SourceVaultList, boom, time.Now())
+	s.ClearUserData()
+	if s.FetchError(SourceFills) != nil {
+		t.Error("per-wallet source status survived a wallet switch")
+	}
+	if s.FetchError(SourceVaultList) == nil {
+		t.Error("global source status was cleared")
+	}
+}
@@ -1,141 +1,264 @@
 package store
 
 import (
+	"maps"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/born1337/hyperliquid-terminal/internal/api"
-	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
 	"github.com/born1337/hyperliquid-terminal/internal/ws"
 )
 
+// Store holds everything fetched or streamed for the active wallet and the
+// market. Read it through accessors, which return copies, and change it
+// through methods that publish an Event to subscribers.
 type Store struct {
-	mu sync.RWMutex
+	mu  sync.RWMutex
+	bus bus
 
 	// Account state
-	ClearinghouseState *api.ClearinghouseState
-	AllMids            api.AllMids
-	MetaAndAssetCtxs   *api.MetaAndAssetCtxs
+	clearinghouseState *api.ClearinghouseState
+	allMids            api.AllMids
+	metaAndAssetCtxs   *api.MetaAndAssetCtxs
 
 	// Per-view data
-	OpenOrders      []api.OpenOrder
-	Fills           []api.Fill
-	FundingPayments []api.FundingPayment
-	Portfolio       []api.PortfolioPeriod
-	UserFees        *api.UserFees
-	VaultEquities   []api.VaultEquity
-	VaultDetails    map[string]*api.VaultDetails
+	openOrders      []api.OpenOrder
+	fills           []api.Fill
+	fundingPayments []api.FundingPayment
+	portfolio       []api.PortfolioPeriod
+	userFees        *api.UserFees
+	vaultEquities   []api.VaultEquity
+	vaultDetails    map[string]*api.VaultDetails
+
+	// HYPE staking
+	stakingSummary   *api.DelegatorSummary
+	delegations      []api.Delegation
+	delegatorRewards []api.DelegatorReward
+	delegatorHistory []api.DelegatorEvent
+	validators       map[string]api.ValidatorSummary // validator address -> summary (global)
+
+	// Vault equities of every configured wallet, keyed by wallet address,
+	// for the cross-wallet unlock timeline
+	walletVaultEquities map[string][]api.VaultEquity
+
+	// Public vault list for the explorer (global, survives wallet switches)
+	vaultSummaries []api.VaultSummary
+
+	// Bumped whenever the vault list or any vault's details change
+	vaultRevision uint64
+
+	// Vault mode: details of the vault being monitored (nil otherwise)
+	managedVault *api.VaultDetails
+
+	// The wallet's address-based request budget
+	userRateLimit *api.UserRateLimit
+
+	// Funding rates across venues and per-coin history (global)
+	predictedFundings api.PredictedFundings
+	fundingHistory    map[string][]api.FundingHistoryEntry // coin -> oldest-first entries
+
+	// Order books and candles of the coins opened in the asset detail
+	// (global)
+	l2Books map[string]*api.L2Book
+	candles map[string][]api.Candle // coin -> oldest first
+
+	// Recent mids and asset context metrics per coin, sampled as they
+	// arrive (global)
+	midHistory map[string]series
+	ctxHistory [numMetrics]map[string]series
+
+	// Fetch health per REST source, and recent failures oldest first
+	sources       map[string]SourceStatus
+	fetchFailures []FetchFailure
 
 	// Derived/cached
-	FundingRates map[string]float64 // coin -> funding rate
+	fundingRates map[string]float64         // coin -> funding rate
+	pnlDrift     map[string]decimal.Decimal // coin -> server uPnL less ours, where they disagree
+	market       *Market                    // nil until the asset contexts load
+	book         *book                      // nil without a clearinghouse state
 }
 
 func New() *Store {
 	return &Store{
-		AllMids:      make(api.AllMids),
-		FundingRates: make(map[string]float64),
-		VaultDetails: make(map[string]*api.VaultDetails),
+		allMids:      make(api.AllMids),
+		fundingRates: make(map[string]float64),
+		vaultDetails: make(map[string]*api.VaultDetails),
+
+		walletVaultEquities: make(map[string][]api.VaultEquity),
+		validators:          make(map[string]api.ValidatorSummary),
+		fundingHistory:      make(map[string][]api.FundingHistoryEntry),
+		l2Books:             make(map[string]*api.L2Book),
+		candles:             make(map[string][]api.Candle),
+		midHistory:          make(map[string]series),
+		ctxHistory:          newCtxHistory(),
 	}
 }
 
-func (s *Store) Lock()    { s.mu.Lock() }
-func (s *Store) Unlock()  { s.mu.Unlock() }
-func (s *Store) RLock()   { s.mu.RLock() }
-func (s *Store) RUnlock() { s.mu.RUnlock() }
-
-// ClearUserData clears per-wallet data, preserving global market data (AllMids, Meta, FundingRates).
+// ClearUserData clears per-wallet data, preserving global market data (AllMids, Meta, FundingRates, VaultSummaries)
+// and the cross-wallet WalletVaultEquities.
 func (s *Store) ClearUserData() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.ClearinghouseState = nil
-	s.OpenOrders = nil
-	s.Fills = nil
-	s.FundingPayments = nil
-	s.Portfolio = nil
-	s.UserFees = nil
-	s.VaultEquities = nil
-	s.VaultDetails = make(map[string]*api.VaultDetails)
+	s.clearinghouseState = nil
+	s.pnlDrift = nil
+	s.book = nil
+	s.openOrders = nil
+	s.fills = nil
+	s.fundingPayments = nil
+	s.portfolio = nil
+	s.userFees = nil
+	s.vaultEquities = nil
+	s.vaultDetails = make(map[string]*api.VaultDetails)
+	s.vaultRevision++
+	s.managedVault = nil
+	s.userRateLimit = nil
+	s.clearStaking()
+	for _, src := range userSources {
+		delete(s.sources, src)
+	}
 }
 
 // ClearAll clears all data (used when switching networks).
 func (s *Store) ClearAll() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.ClearinghouseState = nil
-	s.AllMids = make(api.AllMids)
-	s.MetaAndAssetCtxs = nil
-	s.OpenOrders = nil
-	s.Fills = nil
-	s.FundingPayments = nil
-	s.Portfolio = nil
-	s.UserFees = nil
-	s.VaultEquities = nil
-	s.VaultDetails = make(map[string]*api.VaultDetails)
-	s.FundingRates = make(map[string]float64)
-}
-
-func (s *Store) UpdateMids(mids map[string]string) {
+	s.clearinghouseState = nil
+	s.pnlDrift = nil
+	s.book = nil
+	s.allMids = make(api.AllMids)
+	s.metaAndAssetCtxs = nil
+	s.market = nil
+	s.openOrders = nil
+	s.fills = nil
+	s.fundingPayments = nil
+	s.portfolio = nil
+	s.userFees = nil
+	s.vaultEquities = nil
+	s.vaultDetails = make(map[string]*api.VaultDetails)
+	s.vaultRevision++
+	s.managedVault = nil
+	s.userRateLimit = nil
+	s.vaultSummaries = nil
+	s.walletVaultEquities = make(map[string][]api.VaultEquity)
+	s.clearStaking()
+	s.validators = make(map[string]api.ValidatorSummary)
+	s.predictedFundings = nil
+	s.fundingHistory = make(map[string][]api.FundingHistoryEntry)
+	s.l2Books = make(map[string]*api.L2Book)
+	s.candles = make(map[string][]api.Candle)
+	s.midHistory = make(map[string]series)
+	s.ctxHistory = newCtxHistory()
+	s.fundingRates = make(map[string]float64)
+	s.sources = nil
+}
+
+func (s *Store) clearStaking() {
+	s.stakingSummary = nil
+	s.delegations = nil
+	s.delegatorRewards = nil
+	s.delegatorHistory = nil
+}
+
+// UpdateMids applies a tick of mids, leaving coins it doesn't mention as
+// they were.
+func (s *Store) UpdateMids(mids api.AllMids) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	for k, v := range mids {
-		s.AllMids[k] = v
+		s.allMids[k] = v
+	}
+	s.recordMids(mids)
+	if s.market != nil {
+		s.market = s.market.withMids(mids)
 	}
+	s.revalueBook()
+	s.mu.Unlock()
+
+	s.publish(MidsUpdated{Mids: maps.Clone(mids)})
 }
 
 func (s *Store) UpdateFundingRates() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.MetaAndAssetCtxs == nil {
+	if s.metaAndAssetCtxs == nil {
 		return
 	}
-	for i, asset := range s.MetaAndAssetCtxs.Meta.Universe {
-		if i < len(s.MetaAndAssetCtxs.AssetCtxs) {
-			s.FundingRates[asset.Name] = util.ParseFloat(s.MetaAndAssetCtxs.AssetCtxs[i].Funding)
+	for i, asset := range s.metaAndAssetCtxs.Meta.Universe {
+		if i < len(s.metaAndAssetCtxs.AssetCtxs) {
+			s.fundingRates[asset.Name] = s.metaAndAssetCtxs.AssetCtxs[i].Funding.Float64()
+		}
+	}
+}
+
+// FundingHistoryAverage returns the mean hourly funding rate for coin over the
+// window ending at now. ok is false when no history covers the window.
+func (s *Store) FundingHistoryAverage(coin string, window time.Duration, now time.Time) (rate float64, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.fundingHistoryAverage(coin, window, now)
+}
+
+// fundingHistoryAverage is FundingHistoryAverage for callers that hold the
+// lock.
+func (s *Store) fundingHistoryAverage(coin string, window time.Duration, now time.Time) (rate float64, ok bool) {
+	since := now.Add(-window).UnixMilli()
+	var sum float64
+	var n int
+	for _, e := range s.fundingHistory[coin] {
+		if e.Time < since || e.Time > now.UnixMilli() {
+			continue
 		}
+		sum += e.FundingRate.Float64()
+		n++
+	}
+	if n == 0 {
+		return 0, false
 	}
+	return sum / float64(n), true
 }
 
-// AccountValue returns the account value as float64.
-func (s *Store) AccountValue() float64 {
+// AccountValue returns the account value as of the last snapshot.
+func (s *Store) AccountValue() decimal.Decimal {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if s.ClearinghouseState == nil {
-		return 0
+	if s.clearinghouseState == nil {
+		return decimal.Zero
 	}
-	return util.ParseFloat(s.ClearinghouseState.MarginSummary.AccountValue)
+	return s.clearinghouseState.MarginSummary.AccountValue
 }
 
 // PositionsSorted returns a copy of positions sorted by unrealized PnL.
 // The returned slice and associated data (mids, funding rates) are snapshot copies
 // safe to use without holding the store lock.
-func (s *Store) PositionsSorted(ascending bool) ([]api.AssetPosition, map[string]string, map[string]float64) {
+func (s *Store) PositionsSorted(ascending bool) ([]api.AssetPosition, api.AllMids, map[string]float64) {
 	s.mu.RLock()
-	if s.ClearinghouseState == nil {
+	if s.clearinghouseState == nil {
 		s.mu.RUnlock()
 		return nil, nil, nil
 	}
-	positions := make([]api.AssetPosition, len(s.ClearinghouseState.AssetPositions))
-	copy(positions, s.ClearinghouseState.AssetPositions)
+	positions := make([]api.AssetPosition, len(s.clearinghouseState.AssetPositions))
+	copy(positions, s.clearinghouseState.AssetPositions)
 
 	// Copy mids and funding rates so caller doesn't need to hold lock
-	mids := make(map[string]string, len(s.AllMids))
-	for k, v := range s.AllMids {
+	mids := make(api.AllMids, len(s.allMids))
+	for k, v := range s.allMids {
 		mids[k] = v
 	}
-	fundingRates := make(map[string]float64, len(s.FundingRates))
-	for k, v := range s.FundingRates {
+	fundingRates := make(map[string]float64, len(s.fundingRates))
+	for k, v := range s.fundingRates {
 		fundingRates[k] = v
 	}
 	s.mu.RUnlock()
 
 	// Sort outside the lock
 	sort.Slice(positions, func(i, j int) bool {
-		pi := util.ParseFloat(positions[i].Position.UnrealizedPnl)
-		pj := util.ParseFloat(positions[j].Position.UnrealizedPnl)
+		c := positions[i].Position.UnrealizedPnl.Cmp(positions[j].Position.UnrealizedPnl)
 		if ascending {
-			return pi < pj
+			return c < 0
 		}
-		return pi > pj
+		return c > 0
 	})
 
 	return positions, mids, fundingRates
@@ -144,78 +267,137 @@ func (s *Store) PositionsSorted(ascending bool) ([]api.AssetPosition, map[string
 // ApplyOrderUpdates applies incremental order updates from the WebSocket.
 func (s *Store) ApplyOrderUpdates(updates []ws.OrderUpdate) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
+	var events []Event
 	for _, u := range updates {
 		switch u.Status {
 		case "open", "replaced", "triggered":
 			// Upsert: find by Oid or add new
+			order := api.OpenOrder{
+				Coin:       u.Order.Coin,
+				Side:       u.Order.Side,
+				LimitPx:    u.Order.LimitPx,
+				Sz:         u.Order.Sz,
+				Oid:        u.Order.Oid,
+				Timestamp:  u.Order.Timestamp,
+				OrigSz:     u.Order.OrigSz,
+				OrderType:  u.Order.OrderType,
+				ReduceOnly: u.Order.ReduceOnly,
+			}
 			found := false
-			for i, o := range s.OpenOrders {
+			for i, o := range s.openOrders {
 				if o.Oid == u.Order.Oid {
-					s.OpenOrders[i] = api.OpenOrder{
-						Coin:      u.Order.Coin,
-						Side:      u.Order.Side,
-						LimitPx:   u.Order.LimitPx,
-						Sz:        u.Order.Sz,
-						Oid:       u.Order.Oid,
-						Timestamp: u.Order.Timestamp,
-						OrigSz:    u.Order.OrigSz,
-						OrderType: u.Order.OrderType,
-						ReduceOnly: u.Order.ReduceOnly,
-					}
+					s.openOrders[i] = order
 					found = true
 					break
 				}
 			}
 			if !found {
-				s.OpenOrders = append(s.OpenOrders, api.OpenOrder{
-					Coin:      u.Order.Coin,
-					Side:      u.Order.Side,
-					LimitPx:   u.Order.LimitPx,
-					Sz:        u.Order.Sz,
-					Oid:       u.Order.Oid,
-					Timestamp: u.Order.Timestamp,
-					OrigSz:    u.Order.OrigSz,
-					OrderType: u.Order.OrderType,
-					ReduceOnly: u.Order.ReduceOnly,
-				})
+				s.openOrders = append(s.openOrders, order)
 			}
+			events = append(events, OrderChanged{Order: order, Open: true})
 		case "filled", "canceled", "marginCanceled", "liquidatedCanceled":
 			// Remove by Oid
-			for i, o := range s.OpenOrders {
+			for i, o := range s.openOrders {
 				if o.Oid == u.Order.Oid {
-					s.OpenOrders = append(s.OpenOrders[:i], s.OpenOrders[i+1:]...)
+					s.openOrders = append(s.openOrders[:i], s.openOrders[i+1:]...)
+					events = append(events, OrderChanged{Order: o})
 					break
 				}
 			}
 		}
 	}
+	s.mu.Unlock()
+
+	s.publish(events...)
 }
 
-func (s *Store) MidPrice(coin string) float64 {
+func (s *Store) MidPrice(coin string) decimal.Decimal {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if v, ok := s.AllMids[coin]; ok {
-		return util.ParseFloat(v)
-	}
-	return 0
+	return s.allMids[coin]
 }
 
 func (s *Store) FundingRate(coin string) float64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.FundingRates[coin]
+	return s.fundingRates[coin]
 }
 
 // GetPortfolioPeriod returns a specific period by name (e.g. "allTime", "day").
 func (s *Store) GetPortfolioPeriod(name string) *api.PortfolioPeriod {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	for i := range s.Portfolio {
-		if s.Portfolio[i].Name == name {
-			return &s.Portfolio[i]
+	for _, p := range s.portfolio {
+		if p.Name == name {
+			return &p
 		}
 	}
 	return nil
 }
+
+// VaultUnlock is one wallet's stake in a vault and when its lockup ends.
+type VaultUnlock struct {
+	Wallet       string // wallet address
+	VaultAddress string
+	Equity       decimal.Decimal
+	UnlockAt     int64 // ms; 0 if the stake was never locked
+}
+
+// VaultUnlocks returns every wallet's vault stakes ordered by unlock time.
+// The active wallet (activeAddr) uses the freshest VaultEquities rather than
+// the cross-wallet snapshot.
+func (s *Store) VaultUnlocks(activeAddr string) []VaultUnlock {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var unlocks []VaultUnlock
+	add := func(wallet string, equities []api.VaultEquity) {
+		for _, ve := range equities {
+			unlockAt := ve.LockedUntilTimestamp
+			// vaultDetails carries the active wallet's own lockup as a fallback
+			if unlockAt == 0 && wallet == activeAddr {
+				if d, ok := s.vaultDetails[ve.VaultAddress]; ok && d.FollowerState != nil {
+					unlockAt = d.FollowerState.LockupUntil
+				}
+			}
+			unlocks = append(unlocks, VaultUnlock{
+				Wallet:       wallet,
+				VaultAddress: ve.VaultAddress,
+				Equity:       ve.Equity,
+				UnlockAt:     unlockAt,
+			})
+		}
+	}
+
+	add(activeAddr, s.vaultEquities)
+	for wallet, equities := range s.walletVaultEquities {
+		if strings.EqualFold(wallet, activeAddr) {
+			continue
+		}
+		add(wallet, equities)
+	}
+
+	sort.SliceStable(unlocks, func(i, j int) bool {
+		if unlocks[i].UnlockAt != unlocks[j].UnlockAt {
+			return unlocks[i].UnlockAt < unlocks[j].UnlockAt
+		}
+		return unlocks[i].Wallet < unlocks[j].Wallet
+	})
+	return unlocks
+}
+
+// VaultName returns a display name for a vault from whatever details or
+// summaries are loaded, or "" if none are.
+func (s *Store) VaultName(addr string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if d, ok := s.vaultDetails[addr]; ok && d.Name != "" {
+		return d.Name
+	}
+	for _, vs := range s.vaultSummaries {
+		if vs.VaultAddress == addr {
+			return vs.Name
+		}
+	}
+	return ""
+}
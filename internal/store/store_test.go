@@ -1,53 +1,56 @@
 package store
 
 import (
+	"math"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
 )
 
 func TestNewStore(t *testing.T) {
 	s := New()
-	if s.AllMids == nil {
+	if s.allMids == nil {
 		t.Error("AllMids is nil")
 	}
-	if s.FundingRates == nil {
+	if s.fundingRates == nil {
 		t.Error("FundingRates is nil")
 	}
-	if s.VaultDetails == nil {
+	if s.vaultDetails == nil {
 		t.Error("VaultDetails is nil")
 	}
-	if s.ClearinghouseState != nil {
+	if s.clearinghouseState != nil {
 		t.Error("ClearinghouseState should be nil initially")
 	}
 }
 
 func TestUpdateMids(t *testing.T) {
 	s := New()
-	s.UpdateMids(map[string]string{
-		"BTC": "91000.00",
-		"ETH": "3400.00",
+	s.UpdateMids(api.AllMids{
+		"BTC": decimal.MustParse("91000.00"),
+		"ETH": decimal.MustParse("3400.00"),
 	})
 
-	if s.AllMids["BTC"] != "91000.00" {
-		t.Errorf("BTC = %q, want 91000.00", s.AllMids["BTC"])
+	if s.allMids["BTC"].String() != "91000.00" {
+		t.Errorf("BTC = %s, want 91000.00", s.allMids["BTC"])
 	}
 
 	// Update should merge, not replace
-	s.UpdateMids(map[string]string{
-		"BTC": "92000.00",
-		"SOL": "150.00",
+	s.UpdateMids(api.AllMids{
+		"BTC": decimal.MustParse("92000.00"),
+		"SOL": decimal.MustParse("150.00"),
 	})
 
-	if s.AllMids["BTC"] != "92000.00" {
-		t.Errorf("BTC = %q, want 92000.00", s.AllMids["BTC"])
+	if s.allMids["BTC"].String() != "92000.00" {
+		t.Errorf("BTC = %s, want 92000.00", s.allMids["BTC"])
 	}
-	if s.AllMids["ETH"] != "3400.00" {
-		t.Errorf("ETH = %q, want 3400.00 (should be preserved)", s.AllMids["ETH"])
+	if s.allMids["ETH"].String() != "3400.00" {
+		t.Errorf("ETH = %s, want 3400.00 (should be preserved)", s.allMids["ETH"])
 	}
-	if s.AllMids["SOL"] != "150.00" {
-		t.Errorf("SOL = %q, want 150.00", s.AllMids["SOL"])
+	if s.allMids["SOL"].String() != "150.00" {
+		t.Errorf("SOL = %s, want 150.00", s.allMids["SOL"])
 	}
 }
 
@@ -58,18 +61,18 @@ func TestUpdateMidsConcurrency(t *testing.T) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			s.UpdateMids(map[string]string{"BTC": "91000"})
+			s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("91000")})
 		}()
 	}
 	wg.Wait()
-	if s.AllMids["BTC"] != "91000" {
-		t.Errorf("BTC = %q after concurrent updates", s.AllMids["BTC"])
+	if s.allMids["BTC"].String() != "91000" {
+		t.Errorf("BTC = %q after concurrent updates", s.allMids["BTC"])
 	}
 }
 
 func TestUpdateFundingRates(t *testing.T) {
 	s := New()
-	s.MetaAndAssetCtxs = &api.MetaAndAssetCtxs{
+	s.metaAndAssetCtxs = &api.MetaAndAssetCtxs{
 		Meta: api.Meta{
 			Universe: []api.AssetMeta{
 				{Name: "BTC"},
@@ -77,18 +80,18 @@ func TestUpdateFundingRates(t *testing.T) {
 			},
 		},
 		AssetCtxs: []api.AssetCtx{
-			{Funding: "0.0001"},
-			{Funding: "-0.00005"},
+			{Funding: decimal.MustParse("0.0001")},
+			{Funding: decimal.MustParse("-0.00005")},
 		},
 	}
 
 	s.UpdateFundingRates()
 
-	if s.FundingRates["BTC"] != 0.0001 {
-		t.Errorf("BTC rate = %v, want 0.0001", s.FundingRates["BTC"])
+	if s.fundingRates["BTC"] != 0.0001 {
+		t.Errorf("BTC rate = %v, want 0.0001", s.fundingRates["BTC"])
 	}
-	if s.FundingRates["ETH"] != -0.00005 {
-		t.Errorf("ETH rate = %v, want -0.00005", s.FundingRates["ETH"])
+	if s.fundingRates["ETH"] != -0.00005 {
+		t.Errorf("ETH rate = %v, want -0.00005", s.fundingRates["ETH"])
 	}
 }
 
@@ -100,7 +103,7 @@ func TestUpdateFundingRatesNilMeta(t *testing.T) {
 
 func TestUpdateFundingRatesMismatchedLengths(t *testing.T) {
 	s := New()
-	s.MetaAndAssetCtxs = &api.MetaAndAssetCtxs{
+	s.metaAndAssetCtxs = &api.MetaAndAssetCtxs{
 		Meta: api.Meta{
 			Universe: []api.AssetMeta{
 				{Name: "BTC"},
@@ -109,16 +112,16 @@ func TestUpdateFundingRatesMismatchedLengths(t *testing.T) {
 			},
 		},
 		AssetCtxs: []api.AssetCtx{
-			{Funding: "0.0001"},
+			{Funding: decimal.MustParse("0.0001")},
 		},
 	}
 	// Should not panic with mismatched lengths
 	s.UpdateFundingRates()
-	if s.FundingRates["BTC"] != 0.0001 {
-		t.Errorf("BTC rate = %v, want 0.0001", s.FundingRates["BTC"])
+	if s.fundingRates["BTC"] != 0.0001 {
+		t.Errorf("BTC rate = %v, want 0.0001", s.fundingRates["BTC"])
 	}
 	// ETH and SOL should not have rates set
-	if _, ok := s.FundingRates["ETH"]; ok {
+	if _, ok := s.fundingRates["ETH"]; ok {
 		t.Error("ETH should not have a funding rate")
 	}
 }
@@ -127,30 +130,30 @@ func TestAccountValue(t *testing.T) {
 	s := New()
 
 	// Nil state
-	if v := s.AccountValue(); v != 0 {
+	if v := s.AccountValue(); !v.IsZero() {
 		t.Errorf("AccountValue() = %v, want 0 for nil state", v)
 	}
 
-	s.ClearinghouseState = &api.ClearinghouseState{
+	s.clearinghouseState = &api.ClearinghouseState{
 		MarginSummary: api.MarginSummary{
-			AccountValue: "314096.11",
+			AccountValue: decimal.MustParse("314096.11"),
 		},
 	}
-	if v := s.AccountValue(); v != 314096.11 {
+	if v := s.AccountValue(); v.String() != "314096.11" {
 		t.Errorf("AccountValue() = %v, want 314096.11", v)
 	}
 }
 
 func TestPositionsSorted(t *testing.T) {
 	s := New()
-	s.AllMids = api.AllMids{"BTC": "91000", "ETH": "3400"}
-	s.FundingRates = map[string]float64{"BTC": 0.0001, "ETH": -0.00005}
+	s.allMids = api.AllMids{"BTC": decimal.MustParse("91000"), "ETH": decimal.MustParse("3400")}
+	s.fundingRates = map[string]float64{"BTC": 0.0001, "ETH": -0.00005}
 
-	s.ClearinghouseState = &api.ClearinghouseState{
+	s.clearinghouseState = &api.ClearinghouseState{
 		AssetPositions: []api.AssetPosition{
-			{Position: api.Position{Coin: "BTC", UnrealizedPnl: "-148.25"}},
-			{Position: api.Position{Coin: "ETH", UnrealizedPnl: "500.00"}},
-			{Position: api.Position{Coin: "SOL", UnrealizedPnl: "100.00"}},
+			{Position: api.Position{Coin: "BTC", UnrealizedPnl: decimal.MustParse("-148.25")}},
+			{Position: api.Position{Coin: "ETH", UnrealizedPnl: decimal.MustParse("500.00")}},
+			{Position: api.Position{Coin: "SOL", UnrealizedPnl: decimal.MustParse("100.00")}},
 		},
 	}
 
@@ -172,7 +175,7 @@ func TestPositionsSorted(t *testing.T) {
 	}
 
 	// Verify mids are copied
-	if mids["BTC"] != "91000" {
+	if mids["BTC"].String() != "91000" {
 		t.Errorf("mids[BTC] = %q, want 91000", mids["BTC"])
 	}
 
@@ -198,36 +201,36 @@ func TestPositionsSortedNilState(t *testing.T) {
 
 func TestPositionsSortedDoesNotMutateOriginal(t *testing.T) {
 	s := New()
-	s.ClearinghouseState = &api.ClearinghouseState{
+	s.clearinghouseState = &api.ClearinghouseState{
 		AssetPositions: []api.AssetPosition{
-			{Position: api.Position{Coin: "BTC", UnrealizedPnl: "-100"}},
-			{Position: api.Position{Coin: "ETH", UnrealizedPnl: "200"}},
+			{Position: api.Position{Coin: "BTC", UnrealizedPnl: decimal.MustParse("-100")}},
+			{Position: api.Position{Coin: "ETH", UnrealizedPnl: decimal.MustParse("200")}},
 		},
 	}
 
 	_, _, _ = s.PositionsSorted(false)
 
 	// Original should be unchanged
-	if s.ClearinghouseState.AssetPositions[0].Position.Coin != "BTC" {
+	if s.clearinghouseState.AssetPositions[0].Position.Coin != "BTC" {
 		t.Error("original positions were mutated")
 	}
 }
 
 func TestMidPrice(t *testing.T) {
 	s := New()
-	s.AllMids["BTC"] = "91000.50"
+	s.allMids["BTC"] = decimal.MustParse("91000.50")
 
-	if v := s.MidPrice("BTC"); v != 91000.50 {
+	if v := s.MidPrice("BTC"); v.String() != "91000.50" {
 		t.Errorf("MidPrice(BTC) = %v, want 91000.50", v)
 	}
-	if v := s.MidPrice("NONEXISTENT"); v != 0 {
+	if v := s.MidPrice("NONEXISTENT"); !v.IsZero() {
 		t.Errorf("MidPrice(NONEXISTENT) = %v, want 0", v)
 	}
 }
 
 func TestFundingRate(t *testing.T) {
 	s := New()
-	s.FundingRates["BTC"] = 0.0001
+	s.fundingRates["BTC"] = 0.0001
 
 	if v := s.FundingRate("BTC"); v != 0.0001 {
 		t.Errorf("FundingRate(BTC) = %v, want 0.0001", v)
@@ -239,20 +242,96 @@ func TestFundingRate(t *testing.T) {
 
 func TestGetPortfolioPeriod(t *testing.T) {
 	s := New()
-	s.Portfolio = []api.PortfolioPeriod{
-		{Name: "day", Vlm: "1000"},
-		{Name: "allTime", Vlm: "50000"},
+	s.portfolio = []api.PortfolioPeriod{
+		{Name: "day", Vlm: decimal.MustParse("1000")},
+		{Name: "allTime", Vlm: decimal.MustParse("50000")},
 	}
 
 	p := s.GetPortfolioPeriod("allTime")
 	if p == nil {
 		t.Fatal("GetPortfolioPeriod(allTime) returned nil")
 	}
-	if p.Vlm != "50000" {
-		t.Errorf("Vlm = %q, want 50000", p.Vlm)
+	if p.Vlm.String() != "50000" {
+		t.Errorf("Vlm = %s, want 50000", p.Vlm)
 	}
 
 	if s.GetPortfolioPeriod("nonexistent") != nil {
 		t.Error("GetPortfolioPeriod(nonexistent) should return nil")
 	}
 }
+
+func TestVaultUnlocks(t *testing.T) {
+	s := New()
+	active := "0xaaaa"
+	s.vaultEquities = []api.VaultEquity{
+		{VaultAddress: "0xv1", Equity: decimal.MustParse("100"), LockedUntilTimestamp: 3000},
+		{VaultAddress: "0xv2", Equity: decimal.MustParse("50")},
+	}
+	s.vaultDetails["0xv2"] = &api.VaultDetails{
+		FollowerState: &api.FollowerState{LockupUntil: 2000},
+	}
+	s.walletVaultEquities = map[string][]api.VaultEquity{
+		// Stale snapshot of the active wallet must be ignored
+		"0xAAAA": {{VaultAddress: "0xv1", Equity: decimal.MustParse("1"), LockedUntilTimestamp: 1}},
+		"0xbbbb": {{VaultAddress: "0xv1", Equity: decimal.MustParse("25"), LockedUntilTimestamp: 1000}},
+	}
+
+	unlocks := s.VaultUnlocks(active)
+	if len(unlocks) != 3 {
+		t.Fatalf("len = %d, want 3", len(unlocks))
+	}
+	want := []struct {
+		wallet, vault string
+		at            int64
+		equity        string
+	}{
+		{"0xbbbb", "0xv1", 1000, "25"},
+		{active, "0xv2", 2000, "50"},
+		{active, "0xv1", 3000, "100"},
+	}
+	for i, w := range want {
+		u := unlocks[i]
+		if u.Wallet != w.wallet || u.VaultAddress != w.vault || u.UnlockAt != w.at || u.Equity.String() != w.equity {
+			t.Errorf("unlocks[%d] = %+v, want %+v", i, u, w)
+		}
+	}
+}
+
+func TestVaultName(t *testing.T) {
+	s := New()
+	s.vaultSummaries = []api.VaultSummary{{Name: "From Summary", VaultAddress: "0xv1"}}
+	s.vaultDetails["0xv2"] = &api.VaultDetails{Name: "From Details"}
+
+	if got := s.VaultName("0xv1"); got != "From Summary" {
+		t.Errorf("VaultName(0xv1) = %q", got)
+	}
+	if got := s.VaultName("0xv2"); got != "From Details" {
+		t.Errorf("VaultName(0xv2) = %q", got)
+	}
+	if got := s.VaultName("0xv3"); got != "" {
+		t.Errorf("VaultName(0xv3) = %q, want empty", got)
+	}
+}
+
+func TestFundingHistoryAverage(t *testing.T) {
+	s := New()
+	now := time.UnixMilli(1_770_000_000_000)
+	hour := time.Hour.Milliseconds()
+	s.fundingHistory["BTC"] = []api.FundingHistoryEntry{
+		{Coin: "BTC", FundingRate: decimal.MustParse("0.0004"), Time: now.UnixMilli() - 48*hour},
+		{Coin: "BTC", FundingRate: decimal.MustParse("0.0001"), Time: now.UnixMilli() - 2*hour},
+		{Coin: "BTC", FundingRate: decimal.MustParse("0.0003"), Time: now.UnixMilli() - 1*hour},
+	}
+
+	got, ok := s.FundingHistoryAverage("BTC", 24*time.Hour, now)
+	if !ok || math.Abs(got-0.0002) > 1e-12 {
+		t.Errorf("24h avg = %v, %v; want 0.0002, true", got, ok)
+	}
+	got, ok = s.FundingHistoryAverage("BTC", 7*24*time.Hour, now)
+	if !ok || math.Abs(got-0.0008/3) > 1e-12 {
+		t.Errorf("7d avg = %v, %v; want %v, true", got, ok, 0.0008/3)
+	}
+	if _, ok := s.FundingHistoryAverage("ETH", 24*time.Hour, now); ok {
+		t.Error("ETH avg should not be ok without history")
+	}
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+// ApplyWebData2 applies a webData2 snapshot: clearinghouse state, open
+// orders and, when the universe and contexts line up, asset contexts.
+// Sections missing from the message are left as they are.
+func (s *Store) ApplyWebData2(d ws.WebData2) {
+	s.mu.Lock()
+	ctxs := d.Meta != nil && len(d.AssetCtxs) > 0 && len(d.AssetCtxs) == len(d.Meta.Universe)
+	if ctxs {
+		s.setMetaAndAssetCtxs(&api.MetaAndAssetCtxs{Meta: *d.Meta, AssetCtxs: d.AssetCtxs})
+	}
+	// After the marks it is reconciled against
+	if d.ClearinghouseState != nil {
+		s.setClearinghouseState(d.ClearinghouseState)
+	} else if ctxs {
+		s.revalueBook()
+	}
+	var events []Event
+	if ctxs {
+		events = append(events, ContextsUpdated{})
+	}
+	if d.ClearinghouseState != nil {
+		events = append(events, PositionsChanged{})
+	}
+	if d.OpenOrders != nil {
+		events = append(events, orderEvents(s.openOrders, d.OpenOrders)...)
+		s.openOrders = d.OpenOrders
+	}
+	s.mu.Unlock()
+
+	if ctxs {
+		s.UpdateFundingRates()
+	}
+	s.publish(events...)
+}
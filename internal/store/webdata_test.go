@@ -0,0 +1,48 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+func TestApplyWebData2(t *testing.T) {
+	s := New()
+	s.openOrders = []api.OpenOrder{{Oid: 1}}
+	s.metaAndAssetCtxs = &api.MetaAndAssetCtxs{
+		Meta:      api.Meta{Universe: []api.AssetMeta{{Name: "BTC"}}},
+		AssetCtxs: []api.AssetCtx{{Funding: decimal.MustParse("0.0001")}},
+	}
+	s.UpdateFundingRates()
+
+	state := &api.ClearinghouseState{Withdrawable: decimal.MustParse("42")}
+	s.ApplyWebData2(ws.WebData2{
+		ClearinghouseState: state,
+		Meta:               &api.Meta{Universe: []api.AssetMeta{{Name: "BTC"}, {Name: "ETH"}}},
+		AssetCtxs:          []api.AssetCtx{{Funding: decimal.MustParse("0.0002")}, {Funding: decimal.MustParse("-0.0001")}},
+	})
+	if s.clearinghouseState != state {
+		t.Error("clearinghouse state not applied")
+	}
+	if len(s.openOrders) != 1 {
+		t.Error("open orders cleared by a message without them")
+	}
+	if s.FundingRate("ETH") != -0.0001 || s.FundingRate("BTC") != 0.0002 {
+		t.Errorf("funding rates = %v", s.fundingRates)
+	}
+
+	// A universe that doesn't match its contexts is ignored
+	s.ApplyWebData2(ws.WebData2{
+		OpenOrders: []api.OpenOrder{},
+		Meta:       &api.Meta{Universe: []api.AssetMeta{{Name: "BTC"}}},
+		AssetCtxs:  []api.AssetCtx{{Funding: decimal.MustParse("1")}, {Funding: decimal.MustParse("1")}},
+	})
+	if len(s.metaAndAssetCtxs.AssetCtxs) != 2 || s.FundingRate("BTC") != 0.0002 {
+		t.Error("mismatched contexts applied")
+	}
+	if len(s.openOrders) != 0 {
+		t.Error("empty open orders not applied")
+	}
+}
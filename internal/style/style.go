@@ -48,6 +48,12 @@ var (
 
 	TableHeader = Dim.Underline(true)
 
+	// Header cell under a table's column cursor
+	TableHeaderCursor = Cyan.Underline(true)
+
+	// Table row under a view's row cursor
+	Selected = lipgloss.NewStyle().Reverse(true)
+
 	Border = lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(lipgloss.Color("62"))
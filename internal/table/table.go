@@ -0,0 +1,335 @@
+// Package table is the sortable table shared by the list views. A Table
+// lays rows out in fixed-width columns and sorts them by one or more
+// columns picked with a column cursor:
+//
+//	< / >  move the column cursor
+//	s      sort by the column under the cursor, or reverse it
+//	S      add the column under the cursor as a tie-breaker, or reverse it
+//
+// Each change is reported with a SortChangedMsg so the app can save it.
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column is one column of a Table over rows of type R.
+type Column[R any] struct {
+	Key   string // stable name saved in sort preferences
+	Title string
+	Width int
+	Left  bool // left-aligned; columns are right-aligned otherwise
+
+	// Cell is the unpadded text; Style, if set, colors the padded cell
+	Cell  func(R) string
+	Style func(R) lipgloss.Style
+
+	// Cmp orders two rows ascending; nil leaves the column unsortable.
+	// Missing, if set, marks rows without a value, which sort last in
+	// either direction.
+	Cmp     func(a, b R) int
+	Missing func(R) bool
+
+	// Desc makes descending the first direction a sort picks, for columns
+	// where the biggest values matter most
+	Desc bool
+}
+
+// By returns a Cmp ordering rows by f.
+func By[R any, V cmp.Ordered](f func(R) V) func(a, b R) int {
+	return func(a, b R) int { return cmp.Compare(f(a), f(b)) }
+}
+
+// ByDecimal returns a Cmp ordering rows by the decimal f.
+func ByDecimal[R any](f func(R) decimal.Decimal) func(a, b R) int {
+	return func(a, b R) int { return f(a).Cmp(f(b)) }
+}
+
+// SortChangedMsg reports a table's new sort order.
+type SortChangedMsg struct {
+	Table string
+	Keys  []config.SortKey
+}
+
+// Table is a value like the view models holding it; every change returns
+// a new Table and never modifies slices an earlier copy shares.
+type Table[R any] struct {
+	name     string
+	cols     []Column[R]
+	gap      string
+	defaults []config.SortKey
+	keys     []config.SortKey
+	cursor   int
+}
+
+// New returns a table named name (its key in the saved preferences) with
+// gap spaces between columns, sorted by defaults until the user picks
+// another order.
+func New[R any](name string, gap int, cols []Column[R], defaults ...config.SortKey) Table[R] {
+	t := Table[R]{
+		name:     name,
+		cols:     cols,
+		gap:      strings.Repeat(" ", gap),
+		defaults: defaults,
+	}
+	return t.withKeys(defaults)
+}
+
+// Name is the table's key in the saved preferences.
+func (t Table[R]) Name() string {
+	return t.name
+}
+
+// Keys returns the sort order, primary key first.
+func (t Table[R]) Keys() []config.SortKey {
+	return slices.Clone(t.keys)
+}
+
+// Restore applies the table's saved order from prefs. Keys naming columns
+// that no longer exist or can't be sorted are dropped; if none are left the
+// default order stays.
+func (t Table[R]) Restore(prefs config.SortPrefs) Table[R] {
+	var keys []config.SortKey
+	for _, k := range prefs[t.name] {
+		if i := t.column(k.Column); i >= 0 && t.cols[i].Cmp != nil && !hasKey(keys, k.Column) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		keys = t.defaults
+	}
+	return t.withKeys(keys)
+}
+
+func hasKey(keys []config.SortKey, col string) bool {
+	return slices.ContainsFunc(keys, func(k config.SortKey) bool { return k.Column == col })
+}
+
+// withKeys replaces the sort order and puts the cursor on its primary
+// column.
+func (t Table[R]) withKeys(keys []config.SortKey) Table[R] {
+	t.keys = slices.Clone(keys)
+	if len(t.keys) > 0 {
+		if i := t.column(t.keys[0].Column); i >= 0 {
+			t.cursor = i
+		}
+	}
+	return t
+}
+
+func (t Table[R]) column(key string) int {
+	return slices.IndexFunc(t.cols, func(c Column[R]) bool { return c.Key == key })
+}
+
+func (t Table[R]) keyIndex(col string) int {
+	return slices.IndexFunc(t.keys, func(k config.SortKey) bool { return k.Column == col })
+}
+
+// Update handles the column cursor and sort keys. The returned command,
+// non-nil only when the order changed, delivers a SortChangedMsg.
+func (t Table[R]) Update(msg tea.KeyMsg) (Table[R], tea.Cmd) {
+	switch msg.String() {
+	case "<":
+		t.cursor = t.nextSortable(-1)
+		return t, nil
+	case ">":
+		t.cursor = t.nextSortable(1)
+		return t, nil
+	case "s":
+		col := t.cols[t.cursor]
+		if col.Cmp == nil {
+			return t, nil
+		}
+		keys := []config.SortKey{{Column: col.Key, Desc: col.Desc}}
+		if len(t.keys) > 0 && t.keys[0].Column == col.Key {
+			keys = slices.Clone(t.keys)
+			keys[0].Desc = !keys[0].Desc
+		}
+		t.keys = keys
+	case "S":
+		col := t.cols[t.cursor]
+		if col.Cmp == nil {
+			return t, nil
+		}
+		keys := slices.Clone(t.keys)
+		if i := t.keyIndex(col.Key); i >= 0 {
+			keys[i].Desc = !keys[i].Desc
+		} else {
+			keys = append(keys, config.SortKey{Column: col.Key, Desc: col.Desc})
+		}
+		t.keys = keys
+	default:
+		return t, nil
+	}
+	changed := SortChangedMsg{Table: t.name, Keys: t.Keys()}
+	return t, func() tea.Msg { return changed }
+}
+
+// nextSortable returns the next sortable column from the cursor in the
+// given direction, wrapping around, or the cursor if there is none.
+func (t Table[R]) nextSortable(step int) int {
+	n := len(t.cols)
+	for i, c := 1, t.cursor; i < n; i++ {
+		c = (c + step + n) % n
+		if t.cols[c].Cmp != nil {
+			return c
+		}
+	}
+	return t.cursor
+}
+
+// Sort orders rows by the sort keys. It is stable, so rows equal on every
+// key keep the order they came in.
+func (t Table[R]) Sort(rows []R) {
+	if len(t.keys) == 0 {
+		return
+	}
+	type sortCol struct {
+		col  Column[R]
+		desc bool
+	}
+	var cols []sortCol
+	for _, k := range t.keys {
+		if i := t.column(k.Column); i >= 0 && t.cols[i].Cmp != nil {
+			cols = append(cols, sortCol{t.cols[i], k.Desc})
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b R) int {
+		for _, sc := range cols {
+			if sc.col.Missing != nil {
+				ma, mb := sc.col.Missing(a), sc.col.Missing(b)
+				if ma != mb {
+					if ma {
+						return 1
+					}
+					return -1
+				}
+				if ma {
+					continue
+				}
+			}
+			c := sc.col.Cmp(a, b)
+			if sc.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// Header renders the column titles, marking the sort keys with their
+// direction (and rank, when there are several) and the cursor's column.
+func (t Table[R]) Header() string {
+	cells := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		title := c.Title
+		if k := t.keyIndex(c.Key); k >= 0 {
+			title += " " + arrow(t.keys[k].Desc)
+			if len(t.keys) > 1 {
+				title += fmt.Sprint(k + 1)
+			}
+		}
+		hs := style.TableHeader
+		if i == t.cursor {
+			hs = style.TableHeaderCursor
+		}
+		cells[i] = hs.Render(pad(title, c.Width, c.Left))
+	}
+	return strings.Join(cells, style.TableHeader.Render(t.gap))
+}
+
+func arrow(desc bool) string {
+	if desc {
+		return "▼"
+	}
+	return "▲"
+}
+
+// Row renders one row.
+func (t Table[R]) Row(r R) string {
+	cells := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		cell := pad(c.Cell(r), c.Width, c.Left)
+		if c.Style != nil {
+			cell = c.Style(r).Render(cell)
+		}
+		cells[i] = cell
+	}
+	return strings.Join(cells, t.gap)
+}
+
+// SelectedRow renders the row under a view's cursor, highlighted across
+// its full width.
+func (t Table[R]) SelectedRow(r R) string {
+	cells := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		s := style.Selected
+		if c.Style != nil {
+			s = c.Style(r).Inherit(style.Selected)
+		}
+		cells[i] = s.Render(pad(c.Cell(r), c.Width, c.Left))
+	}
+	return strings.Join(cells, style.Selected.Render(t.gap))
+}
+
+// Window clamps a view's row cursor to n rows and returns it with the
+// range of rows to show in visible lines, scrolled to keep the cursor on
+// screen. visible < 1 shows every row.
+func Window(cursor, n, visible int) (c, start, end int) {
+	if n == 0 {
+		return 0, 0, 0
+	}
+	c = min(max(cursor, 0), n-1)
+	if visible < 1 {
+		visible = n
+	}
+	if c >= visible {
+		start = c - visible + 1
+	}
+	return c, start, min(start+visible, n)
+}
+
+// Width is the rendered width of a row.
+func (t Table[R]) Width() int {
+	w := len(t.gap) * max(len(t.cols)-1, 0)
+	for _, c := range t.cols {
+		w += c.Width
+	}
+	return w
+}
+
+// SortLabel describes the sort order for footers, e.g. "PNL ▼, COIN ▲".
+func (t Table[R]) SortLabel() string {
+	parts := make([]string, 0, len(t.keys))
+	for _, k := range t.keys {
+		if i := t.column(k.Column); i >= 0 {
+			parts = append(parts, t.cols[i].Title+" "+arrow(k.Desc))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pad fills s with spaces to width display cells. Text already as wide is
+// returned as is.
+func pad(s string, width int, left bool) string {
+	n := width - lipgloss.Width(s)
+	if n <= 0 {
+		return s
+	}
+	if left {
+		return s + strings.Repeat(" ", n)
+	}
+	return strings.Repeat(" ", n) + s
+}
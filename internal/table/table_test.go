@@ -0,0 +1,239 @@
+package table
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type item struct {
+	name  string
+	size  int
+	score float64 // 0 means unknown
+}
+
+var testColumns = []Column[item]{
+	{
+		Key: "name", Title: "NAME", Width: 6, Left: true,
+		Cell: func(i item) string { return i.name },
+		Cmp:  By(func(i item) string { return i.name }),
+	},
+	{
+		Key: "note", Title: "NOTE", Width: 4,
+		Cell: func(item) string { return "" },
+	},
+	{
+		Key: "size", Title: "SIZE", Width: 6, Desc: true,
+		Cell: func(i item) string { return strings.Repeat("#", i.size) },
+		Cmp:  By(func(i item) int { return i.size }),
+	},
+	{
+		Key: "score", Title: "SCORE", Width: 8, Desc: true,
+		Cell:    func(item) string { return "" },
+		Cmp:     By(func(i item) float64 { return i.score }),
+		Missing: func(i item) bool { return i.score == 0 },
+	},
+}
+
+func testItems() []item {
+	return []item{
+		{"b", 2, 0},
+		{"a", 1, 5},
+		{"d", 2, 3},
+		{"c", 1, 0},
+	}
+}
+
+func names(items []item) string {
+	var b strings.Builder
+	for _, i := range items {
+		b.WriteString(i.name)
+	}
+	return b.String()
+}
+
+func key(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// press sends keys to t, returning the table and the last sort change.
+func press(t Table[item], keys ...string) (Table[item], *SortChangedMsg) {
+	var changed *SortChangedMsg
+	for _, k := range keys {
+		var cmd tea.Cmd
+		t, cmd = t.Update(key(k))
+		if cmd != nil {
+			msg := cmd().(SortChangedMsg)
+			changed = &msg
+		}
+	}
+	return t, changed
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []config.SortKey
+		want string
+	}{
+		{"none keeps input order", nil, "badc"},
+		{"single key", []config.SortKey{{Column: "name"}}, "abcd"},
+		{"descending", []config.SortKey{{Column: "name", Desc: true}}, "dcba"},
+		{"stable on ties", []config.SortKey{{Column: "size"}}, "acbd"},
+		{"tie-breaker", []config.SortKey{{Column: "size", Desc: true}, {Column: "name", Desc: true}}, "dbca"},
+		{"missing last ascending", []config.SortKey{{Column: "score"}}, "dabc"},
+		{"missing last descending", []config.SortKey{{Column: "score", Desc: true}}, "adbc"},
+		{"missing rows fall through to the next key", []config.SortKey{{Column: "score"}, {Column: "name"}}, "dabc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := New("test", 1, testColumns, tt.keys...)
+			items := testItems()
+			tbl.Sort(items)
+			if got := names(items); got != tt.want {
+				t.Errorf("order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tbl := New("test", 1, testColumns, config.SortKey{Column: "name"})
+
+	// The cursor starts on the primary key and skips unsortable columns
+	tbl, changed := press(tbl, ">")
+	if changed != nil {
+		t.Fatal("moving the cursor reported a sort change")
+	}
+	tbl, changed = press(tbl, "s")
+	if changed == nil || changed.Table != "test" {
+		t.Fatalf("sort change = %+v", changed)
+	}
+	want := []config.SortKey{{Column: "size", Desc: true}}
+	if !slices.Equal(changed.Keys, want) {
+		t.Errorf("keys after s = %+v, want %+v", changed.Keys, want)
+	}
+
+	// s again reverses; S on another column adds a tie-breaker, and again
+	// reverses it
+	tbl, _ = press(tbl, "s", "<", "S", "S")
+	want = []config.SortKey{{Column: "size"}, {Column: "name", Desc: true}}
+	if got := tbl.Keys(); !slices.Equal(got, want) {
+		t.Errorf("keys = %+v, want %+v", got, want)
+	}
+
+	// s on a tie-breaker makes it the only key, in its first direction
+	tbl, _ = press(tbl, "s")
+	want = []config.SortKey{{Column: "name"}}
+	if got := tbl.Keys(); !slices.Equal(got, want) {
+		t.Errorf("keys = %+v, want %+v", got, want)
+	}
+
+	// The cursor wraps around
+	tbl, _ = press(tbl, "<", "s")
+	if got := tbl.Keys(); got[0].Column != "score" {
+		t.Errorf("keys after wrapping left = %+v", got)
+	}
+
+	if _, changed := press(tbl, "x"); changed != nil {
+		t.Error("an unrelated key reported a sort change")
+	}
+}
+
+func TestUpdateLeavesCopiesAlone(t *testing.T) {
+	tbl := New("test", 1, testColumns, config.SortKey{Column: "name"}, config.SortKey{Column: "size"})
+	next, _ := press(tbl, "s")
+	if slices.Equal(tbl.Keys(), next.Keys()) {
+		t.Fatal("keys did not change")
+	}
+	want := []config.SortKey{{Column: "name"}, {Column: "size"}}
+	if got := tbl.Keys(); !slices.Equal(got, want) {
+		t.Errorf("original keys = %+v, want %+v", got, want)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tbl := New("test", 1, testColumns, config.SortKey{Column: "name"})
+
+	tests := []struct {
+		name  string
+		prefs config.SortPrefs
+		want  []config.SortKey
+	}{
+		{"nothing saved", nil, []config.SortKey{{Column: "name"}}},
+		{"other table", config.SortPrefs{"other": {{Column: "size"}}}, []config.SortKey{{Column: "name"}}},
+		{
+			"saved order",
+			config.SortPrefs{"test": {{Column: "score", Desc: true}, {Column: "size"}}},
+			[]config.SortKey{{Column: "score", Desc: true}, {Column: "size"}},
+		},
+		{
+			"unknown, unsortable and repeated columns dropped",
+			config.SortPrefs{"test": {{Column: "gone"}, {Column: "note"}, {Column: "size"}, {Column: "size", Desc: true}}},
+			[]config.SortKey{{Column: "size"}},
+		},
+		{"nothing usable", config.SortPrefs{"test": {{Column: "gone"}}}, []config.SortKey{{Column: "name"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tbl.Restore(tt.prefs).Keys(); !slices.Equal(got, tt.want) {
+				t.Errorf("keys = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// The cursor follows the restored primary key
+	restored := tbl.Restore(config.SortPrefs{"test": {{Column: "score"}}})
+	if restored, _ = press(restored, "s"); restored.Keys()[0] != (config.SortKey{Column: "score", Desc: true}) {
+		t.Errorf("cursor not on the restored key: keys = %+v", restored.Keys())
+	}
+}
+
+func TestRender(t *testing.T) {
+	tbl := New("test", 1, testColumns, config.SortKey{Column: "size", Desc: true}, config.SortKey{Column: "name"})
+
+	if got, want := tbl.Header(), "NAME ▲2 NOTE SIZE ▼1    SCORE"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+	if got, want := tbl.Row(item{"ab", 3, 1}), "ab             ###         "; got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+	if got := tbl.Width(); got != len(tbl.Row(item{})) {
+		t.Errorf("width = %d, rows are %d wide", got, len(tbl.Row(item{})))
+	}
+	if got, want := tbl.SortLabel(), "SIZE ▼, NAME ▲"; got != want {
+		t.Errorf("sort label = %q, want %q", got, want)
+	}
+	// Tests render without color, so the highlight leaves only the layout
+	if got, want := tbl.SelectedRow(item{"ab", 3, 1}), tbl.Row(item{"ab", 3, 1}); got != want {
+		t.Errorf("selected row = %q, want %q", got, want)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name                   string
+		cursor, n, visible     int
+		wantCursor, start, end int
+	}{
+		{"top", 0, 10, 4, 0, 0, 4},
+		{"last visible line", 3, 10, 4, 3, 0, 4},
+		{"scrolled to the cursor", 6, 10, 4, 6, 3, 7},
+		{"cursor past the end", 12, 10, 4, 9, 6, 10},
+		{"negative cursor", -1, 10, 4, 0, 0, 4},
+		{"fewer rows than lines", 2, 3, 4, 2, 0, 3},
+		{"no room shows everything", 5, 10, 0, 5, 0, 10},
+		{"no rows", 3, 0, 4, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, start, end := Window(tt.cursor, tt.n, tt.visible)
+			if c != tt.wantCursor || start != tt.start || end != tt.end {
+				t.Errorf("Window = %d, %d, %d; want %d, %d, %d", c, start, end, tt.wantCursor, tt.start, tt.end)
+			}
+		})
+	}
+}
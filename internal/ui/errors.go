@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// RenderLoadError stands in for a view's "nothing here" message when its
+// data failed to load, so an empty list and a failed fetch look different.
+func RenderLoadError(what string, err error) string {
+	return style.Red.Render("  ⚠ Couldn't load " + what + ": " + err.Error())
+}
+
+// maxPanelFailures is how many recent failures the errors panel lists.
+const maxPanelFailures = 12
+
+// RenderErrorsPanel lists every data source's fetch health and the most
+// recent failures with their causes.
+func RenderErrorsPanel(sources map[string]store.SourceStatus, failures []store.FetchFailure, width, height int) string {
+	boxWidth := min(max(width-4, 40), 110)
+	inner := boxWidth - 4 // less padding
+
+	lines := []string{style.White.Render("Data Source Errors"), ""}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lines = append(lines, style.TableHeader.Render(fmt.Sprintf("%-18s %-14s %s", "SOURCE", "STATUS", "LAST OK")))
+	if len(names) == 0 {
+		lines = append(lines, style.Dim.Render("  Nothing fetched yet"))
+	}
+	for _, name := range names {
+		st := sources[name]
+		status := style.Green.Render(fmt.Sprintf("%-14s", "ok"))
+		if st.Failing() {
+			status = style.Red.Render(fmt.Sprintf("%-14s", fmt.Sprintf("✗ %d failed", st.Failures)))
+		}
+		lastOK := style.Dim.Render("never")
+		if !st.LastSuccess.IsZero() {
+			lastOK = style.Dim.Render(util.FormatAge(util.Now().Sub(st.LastSuccess)) + " ago")
+		}
+		lines = append(lines, fmt.Sprintf("%-18s ", name)+status+" "+lastOK)
+	}
+
+	lines = append(lines, "", style.Cyan.Render("Recent failures"))
+	if len(failures) == 0 {
+		lines = append(lines, style.Dim.Render("  None this session"))
+	}
+	for i := len(failures) - 1; i >= 0 && i >= len(failures)-maxPanelFailures; i-- {
+		f := failures[i]
+		prefix := f.Time.Format("15:04:05") + "  " + fmt.Sprintf("%-18s", f.Source) + " "
+		cause := strings.ReplaceAll(f.Err.Error(), "\n", " ")
+		cause = runewidth.Truncate(cause, max(inner-runewidth.StringWidth(prefix), 10), "…")
+		lines = append(lines, style.Dim.Render(prefix)+style.Red.Render(cause))
+	}
+
+	lines = append(lines, "", style.Dim.Render("Press ! or Esc to close"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(boxWidth)
+
+	return lipgloss.Place(width, height,
+		lipgloss.Center, lipgloss.Center,
+		box.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+	)
+}
@@ -7,29 +7,27 @@ import (
 	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
 	"github.com/charmbracelet/lipgloss"
 )
 
 func RenderHeader(s *store.Store, width int) string {
-	s.RLock()
-	defer s.RUnlock()
-
-	if s.ClearinghouseState == nil {
+	acct, ok := s.LiveAccount()
+	if !ok {
 		return style.Dim.Render("Loading account data...")
 	}
 
-	ms := s.ClearinghouseState.MarginSummary
-	acctVal := util.ParseFloat(ms.AccountValue)
-	posVal := util.ParseFloat(ms.TotalNtlPos)
-	marginUsed := util.ParseFloat(ms.TotalMarginUsed)
-	maintMargin := util.ParseFloat(s.ClearinghouseState.CrossMaintenanceMarginUsed)
-	withdrawable := util.ParseFloat(s.ClearinghouseState.Withdrawable)
+	// Revalued at live prices between clearinghouse snapshots
+	acctVal := acct.AccountValue
+	posVal := acct.TotalNtlPos
+	marginUsed := acct.MarginUsed
+	maintMargin := acct.MaintMargin
+	withdrawable := acct.Withdrawable
+	marginRatio := acct.MarginRatio
 
 	leverage := 0.0
-	marginRatio := 0.0
-	if acctVal > 0 {
-		leverage = posVal / acctVal
-		marginRatio = (maintMargin / acctVal) * 100
+	if acctVal.Sign() > 0 {
+		leverage = posVal.Float64() / acctVal.Float64()
 	}
 
 	line1 := fmt.Sprintf("Acct: %s  Pos: %s  Margin: %s  Lev: %s",
@@ -56,7 +54,7 @@ func RenderHeader(s *store.Store, width int) string {
 	return lipgloss.NewStyle().Width(width).Render(content)
 }
 
-func RenderTitleBar(width int, isTestnet, wsConnected bool, walletName, truncAddr string) string {
+func RenderTitleBar(width int, isTestnet, isVault bool, wsState ws.State, walletName, truncAddr string) string {
 	title := style.White.Render("HLTUI v0.1.0")
 	if walletName != "" {
 		title += "  " + style.Cyan.Render(walletName) + " " + style.Dim.Render(truncAddr)
@@ -68,11 +66,18 @@ func RenderTitleBar(width int, isTestnet, wsConnected bool, walletName, truncAdd
 	} else {
 		indicators = append(indicators, style.Green.Render("[MAINNET]"))
 	}
+	if isVault {
+		indicators = append(indicators, style.Magenta.Render("[VAULT]"))
+	}
 
-	if wsConnected {
-		indicators = append(indicators, style.StatusConnected.Render("[WS: CONNECTED]"))
-	} else {
-		indicators = append(indicators, style.StatusDisconnected.Render("[WS: DISCONNECTED]"))
+	wsLabel := "[WS: " + wsState.String() + "]"
+	switch wsState {
+	case ws.StateConnected:
+		indicators = append(indicators, style.StatusConnected.Render(wsLabel))
+	case ws.StateConnecting, ws.StateReconnecting:
+		indicators = append(indicators, style.Yellow.Render(wsLabel))
+	default:
+		indicators = append(indicators, style.StatusDisconnected.Render(wsLabel))
 	}
 
 	right := strings.Join(indicators, " ")
@@ -14,14 +14,30 @@ func RenderHelp(width, height int) string {
 		style.Cyan.Render("Navigation"),
 		"  " + style.Yellow.Render("Tab / Shift+Tab") + "  Cycle views",
 		"  " + style.Yellow.Render("←/→ or h/l") + "       Switch views",
-		"  " + style.Yellow.Render("0-6") + "              Jump to view",
+		"  " + style.Yellow.Render("0-8") + "              Jump to view",
 		"  " + style.Yellow.Render("j/k or ↑/↓") + "      Scroll up/down",
 		"",
+		style.Cyan.Render("Tables"),
+		"  " + style.Yellow.Render("< / >") + "  Move the column cursor",
+		"  " + style.Yellow.Render("s") + "      Sort by the column / reverse",
+		"  " + style.Yellow.Render("S") + "      Then by the column / reverse",
+		"  " + style.Yellow.Render("/") + "      Filter, e.g. side:short pnl<0",
+		"",
 		style.Cyan.Render("Actions"),
-		"  " + style.Yellow.Render("s") + "  Toggle sort direction",
-		"  " + style.Yellow.Render("f") + "  Cycle OI filter (Market view)",
+		"  " + style.Yellow.Render("⏎") + "  Asset detail (Esc to close)",
+		"  " + style.Yellow.Render("f") + "  Cycle OI filter / vault TVL filter",
+		"  " + style.Yellow.Render("*") + "  Star / unstar a market",
+		"  " + style.Yellow.Render("c") + "  Watchlist columns",
+		"  " + style.Yellow.Render("v") + "  Market movers ([ ] window)",
+		"  " + style.Yellow.Render("e") + "  Toggle vault explorer",
+		"  " + style.Yellow.Render("u") + "  Vault unlock timeline",
+		"  " + style.Yellow.Render("a") + "  Toggle lockup / scanner alerts",
+		"  " + style.Yellow.Render("t") + "  Rewards / delegation history",
+		"  " + style.Yellow.Render("d") + "  Staking: delegations / history table",
+		"  " + style.Yellow.Render("m") + "  Watchlist / funding rates / carry",
 		"  " + style.Yellow.Render("w") + "  Switch wallet / add / delete",
 		"  " + style.Yellow.Render("r") + "  Refresh all data",
+		"  " + style.Yellow.Render("!") + "  Data source errors",
 		"  " + style.Yellow.Render(";") + "  Toggle this help",
 		"  " + style.Yellow.Render("q") + "  Quit",
 		"",
@@ -33,6 +49,9 @@ func RenderHelp(width, height int) string {
 		"  " + style.White.Render("4: Funding") + "     Funding rates & payments",
 		"  " + style.White.Render("5: Portfolio") + "   Performance & fees",
 		"  " + style.White.Render("6: Vaults") + "      Vault investments",
+		"               " + style.Dim.Render("(Vault Manager with -V)"),
+		"  " + style.White.Render("7: Staking") + "     HYPE delegations & rewards",
+		"  " + style.White.Render("8: Scanner") + "     OI, volume & funding anomalies",
 		"",
 		style.Dim.Render("Press ; or Esc to close"),
 	}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+)
+
+var sparkBars = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// scaleBars maps each value to a bar scaled between the series' lowest and
+// highest values. A flat series sits mid-height.
+func scaleBars(values []float64) []rune {
+	if len(values) == 0 {
+		return nil
+	}
+	minVal, maxVal := values[0], values[0]
+	for _, v := range values {
+		minVal, maxVal = math.Min(minVal, v), math.Max(maxVal, v)
+	}
+	rng := maxVal - minVal
+
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		idx := 3
+		if rng > 0 {
+			idx = int(math.Round((v - minVal) / rng * 7))
+		}
+		bars[i] = sparkBars[min(max(idx, 0), 7)]
+	}
+	return bars
+}
+
+// RenderSparkline draws the last maxBars points of a time series as a
+// single-line bar chart, green for non-negative values and red otherwise.
+func RenderSparkline(history []api.TimeValue, maxBars int) string {
+	if len(history) == 0 {
+		return ""
+	}
+	recent := history[max(len(history)-maxBars, 0):]
+	vals := make([]float64, len(recent))
+	for i, tv := range recent {
+		vals[i] = tv.Value.Float64()
+	}
+
+	var b strings.Builder
+	b.WriteString("  ")
+	for i, bar := range scaleBars(vals) {
+		if vals[i] >= 0 {
+			b.WriteString(style.Green.Render(string(bar)))
+		} else {
+			b.WriteString(style.Red.Render(string(bar)))
+		}
+	}
+	return b.String()
+}
+
+// RenderCandles draws the closes of the last maxBars candles as a
+// single-line bar chart, green for candles that closed up and red for those
+// that closed down.
+func RenderCandles(candles []api.Candle, maxBars int) string {
+	if len(candles) == 0 {
+		return ""
+	}
+	recent := candles[max(len(candles)-maxBars, 0):]
+	closes := make([]float64, len(recent))
+	for i, c := range recent {
+		closes[i] = c.Close.Float64()
+	}
+
+	var b strings.Builder
+	b.WriteString("  ")
+	for i, bar := range scaleBars(closes) {
+		if c := recent[i]; c.Close.Cmp(c.Open) >= 0 {
+			b.WriteString(style.Green.Render(string(bar)))
+		} else {
+			b.WriteString(style.Red.Render(string(bar)))
+		}
+	}
+	return b.String()
+}
+
+// Sparkline draws values as at most width unstyled bars, each the last
+// value of an equal share of the series, for table cells that color the
+// whole cell.
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width < 1 {
+		return ""
+	}
+	if len(values) > width {
+		sampled := make([]float64, width)
+		for i := range sampled {
+			sampled[i] = values[(i+1)*len(values)/width-1]
+		}
+		values = sampled
+	}
+	return string(scaleBars(values))
+}
@@ -1,13 +1,54 @@
 package ui
 
 import (
+	"fmt"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/charmbracelet/lipgloss"
 )
 
-func RenderStatusBar(width int, errMsg string) string {
-	if errMsg != "" {
-		return style.Red.Render(errMsg)
+// RenderStatusBar shows, in priority order, an error, a recent alert notice,
+// or the key hints, with the request budget on the right when it fits.
+func RenderStatusBar(width int, errMsg, notice, budget string) string {
+	var left string
+	switch {
+	case errMsg != "":
+		left = style.Red.Render(errMsg)
+	case notice != "":
+		left = style.Yellow.Render("⚑ " + notice)
+	default:
+		hints := "←/→:switch  0-7:views  j/k:scroll  s:sort  r:refresh  w:wallet  ;:help  q:quit"
+		left = style.Dim.Render(hints)
+	}
+	if budget == "" || lipgloss.Width(left)+lipgloss.Width(budget)+2 > width {
+		return left
+	}
+	return RenderInfoLine(width, left, budget)
+}
+
+// RenderRateBudget summarizes the request budgets: the per-IP weight left
+// this minute and, once loaded, the wallet's address-based request
+// allowance. Either is yellow under a tenth of its cap and the wallet's red
+// when spent.
+func RenderRateBudget(weight, weightCap int, limit *api.UserRateLimit) string {
+	text := fmt.Sprintf("weight %d/%d", weight, weightCap)
+	out := style.Dim.Render(text)
+	if weight < weightCap/10 {
+		out = style.Yellow.Render(text)
+	}
+	if limit == nil {
+		return out
+	}
+	left := limit.Remaining()
+	text = compactCount(left) + " req left"
+	switch {
+	case left == 0:
+		text = style.Red.Render(text)
+	case left < limit.NRequestsCap/10:
+		text = style.Yellow.Render(text)
+	default:
+		text = style.Dim.Render(text)
 	}
-	hints := "←/→:switch  0-6:views  j/k:scroll  s:sort  r:refresh  w:wallet  ;:help  q:quit"
-	return style.Dim.Render(hints)
+	return out + style.Dim.Render(" · ") + text
 }
@@ -3,8 +3,12 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+	"github.com/charmbracelet/lipgloss"
 )
 
 var TabNames = []string{
@@ -15,17 +19,79 @@ var TabNames = []string{
 	"Funding",
 	"Portfolio",
 	"Vaults",
+	"Staking",
+	"Scanner",
 }
 
-func RenderTabs(activeIdx int, width int) string {
+// RenderTabs renders the view tabs. Tabs whose data failed to load (failing
+// is indexed like names and may be nil) carry a warning badge.
+func RenderTabs(names []string, activeIdx int, failing []bool, width int) string {
 	var tabs []string
-	for i, name := range TabNames {
+	for i, name := range names {
 		label := fmt.Sprintf("%d:%s", i, name)
-		if i == activeIdx {
+		warn := i < len(failing) && failing[i]
+		if warn {
+			label += " ⚠"
+		}
+		switch {
+		case i == activeIdx:
 			tabs = append(tabs, style.ActiveTab.Render(label))
-		} else {
+		case warn:
+			tabs = append(tabs, style.InactiveTab.Foreground(style.Yellow.GetForeground()).Render(label))
+		default:
 			tabs = append(tabs, style.InactiveTab.Render(label))
 		}
 	}
 	return strings.Join(tabs, " ")
 }
+
+// RenderInfoLine lays out the line under the tabs: left-aligned left and
+// right-aligned right.
+func RenderInfoLine(width int, left, right string) string {
+	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		gap = 1
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// RenderDataAge shows how old the active view's data is, flagged when it
+// is stale.
+func RenderDataAge(age time.Duration, stale bool) string {
+	text := "updated " + util.FormatAge(age) + " ago"
+	if stale {
+		return style.Yellow.Render("⚠ STALE · " + text)
+	}
+	return style.Dim.Render(text)
+}
+
+// RenderFeedStats summarizes the WebSocket feed: snapshots superseded
+// before they were shown, messages lost, and how long the last delivery
+// waited. Drops are red and a lag over a second is yellow.
+func RenderFeedStats(t ws.ChannelStats) string {
+	sep := style.Dim.Render(" · ")
+	dropped := style.Dim.Render(compactCount(t.Dropped) + " dropped")
+	if t.Dropped > 0 {
+		dropped = style.Red.Render(compactCount(t.Dropped) + " dropped")
+	}
+	lagText := "lag " + t.Lag.Round(time.Millisecond).String()
+	if t.Lag < time.Millisecond {
+		lagText = "lag <1ms"
+	}
+	lag := style.Dim.Render(lagText)
+	if t.Lag > time.Second {
+		lag = style.Yellow.Render(lagText)
+	}
+	return style.Dim.Render("ws "+compactCount(t.Coalesced)+" coalesced") + sep + dropped + sep + lag
+}
+
+// compactCount formats a counter as 999, 4.1k or 1.2M.
+func compactCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	}
+	return fmt.Sprintf("%d", n)
+}
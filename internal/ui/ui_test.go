@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/fakehl"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+	"github.com/born1337/hyperliquid-terminal/internal/ws"
+)
+
+func TestHeader(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderHeader(s, size.Width)
+	})
+}
+
+func TestTitleBar(t *testing.T) {
+	viewtest.Setup(t)
+	addr := config.TruncateAddress(fakehl.DemoAddress)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderTitleBar(size.Width, false, false, ws.StateConnected, "Demo", addr) + "\n" +
+			RenderTitleBar(size.Width, false, false, ws.StateReconnecting, "Demo", addr) + "\n" +
+			RenderTitleBar(size.Width, true, true, ws.StateClosed, "Testnet vault", addr)
+	})
+}
+
+func TestTabs(t *testing.T) {
+	viewtest.Run(t, func(size viewtest.Size) string {
+		failing := make([]bool, len(TabNames))
+		failing[3], failing[7] = true, true
+		return RenderTabs(TabNames, 1, nil, size.Width) + "\n" +
+			RenderTabs(TabNames, 3, failing, size.Width)
+	})
+}
+
+func TestInfoLine(t *testing.T) {
+	quiet := ws.ChannelStats{Coalesced: 4120, Lag: 2*time.Millisecond + 300*time.Microsecond}
+	behind := ws.ChannelStats{Coalesced: 1_250_000, Dropped: 3, Lag: 1500 * time.Millisecond}
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderInfoLine(size.Width, RenderFeedStats(quiet), RenderDataAge(4*time.Second, false)) + "\n" +
+			RenderInfoLine(size.Width, RenderFeedStats(behind), RenderDataAge(2*time.Minute+5*time.Second, true)) + "\n" +
+			RenderInfoLine(size.Width, "", RenderDataAge(0, false))
+	})
+}
+
+func TestStatusBar(t *testing.T) {
+	viewtest.Run(t, func(size viewtest.Size) string {
+		budget := RenderRateBudget(1180, 1200, &api.UserRateLimit{NRequestsUsed: 2890, NRequestsCap: 2864574})
+		return RenderStatusBar(size.Width, "", "", "") + "\n" +
+			RenderStatusBar(size.Width, "request failed: 429 Too Many Requests", "", "") + "\n" +
+			RenderStatusBar(size.Width, "", "Copied address", "") + "\n" +
+			RenderStatusBar(size.Width, "", "", budget) + "\n" +
+			RenderStatusBar(size.Width, "", "Copied address", budget) + "\n" +
+			RenderStatusBar(size.Width, "", "", RenderRateBudget(40, 1200, &api.UserRateLimit{NRequestsUsed: 10000, NRequestsCap: 10000}))
+	})
+}
+
+func TestErrorsPanel(t *testing.T) {
+	viewtest.Setup(t)
+	s := store.New()
+	boom := &api.HTTPError{Type: "userFills", Status: 502, Body: "<html>\n<head><title>502 Bad Gateway</title></head>\n</html>"}
+	s.RecordFetch(store.SourceAccount, nil, viewtest.Clock.Add(-10*time.Second))
+	s.RecordFetch(store.SourceFills, nil, viewtest.Clock.Add(-5*time.Minute))
+	s.RecordFetch(store.SourceFills, boom, viewtest.Clock.Add(-time.Minute))
+	s.RecordFetch(store.SourceFills, boom, viewtest.Clock.Add(-10*time.Second))
+	s.RecordFetch(store.SourceStaking, errors.New("delegations: context deadline exceeded"), viewtest.Clock.Add(-10*time.Second))
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderErrorsPanel(s.Sources(), s.FetchFailures(), size.Width, size.Height)
+	})
+}
+
+func TestHelp(t *testing.T) {
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderHelp(size.Width, size.Height)
+	})
+}
+
+func TestWalletPicker(t *testing.T) {
+	wallets := []config.Wallet{
+		{Name: "Main", Address: fakehl.DemoAddress},
+		{Name: "Vault", Address: "0x1e37a337ed460039d1b15bd3bc489de789768d5e", Vault: true},
+		{Name: "Test", Address: "0x5b5d51203a0f9079f8aeb098a6523a13f298c060", Testnet: true},
+	}
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderWalletPicker(wallets, 0, 2, size.Width, size.Height)
+	})
+}
+
+func TestSparkline(t *testing.T) {
+	s := viewtest.Store(t)
+	p := s.GetPortfolioPeriod("month")
+	if p == nil {
+		t.Fatal("fixture has no month portfolio period")
+	}
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderSparkline(p.AccountValueHistory, size.Width-4) + "\n" +
+			RenderSparkline(p.PnlHistory, size.Width-4)
+	})
+}
+
+func TestCandles(t *testing.T) {
+	s := viewtest.Store(t)
+	candles := s.Candles("BTC")
+	if len(candles) == 0 {
+		t.Fatal("store has no BTC candles")
+	}
+	viewtest.Run(t, func(size viewtest.Size) string {
+		return RenderCandles(candles, size.Width-4)
+	})
+}
+
+func TestInlineSparkline(t *testing.T) {
+	for _, tc := range []struct {
+		values []float64
+		width  int
+		want   string
+	}{
+		{nil, 8, ""},
+		{[]float64{1, 2, 3}, 0, ""},
+		{[]float64{5, 5, 5}, 8, "▄▄▄"},
+		{[]float64{0, 7, 1, 6}, 8, "▁█▂▇"},
+		// Each bar is the last of its share, 3 and 7, scaled between them
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, 2, "▁█"},
+	} {
+		if got := Sparkline(tc.values, tc.width); got != tc.want {
+			t.Errorf("Sparkline(%v, %d) = %q, want %q", tc.values, tc.width, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package util
+
+import "time"
+
+// Now returns the current time. Views read the clock through it so
+// rendering tests can pin it.
+var Now = time.Now
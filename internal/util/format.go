@@ -4,19 +4,30 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
 )
 
-func FormatUSD(val float64) string {
-	negative := val < 0
-	if negative {
-		val = -val
+// FormatUSD formats a USD amount to the cent with thousands separators:
+// "$1,234.50", "-$12.00".
+func FormatUSD(val decimal.Decimal) string {
+	s := groupThousands(val.Abs().StringFixed(2))
+	if val.Sign() < 0 {
+		return "-$" + s
+	}
+	return "$" + s
+}
+
+func FormatSignedUSD(val decimal.Decimal) string {
+	if val.Sign() >= 0 {
+		return "+" + FormatUSD(val)
 	}
-	s := fmt.Sprintf("%.2f", val)
-	parts := strings.Split(s, ".")
-	intPart := parts[0]
-	decPart := parts[1]
+	return FormatUSD(val)
+}
 
-	// Add commas
+// groupThousands adds commas to the integer part of a plain decimal string.
+func groupThousands(s string) string {
+	intPart, decPart, hasDec := strings.Cut(s, ".")
 	n := len(intPart)
 	if n > 3 {
 		var b strings.Builder
@@ -28,18 +39,10 @@ func FormatUSD(val float64) string {
 		}
 		intPart = b.String()
 	}
-
-	if negative {
-		return "-$" + intPart + "." + decPart
-	}
-	return "$" + intPart + "." + decPart
-}
-
-func FormatSignedUSD(val float64) string {
-	if val >= 0 {
-		return "+" + FormatUSD(val)
+	if !hasDec {
+		return intPart
 	}
-	return FormatUSD(val)
+	return intPart + "." + decPart
 }
 
 func FormatPercent(val float64) string {
@@ -53,6 +56,12 @@ func FormatFundingRate(val float64) string {
 	return fmt.Sprintf("%.4f%%", val*100*24)
 }
 
+// AnnualizeFunding converts an hourly funding rate to an annualized
+// percentage.
+func AnnualizeFunding(hourly float64) float64 {
+	return hourly * 24 * 365 * 100
+}
+
 func FormatLeverage(val float64) string {
 	if val == float64(int(val)) {
 		return fmt.Sprintf("%.0fx", val)
@@ -60,29 +69,19 @@ func FormatLeverage(val float64) string {
 	return fmt.Sprintf("%.2fx", val)
 }
 
-func FormatPrice(val float64) string {
-	if val >= 1000 {
-		s := fmt.Sprintf("%.2f", val)
-		parts := strings.Split(s, ".")
-		intPart := parts[0]
-		decPart := parts[1]
-		n := len(intPart)
-		if n > 3 {
-			var b strings.Builder
-			for i, c := range intPart {
-				if i > 0 && (n-i)%3 == 0 {
-					b.WriteByte(',')
-				}
-				b.WriteRune(c)
-			}
-			intPart = b.String()
-		}
-		return "$" + intPart + "." + decPart
+var (
+	thousand = decimal.NewFromInt(1000)
+	one      = decimal.NewFromInt(1)
+)
+
+func FormatPrice(val decimal.Decimal) string {
+	if val.Cmp(thousand) >= 0 {
+		return "$" + groupThousands(val.StringFixed(2))
 	}
-	if val >= 1 {
-		return fmt.Sprintf("$%.2f", val)
+	if val.Cmp(one) >= 0 {
+		return "$" + val.StringFixed(2)
 	}
-	return fmt.Sprintf("$%.4f", val)
+	return "$" + val.StringFixed(4)
 }
 
 func FormatTime(ts int64) string {
@@ -95,12 +94,54 @@ func FormatTimeFull(ts int64) string {
 	return t.Format("2006-01-02 15:04:05")
 }
 
-func FormatSize(val float64) string {
-	if val < 0 {
-		val = -val
+func FormatSize(val decimal.Decimal) string {
+	val = val.Abs()
+	if val.Cmp(one) >= 0 {
+		return val.StringFixed(4)
+	}
+	return val.StringFixed(6)
+}
+
+// FormatCountdown formats a remaining duration compactly: "2d 04h", "3h 12m",
+// "45m" or "<1m". Non-positive durations return "now".
+func FormatCountdown(d time.Duration) string {
+	if d <= 0 {
+		return "now"
+	}
+	if d < time.Minute {
+		return "<1m"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	mins := int(d % time.Hour / time.Minute)
+	if days > 0 {
+		return fmt.Sprintf("%dd %02dh", days, hours)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %02dm", hours, mins)
+	}
+	return fmt.Sprintf("%dm", mins)
+}
+
+// FormatAge formats how long ago something happened to the second: "4s",
+// "2m 05s", then as FormatCountdown from an hour up.
+func FormatAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %02ds", int(d/time.Minute), int(d%time.Minute/time.Second))
 	}
-	if val >= 1 {
-		return fmt.Sprintf("%.4f", val)
+	return FormatCountdown(d)
+}
+
+// FormatWindow formats a lookback window as "15m", "1h" or "4h".
+func FormatWindow(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
 	}
-	return fmt.Sprintf("%.6f", val)
+	return fmt.Sprintf("%dm", d/time.Minute)
 }
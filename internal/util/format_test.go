@@ -1,44 +1,52 @@
 package util
 
-import "testing"
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
 
 func TestFormatUSD(t *testing.T) {
 	tests := []struct {
-		input float64
+		input string
 		want  string
 	}{
-		{0, "$0.00"},
-		{1.5, "$1.50"},
-		{999.99, "$999.99"},
-		{1000, "$1,000.00"},
-		{1234567.89, "$1,234,567.89"},
-		{-500, "-$500.00"},
-		{-1234567.89, "-$1,234,567.89"},
-		{0.01, "$0.01"},
-		{99999999.99, "$99,999,999.99"},
+		{"0", "$0.00"},
+		{"1.5", "$1.50"},
+		{"999.99", "$999.99"},
+		{"1000", "$1,000.00"},
+		{"1234567.89", "$1,234,567.89"},
+		{"-500", "-$500.00"},
+		{"-1234567.89", "-$1,234,567.89"},
+		{"0.01", "$0.01"},
+		{"99999999.99", "$99,999,999.99"},
+		{"1.005", "$1.01"}, // exact half rounds away from zero
+		{"-0.001", "-$0.00"},
 	}
 	for _, tt := range tests {
-		got := FormatUSD(tt.input)
+		got := FormatUSD(decimal.MustParse(tt.input))
 		if got != tt.want {
-			t.Errorf("FormatUSD(%v) = %q, want %q", tt.input, got, tt.want)
+			t.Errorf("FormatUSD(%s) = %q, want %q", tt.input, got, tt.want)
 		}
 	}
 }
 
 func TestFormatSignedUSD(t *testing.T) {
 	tests := []struct {
-		input float64
+		input string
 		want  string
 	}{
-		{100, "+$100.00"},
-		{-100, "-$100.00"},
-		{0, "+$0.00"},
-		{1234.56, "+$1,234.56"},
+		{"100", "+$100.00"},
+		{"-100", "-$100.00"},
+		{"0", "+$0.00"},
+		{"1234.56", "+$1,234.56"},
 	}
 	for _, tt := range tests {
-		got := FormatSignedUSD(tt.input)
+		got := FormatSignedUSD(decimal.MustParse(tt.input))
 		if got != tt.want {
-			t.Errorf("FormatSignedUSD(%v) = %q, want %q", tt.input, got, tt.want)
+			t.Errorf("FormatSignedUSD(%s) = %q, want %q", tt.input, got, tt.want)
 		}
 	}
 }
@@ -78,6 +86,13 @@ func TestFormatFundingRate(t *testing.T) {
 	}
 }
 
+func TestAnnualizeFunding(t *testing.T) {
+	// 0.00125% an hour is 10.95% a year
+	if got := AnnualizeFunding(0.0000125); math.Abs(got-10.95) > 1e-9 {
+		t.Errorf("AnnualizeFunding(0.0000125) = %v, want 10.95", got)
+	}
+}
+
 func TestFormatLeverage(t *testing.T) {
 	tests := []struct {
 		input float64
@@ -99,37 +114,37 @@ func TestFormatLeverage(t *testing.T) {
 
 func TestFormatPrice(t *testing.T) {
 	tests := []struct {
-		input float64
+		input string
 		want  string
 	}{
-		{91000, "$91,000.00"},
-		{100.50, "$100.50"},
-		{0.0045, "$0.0045"},
-		{1234.56, "$1,234.56"},
-		{0.5, "$0.5000"},
+		{"91000", "$91,000.00"},
+		{"100.50", "$100.50"},
+		{"0.0045", "$0.0045"},
+		{"1234.56", "$1,234.56"},
+		{"0.5", "$0.5000"},
 	}
 	for _, tt := range tests {
-		got := FormatPrice(tt.input)
+		got := FormatPrice(decimal.MustParse(tt.input))
 		if got != tt.want {
-			t.Errorf("FormatPrice(%v) = %q, want %q", tt.input, got, tt.want)
+			t.Errorf("FormatPrice(%s) = %q, want %q", tt.input, got, tt.want)
 		}
 	}
 }
 
 func TestFormatSize(t *testing.T) {
 	tests := []struct {
-		input float64
+		input string
 		want  string
 	}{
-		{1.0982, "1.0982"},
-		{-1.0982, "1.0982"}, // should strip negative
-		{0.00123, "0.001230"},
-		{100, "100.0000"},
+		{"1.0982", "1.0982"},
+		{"-1.0982", "1.0982"}, // should strip negative
+		{"0.00123", "0.001230"},
+		{"100", "100.0000"},
 	}
 	for _, tt := range tests {
-		got := FormatSize(tt.input)
+		got := FormatSize(decimal.MustParse(tt.input))
 		if got != tt.want {
-			t.Errorf("FormatSize(%v) = %q, want %q", tt.input, got, tt.want)
+			t.Errorf("FormatSize(%s) = %q, want %q", tt.input, got, tt.want)
 		}
 	}
 }
@@ -142,3 +157,60 @@ func TestFormatTime(t *testing.T) {
 		t.Error("FormatTime returned empty string")
 	}
 }
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{-time.Hour, "now"},
+		{0, "now"},
+		{30 * time.Second, "<1m"},
+		{45 * time.Minute, "45m"},
+		{3*time.Hour + 12*time.Minute, "3h 12m"},
+		{2*24*time.Hour + 4*time.Hour + 59*time.Minute, "2d 04h"},
+	}
+	for _, tt := range tests {
+		got := FormatCountdown(tt.input)
+		if got != tt.want {
+			t.Errorf("FormatCountdown(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{-time.Second, "0s"},
+		{4*time.Second + 900*time.Millisecond, "4s"},
+		{2*time.Minute + 5*time.Second, "2m 05s"},
+		{3*time.Hour + 12*time.Minute, "3h 12m"},
+	}
+	for _, tt := range tests {
+		got := FormatAge(tt.input)
+		if got != tt.want {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{5 * time.Minute, "5m"},
+		{15 * time.Minute, "15m"},
+		{90 * time.Minute, "90m"},
+		{time.Hour, "1h"},
+		{4 * time.Hour, "4h"},
+	}
+	for _, tt := range tests {
+		got := FormatWindow(tt.input)
+		if got != tt.want {
+			t.Errorf("FormatWindow(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package asset
+
+import (
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenMsg asks the app to show the detail screen for Coin. The list views
+// send it when enter is pressed on a row.
+type OpenMsg struct {
+	Coin string
+}
+
+// Open returns a command delivering an OpenMsg for coin.
+func Open(coin string) tea.Cmd {
+	return func() tea.Msg { return OpenMsg{Coin: coin} }
+}
+
+// Model is the detail screen for one asset: its market context and
+// metadata, the wallet's position, orders, fills and funding in it, a price
+// chart and the order book.
+type Model struct {
+	store  *store.Store
+	coin   string
+	height int
+	width  int
+	scroll int
+}
+
+func New(s *store.Store, coin string) Model {
+	return Model{store: s, coin: coin}
+}
+
+// Coin returns the asset shown.
+func (m Model) Coin() string {
+	return m.coin
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "j", "down":
+			m.scroll = min(m.scroll+1, m.maxScroll(m.lines()))
+		case "k", "up":
+			if m.scroll > 0 {
+				m.scroll--
+			}
+		case "g":
+			m.scroll = 0
+		}
+	}
+	return m, nil
+}
+
+// SetSize sets the lines and columns the screen may use.
+func (m *Model) SetSize(width, height int) {
+	m.width, m.height = width, height
+}
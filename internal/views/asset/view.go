@@ -0,0 +1,470 @@
+package asset
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	colLabel = 13
+	colValue = 20
+
+	// Levels shown each side of the book, and rows of the wallet's orders
+	// and fills
+	bookDepth = 10
+	maxOrders = 8
+	maxFills  = 5
+)
+
+var separator80 = strings.Repeat("─", 80)
+
+func (m Model) View() string {
+	lines := m.lines()
+	start := min(m.scroll, m.maxScroll(lines))
+	end := min(start+m.visible(lines), len(lines))
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	b.WriteString("\n\n")
+	b.WriteString(style.Dim.Render("  [esc] back  [j/k] scroll  [r] refresh book and chart"))
+	return b.String()
+}
+
+func (m Model) lines() []string {
+	return strings.Split(m.content(util.Now()), "\n")
+}
+
+// visible is how many content lines fit; the footer stays put while the
+// rest scrolls.
+func (m Model) visible(lines []string) int {
+	if m.height-2 < 1 {
+		return len(lines)
+	}
+	return m.height - 2
+}
+
+func (m Model) maxScroll(lines []string) int {
+	return max(len(lines)-m.visible(lines), 0)
+}
+
+func (m Model) content(now time.Time) string {
+	market := m.store.Market()
+	if market == nil {
+		return style.Dim.Render("  Loading market data...")
+	}
+	a, ok := market.Asset(m.coin)
+	if !ok {
+		return style.Dim.Render("  " + m.coin + " is not listed")
+	}
+
+	sections := []string{
+		title(a),
+		m.marketSection(a),
+		m.chartSection(),
+		m.bookSection(a),
+		m.positionSection(),
+		m.ordersSection(),
+		m.fillsSection(),
+		m.fundingSection(now),
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+func title(a store.Asset) string {
+	margin := "cross or isolated"
+	if a.OnlyIsolated {
+		margin = "isolated only"
+	}
+	return fmt.Sprintf("%s  %s  %s  %s",
+		style.White.Render(a.Name),
+		style.White.Render(util.FormatPrice(a.LivePrice())),
+		style.PnlColor(a.Change24h).Render(util.FormatPercent(a.Change24h)),
+		style.Dim.Render(fmt.Sprintf("max %dx · %s · size decimals %d · asset %d", a.MaxLeverage, margin, a.SzDecimals, a.Index)),
+	)
+}
+
+func heading(s string) string {
+	return style.Cyan.Render(s) + "\n" + style.Dim.Render(separator80)
+}
+
+// field renders a label and value as one cell of a grid row.
+func field(label, value string) string {
+	return style.Dim.Render(padRight(label, colLabel)) + padRight(value, colValue)
+}
+
+// grid lays fields out in as many columns as the width fits, at most three.
+func (m Model) grid(fields ...string) string {
+	cols := 3
+	if m.width > 0 {
+		cols = max(min((m.width-2)/(colLabel+colValue), 3), 1)
+	}
+	var lines []string
+	for i := 0; i < len(fields); i += cols {
+		row := fields[i:min(i+cols, len(fields))]
+		lines = append(lines, "  "+strings.TrimRight(strings.Join(row, ""), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) marketSection(a store.Asset) string {
+	ctx := a.Ctx
+	price := a.LivePrice()
+
+	basis := "-"
+	if ctx.OraclePx.Sign() > 0 {
+		pct := ctx.MarkPx.Sub(ctx.OraclePx).Float64() / ctx.OraclePx.Float64() * 100
+		basis = style.PnlColor(pct).Render(fmt.Sprintf("%+.4f%%", pct))
+	}
+	impactBid, impactAsk, impactSpread := "-", "-", "-"
+	if len(ctx.ImpactPxs) == 2 {
+		bid, ask := ctx.ImpactPxs[0], ctx.ImpactPxs[1]
+		impactBid, impactAsk = util.FormatPrice(bid), util.FormatPrice(ask)
+		if price.Sign() > 0 {
+			impactSpread = fmt.Sprintf("%.1f bps", ask.Sub(bid).Float64()/price.Float64()*1e4)
+		}
+	}
+	oi := ctx.OpenInterest.StringFixed(int32(a.SzDecimals)) + " " + a.Name
+
+	return heading("Market") + "\n" + m.grid(
+		field("Mark", util.FormatPrice(ctx.MarkPx)),
+		field("Oracle", util.FormatPrice(ctx.OraclePx)),
+		field("Mid", formatOptionalPrice(a.Mid)),
+		field("Premium", style.PnlColor(ctx.Premium.Float64()).Render(fmt.Sprintf("%.4f%%", ctx.Premium.Float64()*100))),
+		field("Mark basis", basis),
+		field("Prev day", formatOptionalPrice(ctx.PrevDayPx)),
+		field("Impact bid", impactBid),
+		field("Impact ask", impactAsk),
+		field("Impact sprd", impactSpread),
+		field("Funding/1h", style.PnlColor(a.Funding).Render(fmt.Sprintf("%.4f%%", a.Funding*100))),
+		field("Funding/24h", style.PnlColor(a.Funding).Render(util.FormatFundingRate(a.Funding))),
+		field("Funding APR", style.PnlColor(a.Funding).Render(fmt.Sprintf("%.2f%%", util.AnnualizeFunding(a.Funding)))),
+		field("Open int", oi),
+		field("OI value", formatCompact(ctx.OpenInterest.Mul(price).Float64())),
+		field("24h volume", formatCompact(ctx.DayNtlVlm.Float64())),
+	)
+}
+
+func formatOptionalPrice(px decimal.Decimal) string {
+	if px.Sign() <= 0 {
+		return "-"
+	}
+	return util.FormatPrice(px)
+}
+
+func (m Model) chartSection() string {
+	candles := m.store.Candles(m.coin)
+	if len(candles) == 0 {
+		return heading("Price") + "\n" + m.pending(store.SourceCandles, "chart")
+	}
+	bars := min(len(candles), max(m.width-4, 20))
+	shown := candles[len(candles)-bars:]
+	lo, hi := shown[0].Low, shown[0].High
+	for _, c := range shown {
+		if c.Low.Cmp(lo) < 0 {
+			lo = c.Low
+		}
+		if c.High.Cmp(hi) > 0 {
+			hi = c.High
+		}
+	}
+	first, last := shown[0], shown[len(shown)-1]
+	chg := last.Close.Sub(first.Open).Float64() / first.Open.Float64() * 100
+
+	var b strings.Builder
+	b.WriteString(heading(fmt.Sprintf("Price (%d × %s candles)", len(shown), last.Interval)))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderCandles(shown, bars))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  %s %s  %s %s  %s %s  %s",
+		style.Dim.Render("low"), util.FormatPrice(lo),
+		style.Dim.Render("high"), util.FormatPrice(hi),
+		style.Dim.Render("change"), style.PnlColor(chg).Render(util.FormatPercent(chg)),
+		style.Dim.Render("since "+util.FormatTime(first.OpenTime)),
+	)
+	return b.String()
+}
+
+// pending explains a missing fetched section: loading, or why it failed.
+func (m Model) pending(source, what string) string {
+	if err := m.store.FetchError(source); err != nil {
+		return style.Red.Render("  Loading " + what + " failed: " + err.Error())
+	}
+	return style.Dim.Render("  Loading " + what + "...")
+}
+
+func (m Model) bookSection(a store.Asset) string {
+	book := m.store.L2Book(m.coin)
+	if book == nil {
+		return heading("Order Book") + "\n" + m.pending(store.SourceBook, "order book")
+	}
+	bids, asks := book.Bids(), book.Asks()
+
+	var b strings.Builder
+	b.WriteString(heading("Order Book " + style.Dim.Render("as of "+time.UnixMilli(book.Time).Format("15:04:05"))))
+	b.WriteString("\n")
+	b.WriteString(style.TableHeader.Render(fmt.Sprintf("  %4s %14s %14s   %-14s %-14s %-4s", "#", "BID SIZE", "BID", "ASK", "ASK SIZE", "#")))
+	b.WriteString("\n")
+	for i := range min(max(len(bids), len(asks)), bookDepth) {
+		bid := fmt.Sprintf("%4s %14s %14s", "", "", "")
+		if i < len(bids) {
+			l := bids[i]
+			bid = fmt.Sprintf("%4d %14s ", l.N, formatBookSize(l.Sz, a.SzDecimals)) + style.Green.Render(fmt.Sprintf("%14s", util.FormatPrice(l.Px)))
+		}
+		ask := ""
+		if i < len(asks) {
+			l := asks[i]
+			ask = style.Red.Render(fmt.Sprintf("%-14s", util.FormatPrice(l.Px))) + fmt.Sprintf(" %-14s %-4d", formatBookSize(l.Sz, a.SzDecimals), l.N)
+		}
+		b.WriteString("  " + bid + "   " + ask)
+		b.WriteString("\n")
+	}
+	if len(bids) > 0 && len(asks) > 0 {
+		bestBid, bestAsk := bids[0].Px, asks[0].Px
+		mid := bestBid.Add(bestAsk).Float64() / 2
+		spread := bestAsk.Sub(bestBid)
+		fmt.Fprintf(&b, "  %s %s (%.2f bps)   %s %s / %s",
+			style.Dim.Render("spread"), util.FormatPrice(spread), spread.Float64()/mid*1e4,
+			style.Dim.Render("depth shown"),
+			style.Green.Render(formatCompact(notional(bids[:min(len(bids), bookDepth)]).Float64())),
+			style.Red.Render(formatCompact(notional(asks[:min(len(asks), bookDepth)]).Float64())),
+		)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func formatBookSize(sz decimal.Decimal, szDecimals int) string {
+	return sz.StringFixed(int32(szDecimals))
+}
+
+// notional is the USD value resting on levels.
+func notional(levels []api.L2Level) decimal.Decimal {
+	total := decimal.Zero
+	for _, l := range levels {
+		total = total.Add(l.Px.Mul(l.Sz))
+	}
+	return total
+}
+
+func (m Model) positionSection() string {
+	positions, _ := m.store.LivePositions(false)
+	i := slices.IndexFunc(positions, func(lp store.LivePosition) bool { return lp.Position.Coin == m.coin })
+	if i < 0 {
+		return heading("Position") + "\n" + style.Dim.Render("  No open position")
+	}
+	lp := positions[i]
+	p := lp.Position
+
+	side, sideStyle := "LONG", style.Green
+	if p.Szi.Sign() < 0 {
+		side, sideStyle = "SHORT", style.Red
+	}
+	liq, liqDist := "-", "-"
+	if p.LiquidationPx != nil && p.LiquidationPx.Sign() > 0 {
+		liq = util.FormatPrice(*p.LiquidationPx)
+	}
+	if pct, ok := lp.LiqDistance(); ok {
+		liqDist = fmt.Sprintf("%.2f%%", pct)
+	}
+	funding := "-"
+	if p.CumFunding != nil {
+		// Positive SinceOpen is funding paid
+		received := p.CumFunding.SinceOpen.Neg()
+		funding = style.PnlColor(received.Float64()).Render(util.FormatSignedUSD(received))
+	}
+
+	return heading("Position") + "\n" + m.grid(
+		field("Side", sideStyle.Render(side)),
+		field("Size", util.FormatSize(p.Szi)+" "+p.Coin),
+		field("Leverage", util.FormatLeverage(p.Leverage.Value)+" "+p.Leverage.Type),
+		field("Entry", util.FormatPrice(p.EntryPx)),
+		field("Current", formatOptionalPrice(lp.MarkPx)),
+		field("Value", util.FormatUSD(lp.Value)),
+		field("PnL", style.PnlColor(lp.UnrealizedPnl.Float64()).Render(util.FormatSignedUSD(lp.UnrealizedPnl))),
+		field("ROE", style.PnlColor(lp.ReturnOnEquity).Render(util.FormatPercent(lp.ReturnOnEquity*100))),
+		field("Margin", util.FormatUSD(p.MarginUsed)),
+		field("Liq price", liq),
+		field("Liq dist", liqDist),
+		field("Funding rcvd", funding),
+	)
+}
+
+func (m Model) ordersSection() string {
+	var orders []api.OpenOrder
+	for _, o := range m.store.OpenOrders() {
+		if o.Coin == m.coin {
+			orders = append(orders, o)
+		}
+	}
+	if len(orders) == 0 {
+		return heading("Open Orders") + "\n" + style.Dim.Render("  No open orders")
+	}
+	// A price ladder, asks above bids
+	slices.SortFunc(orders, func(a, b api.OpenOrder) int { return b.LimitPx.Cmp(a.LimitPx) })
+
+	var b strings.Builder
+	b.WriteString(heading(fmt.Sprintf("Open Orders (%d)", len(orders))))
+	for _, o := range orders[:min(len(orders), maxOrders)] {
+		side, sideStyle := "BUY", style.Green
+		if o.Side == "A" || o.Side == "sell" {
+			side, sideStyle = "SELL", style.Red
+		}
+		kind := o.OrderType
+		if o.IsTrigger {
+			kind = "trigger"
+		}
+		line := fmt.Sprintf("  %s %-8s %12s @ %-12s", sideStyle.Render(padRight(side, 5)), kind, util.FormatSize(o.Sz), util.FormatPrice(o.LimitPx))
+		if !o.TriggerPx.IsZero() {
+			line += style.Dim.Render(" trigger ") + util.FormatPrice(o.TriggerPx)
+		}
+		if o.ReduceOnly {
+			line += style.Yellow.Render(" reduce-only")
+		}
+		line += "  " + style.Dim.Render(util.FormatTime(o.Timestamp))
+		b.WriteString("\n" + line)
+	}
+	if len(orders) > maxOrders {
+		b.WriteString("\n" + style.Dim.Render(fmt.Sprintf("  and %d more in the Orders view", len(orders)-maxOrders)))
+	}
+	return b.String()
+}
+
+func (m Model) fillsSection() string {
+	var fills []api.Fill
+	for _, f := range m.store.Fills() {
+		if f.Coin == m.coin {
+			fills = append(fills, f)
+		}
+	}
+	if len(fills) == 0 {
+		return heading("Recent Fills") + "\n" + style.Dim.Render("  No recent fills")
+	}
+	slices.SortFunc(fills, func(a, b api.Fill) int { return cmp.Compare(b.Time, a.Time) })
+
+	var pnl, fees decimal.Decimal
+	for _, f := range fills {
+		pnl = pnl.Add(f.ClosedPnl)
+		fees = fees.Add(f.Fee)
+	}
+
+	var b strings.Builder
+	b.WriteString(heading(fmt.Sprintf("Recent Fills (%d)", len(fills))))
+	for _, f := range fills[:min(len(fills), maxFills)] {
+		dir := f.Dir
+		if dir == "" {
+			dir = "Buy"
+			if f.Side == "A" || f.Side == "sell" {
+				dir = "Sell"
+			}
+		}
+		dirStyle := style.Green
+		if f.Side == "A" || f.Side == "sell" {
+			dirStyle = style.Red
+		}
+		pnlCell := style.Dim.Render(fmt.Sprintf("%12s", "-"))
+		if !f.ClosedPnl.IsZero() {
+			pnlCell = style.PnlColor(f.ClosedPnl.Float64()).Render(fmt.Sprintf("%12s", util.FormatSignedUSD(f.ClosedPnl)))
+		}
+		fmt.Fprintf(&b, "\n  %s  %s %12s @ %-12s %s  %s",
+			style.Dim.Render(util.FormatTimeFull(f.Time)),
+			dirStyle.Render(padRight(dir, 12)),
+			util.FormatSize(f.Sz), util.FormatPrice(f.Px),
+			pnlCell,
+			style.Dim.Render("fee "+util.FormatUSD(f.Fee)),
+		)
+	}
+	fmt.Fprintf(&b, "\n  %s %s   %s %s",
+		style.White.Render("Realized:"), style.PnlColor(pnl.Float64()).Render(util.FormatSignedUSD(pnl)),
+		style.White.Render("Fees:"), style.Red.Render(util.FormatUSD(fees)),
+	)
+	return b.String()
+}
+
+func (m Model) fundingSection(now time.Time) string {
+	var b strings.Builder
+	b.WriteString(heading("Funding"))
+
+	var paid decimal.Decimal
+	var n int
+	var lastAt int64
+	for _, fp := range m.store.FundingPayments() {
+		if fp.Coin != m.coin {
+			continue
+		}
+		paid = paid.Add(fp.Usdc)
+		n++
+		lastAt = max(lastAt, fp.Time)
+	}
+	if n > 0 {
+		fmt.Fprintf(&b, "\n  %s %s  %s",
+			style.White.Render("Payments:"),
+			style.PnlColor(paid.Float64()).Render(util.FormatSignedUSD(paid)),
+			style.Dim.Render(fmt.Sprintf("(%d, last %s)", n, util.FormatTime(lastAt))),
+		)
+	} else {
+		b.WriteString("\n" + style.Dim.Render("  No funding payments"))
+	}
+
+	history, loaded := m.store.FundingHistory(m.coin)
+	if !loaded || len(history) == 0 {
+		b.WriteString("\n" + m.pending(store.SourceFundingHistory, "funding history"))
+		return b.String()
+	}
+	var avgs []string
+	for _, w := range []struct {
+		label  string
+		window time.Duration
+	}{{"1D", 24 * time.Hour}, {"7D", 7 * 24 * time.Hour}, {"30D", 30 * 24 * time.Hour}} {
+		cell := style.Dim.Render("-")
+		if avg, ok := m.store.FundingHistoryAverage(m.coin, w.window, now); ok {
+			apr := util.AnnualizeFunding(avg)
+			cell = style.PnlColor(apr).Render(fmt.Sprintf("%.2f%%", apr))
+		}
+		avgs = append(avgs, style.Dim.Render("avg "+w.label+" APR:")+" "+cell)
+	}
+	b.WriteString("\n  " + strings.Join(avgs, "   "))
+
+	series := make([]api.TimeValue, len(history))
+	for i, e := range history {
+		series[i] = api.TimeValue{Time: e.Time, Value: e.FundingRate}
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.RenderSparkline(series, max(m.width-4, 20)))
+	return b.String()
+}
+
+func formatCompact(val float64) string {
+	if val == 0 {
+		return "-"
+	}
+	abs := math.Abs(val)
+	if abs >= 1_000_000_000 {
+		return fmt.Sprintf("$%.2fB", val/1_000_000_000)
+	}
+	if abs >= 1_000_000 {
+		return fmt.Sprintf("$%.2fM", val/1_000_000)
+	}
+	if abs >= 1_000 {
+		return fmt.Sprintf("$%.1fK", val/1_000)
+	}
+	return fmt.Sprintf("$%.0f", val)
+}
+
+// padRight pads s to width display cells, ignoring color codes.
+func padRight(s string, width int) string {
+	if n := width - lipgloss.Width(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
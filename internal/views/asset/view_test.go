@@ -0,0 +1,38 @@
+package asset
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+)
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s, "BTC")
+		m.SetSize(size.Width, size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestViewScrolls(t *testing.T) {
+	s := viewtest.Store(t)
+	m := New(s, "BTC")
+	m.SetSize(120, 20)
+	top := m.View()
+	for range 100 {
+		m, _ = m.Update(viewtest.Key("j"))
+	}
+	bottom := m.View()
+	if top == bottom {
+		t.Fatal("j did not scroll")
+	}
+	m, _ = m.Update(viewtest.Key("k"))
+	if m.View() == bottom {
+		t.Error("k did not scroll back from the bottom")
+	}
+	m, _ = m.Update(viewtest.Key("g"))
+	if m.View() != top {
+		t.Error("g did not return to the top")
+	}
+}
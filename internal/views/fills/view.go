@@ -4,78 +4,142 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
 )
 
 var separator80 = strings.Repeat("─", 80)
 
+func isSell(f api.Fill) bool {
+	return f.Side == "A" || f.Side == "sell"
+}
+
+var columns = []table.Column[api.Fill]{
+	{
+		Key: "time", Title: "TIME", Width: 16, Left: true, Desc: true,
+		Cell: func(f api.Fill) string { return util.FormatTimeFull(f.Time) },
+		Cmp:  table.By(func(f api.Fill) int64 { return f.Time }),
+	},
+	{
+		Key: "coin", Title: "COIN", Width: 9, Left: true,
+		Cell:  func(f api.Fill) string { return f.Coin },
+		Style: func(api.Fill) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(f api.Fill) string { return f.Coin }),
+	},
+	{
+		Key: "side", Title: "SIDE", Width: 6, Left: true,
+		Cell: func(f api.Fill) string {
+			if isSell(f) {
+				return "SELL"
+			}
+			return "BUY"
+		},
+		Style: func(f api.Fill) lipgloss.Style {
+			if isSell(f) {
+				return style.Red
+			}
+			return style.Green
+		},
+		Cmp: table.By(func(f api.Fill) string { return f.Side }),
+	},
+	{
+		Key: "size", Title: "SIZE", Width: 12, Desc: true,
+		Cell: func(f api.Fill) string { return util.FormatSize(f.Sz) },
+		Cmp:  table.ByDecimal(func(f api.Fill) decimal.Decimal { return f.Sz }),
+	},
+	{
+		Key: "price", Title: "PRICE", Width: 12, Desc: true,
+		Cell: func(f api.Fill) string { return util.FormatPrice(f.Px) },
+		Cmp:  table.ByDecimal(func(f api.Fill) decimal.Decimal { return f.Px }),
+	},
+	{
+		Key: "pnl", Title: "REALIZED PNL", Width: 14, Desc: true,
+		Cell: func(f api.Fill) string {
+			if f.ClosedPnl.IsZero() {
+				return "-"
+			}
+			return util.FormatSignedUSD(f.ClosedPnl)
+		},
+		Style: func(f api.Fill) lipgloss.Style { return style.PnlColor(f.ClosedPnl.Float64()) },
+		Cmp:   table.ByDecimal(func(f api.Fill) decimal.Decimal { return f.ClosedPnl }),
+	},
+	{
+		Key: "fee", Title: "FEE", Width: 12, Desc: true,
+		Cell:  func(f api.Fill) string { return util.FormatUSD(f.Fee) },
+		Style: func(api.Fill) lipgloss.Style { return style.Red },
+		Cmp:   table.ByDecimal(func(f api.Fill) decimal.Decimal { return f.Fee }),
+	},
+}
+
+var filterFields = []filter.Field[api.Fill]{
+	filter.Text("coin", func(f api.Fill) string { return f.Coin }),
+	filter.Text("side", func(f api.Fill) string {
+		if isSell(f) {
+			return "sell"
+		}
+		return "buy"
+	}),
+	filter.Text("dir", func(f api.Fill) string { return f.Dir }),
+	filter.Decimal("size", func(f api.Fill) decimal.Decimal { return f.Sz }),
+	filter.Decimal("price", func(f api.Fill) decimal.Decimal { return f.Px }),
+	filter.Decimal("pnl", func(f api.Fill) decimal.Decimal { return f.ClosedPnl }),
+	filter.Decimal("fee", func(f api.Fill) decimal.Decimal { return f.Fee }),
+	filter.Time("time", func(f api.Fill) int64 { return f.Time }),
+}
+
+// rows returns the fills the filter keeps, in the table's order, and how
+// many there are.
+func (m Model) rows() ([]api.Fill, int) {
+	fills := m.store.Fills()
+	total := len(fills)
+	fills = m.filter.Apply(fills)
+	m.table.Sort(fills)
+	return fills, total
+}
+
 func (m Model) View() string {
-	m.store.RLock()
-	allFills := m.store.Fills
-	m.store.RUnlock()
+	allFills, total := m.rows()
 
-	if len(allFills) == 0 {
+	if total == 0 {
+		if err := m.store.FetchError(store.SourceFills); err != nil {
+			return ui.RenderLoadError("fills", err)
+		}
 		return style.Dim.Render("  No recent fills")
 	}
 
 	var b strings.Builder
 
-	header := fmt.Sprintf("%-16s %-9s %-6s %12s %12s %14s %12s",
-		"TIME", "COIN", "SIDE", "SIZE", "PRICE", "REALIZED PNL", "FEE",
-	)
-	b.WriteString(style.TableHeader.Render(header))
-	b.WriteString("\n")
-
 	visibleRows := m.height - 5
-	if visibleRows < 1 {
-		visibleRows = len(allFills)
-	}
-	start := m.scroll
-	if start >= len(allFills) {
-		start = len(allFills) - 1
-	}
-	if start < 0 {
-		start = 0
-	}
-	end := start + visibleRows
-	if end > len(allFills) {
-		end = len(allFills)
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(allFills), total))
+		b.WriteString("\n")
+		visibleRows--
 	}
 
-	var totalRealizedPnl, totalFees float64
+	b.WriteString(m.table.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.cursor, len(allFills), visibleRows)
+
+	var totalRealizedPnl, totalFees decimal.Decimal
 	for _, f := range allFills {
-		totalRealizedPnl += util.ParseFloat(f.ClosedPnl)
-		totalFees += util.ParseFloat(f.Fee)
+		totalRealizedPnl = totalRealizedPnl.Add(f.ClosedPnl)
+		totalFees = totalFees.Add(f.Fee)
 	}
 
-	for _, f := range allFills[start:end] {
-		sideStyle := style.Green
-		side := "BUY"
-		if f.Side == "A" || f.Side == "sell" {
-			sideStyle = style.Red
-			side = "SELL"
+	for i, f := range allFills[start:end] {
+		if start+i == cursor {
+			b.WriteString(m.table.SelectedRow(f))
+		} else {
+			b.WriteString(m.table.Row(f))
 		}
-
-		closedPnl := util.ParseFloat(f.ClosedPnl)
-		pnlStyle := style.PnlColor(closedPnl)
-		pnlStr := "-"
-		if closedPnl != 0 {
-			pnlStr = util.FormatSignedUSD(closedPnl)
-		}
-
-		fee := util.ParseFloat(f.Fee)
-
-		row := fmt.Sprintf("%-16s %-9s %s %12s %12s %14s %12s",
-			util.FormatTimeFull(f.Time),
-			style.White.Render(f.Coin),
-			sideStyle.Render(fmt.Sprintf("%-6s", side)),
-			util.FormatSize(util.ParseFloat(f.Sz)),
-			util.FormatPrice(util.ParseFloat(f.Px)),
-			pnlStyle.Render(pnlStr),
-			style.Red.Render(util.FormatUSD(fee)),
-		)
-		b.WriteString(row)
 		b.WriteString("\n")
 	}
 
@@ -83,7 +147,7 @@ func (m Model) View() string {
 	b.WriteString("\n")
 	b.WriteString(style.Dim.Render(separator80))
 	b.WriteString("\n")
-	pnlStyle := style.PnlColor(totalRealizedPnl)
+	pnlStyle := style.PnlColor(totalRealizedPnl.Float64())
 	summary := fmt.Sprintf("  %s %s   %s %s   %s",
 		style.White.Render("Realized PnL:"),
 		pnlStyle.Render(util.FormatSignedUSD(totalRealizedPnl)),
@@ -92,6 +156,7 @@ func (m Model) View() string {
 		style.Dim.Render(fmt.Sprintf("(%d fills)", len(allFills))),
 	)
 	b.WriteString(summary)
+	b.WriteString(style.Dim.Render("  [enter] details"))
 
 	return b.String()
 }
@@ -0,0 +1,28 @@
+package fills
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+)
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestFilteredView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		for _, k := range []string{"/", "s", "i", "d", "e", ":", "s", "e", "l", "l", "enter"} {
+			m, _ = m.Update(viewtest.Key(k))
+		}
+		return m.View()
+	})
+}
@@ -1,37 +1,143 @@
 package funding
 
 import (
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
 	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// RatesOpenedMsg is emitted when the Funding Rates mode is shown so the app
+// can load predicted fundings across venues.
+type RatesOpenedMsg struct{}
+
 type Model struct {
 	store  *store.Store
-	scroll int
+	cursor int
 	height int
+	table  table.Table[api.FundingPayment]
+	filter filter.Bar[api.FundingPayment]
+
+	// Funding Rates mode
+	rates       bool
+	rateCursor  int
+	ratesTable  table.Table[rateRow]
+	ratesFilter filter.Bar[rateRow]
 }
 
 func New(s *store.Store) Model {
-	return Model{store: s}
+	return Model{
+		store:  s,
+		table:  table.New("funding", 2, paymentColumns, config.SortKey{Column: "time"}),
+		filter: filter.NewBar(paymentFilterFields),
+		// Widest spread first
+		ratesTable:  table.New("funding-rates", 2, rateColumns, config.SortKey{Column: "spread", Desc: true}),
+		ratesFilter: filter.NewBar(rateFilterFields),
+	}
 }
 
 func (m Model) Init() tea.Cmd { return nil }
 
+// RatesMode reports whether the Funding Rates mode is showing.
+func (m Model) RatesMode() bool {
+	return m.rates
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		var cmd tea.Cmd
+		var used bool
+		if m.rates {
+			if m.ratesFilter, cmd, used = m.ratesFilter.Update(msg); used {
+				m.rateCursor = 0
+				return m, cmd
+			}
+		} else if m.filter, cmd, used = m.filter.Update(msg); used {
+			m.cursor = 0
+			return m, cmd
+		}
+		if msg.String() == "m" {
+			m.rates = !m.rates
+			m.cursor = 0
+			if m.rates {
+				return m, func() tea.Msg { return RatesOpenedMsg{} }
+			}
+			return m, nil
+		}
+		if m.rates {
+			return m.updateRates(msg)
+		}
 		switch msg.String() {
 		case "j", "down":
-			m.scroll++
+			if rows, _ := m.paymentRows(); m.cursor < len(rows)-1 {
+				m.cursor++
+			}
 		case "k", "up":
-			if m.scroll > 0 {
-				m.scroll--
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g":
+			m.cursor = 0
+		case "enter":
+			if rows, _ := m.paymentRows(); len(rows) > 0 {
+				c, _, _ := table.Window(m.cursor, len(rows), 0)
+				return m, asset.Open(rows[c].Coin)
+			}
+		default:
+			var cmd tea.Cmd
+			if m.table, cmd = m.table.Update(msg); cmd != nil {
+				m.cursor = 0
 			}
+			return m, cmd
 		}
 	}
 	return m, nil
 }
 
+func (m Model) updateRates(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if rows, _ := m.rateRows(); m.rateCursor < len(rows)-1 {
+			m.rateCursor++
+		}
+	case "k", "up":
+		if m.rateCursor > 0 {
+			m.rateCursor--
+		}
+	case "g":
+		m.rateCursor = 0
+	case "enter":
+		if coin := m.selectedCoin(); coin != "" {
+			return m, asset.Open(coin)
+		}
+	default:
+		var cmd tea.Cmd
+		if m.ratesTable, cmd = m.ratesTable.Update(msg); cmd != nil {
+			m.rateCursor = 0
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+// SetSortPrefs restores the saved sort orders.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.table = m.table.Restore(prefs)
+	m.ratesTable = m.ratesTable.Restore(prefs)
+}
+
+// Filtering reports whether the showing mode's filter bar has the keyboard.
+func (m Model) Filtering() bool {
+	if m.rates {
+		return m.ratesFilter.Editing()
+	}
+	return m.filter.Editing()
+}
+
 func (m *Model) SetHeight(h int) {
 	m.height = h
 }
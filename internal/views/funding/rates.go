@@ -0,0 +1,308 @@
+package funding
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	colRCoin   = 10
+	colRAPR    = 10
+	colRSpread = 10
+	colRArb    = 20
+	colRNext   = 8
+
+	// Spreads at or above this annualized percentage are highlighted as
+	// arbitrage candidates.
+	arbHighlightAPR = 10.0
+
+	// Lines reserved under the table for the selected coin's history panel
+	historyPanelLines = 8
+)
+
+type rateRow struct {
+	coin      string
+	hl        float64 // hourly rates; NaN if the venue doesn't list the coin
+	bin       float64
+	bybit     float64
+	nextHL    int64
+	spread    float64 // hourly, absolute
+	arb       string
+	hasPos    bool
+	hasVenues bool
+	selected  bool // under the cursor
+}
+
+// arbitrage reports whether the spread is wide enough to highlight.
+func (r rateRow) arbitrage() bool {
+	return r.hasVenues && util.AnnualizeFunding(r.spread) >= arbHighlightAPR
+}
+
+var rateColumns = []table.Column[rateRow]{
+	{
+		Key: "coin", Title: "COIN", Width: colRCoin, Left: true,
+		Cell: func(r rateRow) string {
+			marker := "  "
+			if r.selected {
+				marker = "▸ "
+			}
+			coin := r.coin
+			if r.hasPos {
+				coin += " ●"
+			}
+			return marker + coin
+		},
+		Style: func(r rateRow) lipgloss.Style {
+			if r.selected {
+				return style.Cyan
+			}
+			return style.White
+		},
+		Cmp: table.By(func(r rateRow) string { return r.coin }),
+	},
+	aprColumn("hl", "HL APR", func(r rateRow) float64 { return r.hl }),
+	aprColumn("bin", "BIN APR", func(r rateRow) float64 { return r.bin }),
+	aprColumn("bybit", "BYBIT APR", func(r rateRow) float64 { return r.bybit }),
+	{
+		Key: "spread", Title: "SPREAD", Width: colRSpread, Desc: true,
+		Cell: func(r rateRow) string {
+			if !r.hasVenues {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f%%", util.AnnualizeFunding(r.spread))
+		},
+		Style: func(r rateRow) lipgloss.Style {
+			switch {
+			case r.arbitrage():
+				return style.Yellow.Bold(true)
+			case r.hasVenues:
+				return style.White
+			}
+			return style.Dim
+		},
+		Cmp: table.By(func(r rateRow) float64 { return r.spread }),
+		// Coins only listed on Hyperliquid have no spread to compare
+		Missing: func(r rateRow) bool { return !r.hasVenues },
+	},
+	{
+		Key: "arb", Title: "ARB", Width: colRArb, Left: true,
+		Cell: func(r rateRow) string {
+			if !r.arbitrage() {
+				return "-"
+			}
+			return r.arb
+		},
+		Style: func(r rateRow) lipgloss.Style {
+			if r.arbitrage() {
+				return style.Yellow
+			}
+			return style.Dim
+		},
+		Cmp:     table.By(func(r rateRow) string { return r.arb }),
+		Missing: func(r rateRow) bool { return !r.arbitrage() },
+	},
+	{
+		Key: "next", Title: "NEXT", Width: colRNext,
+		Cell: func(r rateRow) string {
+			if r.nextHL <= 0 {
+				return "-"
+			}
+			return util.FormatCountdown(time.UnixMilli(r.nextHL).Sub(util.Now()))
+		},
+		Style:   func(rateRow) lipgloss.Style { return style.Dim },
+		Cmp:     table.By(func(r rateRow) int64 { return r.nextHL }),
+		Missing: func(r rateRow) bool { return r.nextHL <= 0 },
+	},
+}
+
+// aprColumn shows an hourly venue rate annualized; NaN where the venue
+// doesn't list the coin.
+func aprColumn(key, title string, hourly func(rateRow) float64) table.Column[rateRow] {
+	return table.Column[rateRow]{
+		Key: key, Title: title, Width: colRAPR, Desc: true,
+		Cell: func(r rateRow) string {
+			if math.IsNaN(hourly(r)) {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f%%", util.AnnualizeFunding(hourly(r)))
+		},
+		Style: func(r rateRow) lipgloss.Style {
+			if math.IsNaN(hourly(r)) {
+				return style.Dim
+			}
+			return style.PnlColor(hourly(r))
+		},
+		Cmp:     table.By(hourly),
+		Missing: func(r rateRow) bool { return math.IsNaN(hourly(r)) },
+	}
+}
+
+func venueHourly(pf api.PredictedFunding, venue string) float64 {
+	v, ok := pf.Venue(venue)
+	if !ok {
+		return math.NaN()
+	}
+	return v.FundingRate.Float64() / float64(v.IntervalHours())
+}
+
+var rateFilterFields = []filter.Field[rateRow]{
+	filter.Text("coin", func(r rateRow) string { return r.coin }),
+	filter.Number("hl", func(r rateRow) float64 { return util.AnnualizeFunding(r.hl) }),
+	filter.Number("bin", func(r rateRow) float64 { return util.AnnualizeFunding(r.bin) }),
+	filter.Number("bybit", func(r rateRow) float64 { return util.AnnualizeFunding(r.bybit) }),
+	filter.Number("spread", func(r rateRow) float64 {
+		if !r.hasVenues {
+			return math.NaN()
+		}
+		return util.AnnualizeFunding(r.spread)
+	}),
+	filter.Text("arb", func(r rateRow) string { return r.arb }),
+	filter.Text("pos", func(r rateRow) string {
+		if r.hasPos {
+			return "yes"
+		}
+		return "no"
+	}),
+}
+
+// rateRows builds the predicted funding comparison in the table's order,
+// without the rows the filter hides, and returns how many there were
+// before filtering.
+func (m Model) rateRows() ([]rateRow, int) {
+	predicted := m.store.PredictedFundings()
+	positions := make(map[string]bool)
+	for _, coin := range m.store.PositionCoins() {
+		positions[coin] = true
+	}
+
+	rows := make([]rateRow, 0, len(predicted))
+	for _, pf := range predicted {
+		hlVenue, ok := pf.Venue(api.VenueHyperliquid)
+		if !ok {
+			continue
+		}
+		r := rateRow{
+			coin:   pf.Coin,
+			hl:     venueHourly(pf, api.VenueHyperliquid),
+			bin:    venueHourly(pf, api.VenueBinance),
+			bybit:  venueHourly(pf, api.VenueBybit),
+			nextHL: hlVenue.NextFundingTime,
+			hasPos: positions[pf.Coin],
+		}
+		// Widest spread against any CEX venue; long the cheaper side, short the richer one
+		for _, other := range []struct {
+			name string
+			rate float64
+		}{{"Bin", r.bin}, {"Bybit", r.bybit}} {
+			if math.IsNaN(other.rate) {
+				continue
+			}
+			r.hasVenues = true
+			if spread := math.Abs(other.rate - r.hl); spread > r.spread {
+				r.spread = spread
+				if other.rate > r.hl {
+					r.arb = "Long HL/Short " + other.name
+				} else {
+					r.arb = "Short HL/Long " + other.name
+				}
+			}
+		}
+		rows = append(rows, r)
+	}
+
+	total := len(rows)
+	rows = m.ratesFilter.Apply(rows)
+	m.ratesTable.Sort(rows)
+	return rows, total
+}
+
+func (m Model) selectedCoin() string {
+	rows, _ := m.rateRows()
+	if len(rows) == 0 {
+		return ""
+	}
+	c, _, _ := table.Window(m.rateCursor, len(rows), 0)
+	return rows[c].coin
+}
+
+func (m Model) ratesView() string {
+	rows, total := m.rateRows()
+	if total == 0 {
+		return style.Dim.Render("  Loading predicted fundings...")
+	}
+
+	var b strings.Builder
+	visibleRows := m.height - 3 - historyPanelLines
+	if m.ratesFilter.Shown() {
+		b.WriteString(m.ratesFilter.View(len(rows), total))
+		b.WriteString("\n")
+		visibleRows--
+	}
+	if len(rows) == 0 {
+		b.WriteString(style.Dim.Render("  No coins match the filter"))
+		return b.String()
+	}
+
+	cursor, start, end := table.Window(m.rateCursor, len(rows), visibleRows)
+	rows[cursor].selected = true
+
+	b.WriteString(m.ratesTable.Header())
+	b.WriteString("\n")
+
+	for _, r := range rows[start:end] {
+		b.WriteString(m.ratesTable.Row(r))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.historyPanel(rows[cursor].coin, util.Now()))
+	return b.String()
+}
+
+// historyPanel shows the selected coin's funding chart and trailing averages.
+func (m Model) historyPanel(coin string, now time.Time) string {
+	history, _ := m.store.FundingHistory(coin)
+
+	var b strings.Builder
+	b.WriteString(style.White.Render(coin + " Funding History (30D)"))
+	b.WriteString("\n")
+	if len(history) == 0 {
+		b.WriteString(style.Dim.Render("  [enter] details and history  [</>] column  [s] sort  [m] payments"))
+		return b.String()
+	}
+
+	var avgs []string
+	for _, w := range []struct {
+		label  string
+		window time.Duration
+	}{{"1D", 24 * time.Hour}, {"7D", 7 * 24 * time.Hour}, {"30D", 30 * 24 * time.Hour}} {
+		cell := style.Dim.Render("-")
+		if avg, ok := m.store.FundingHistoryAverage(coin, w.window, now); ok {
+			apr := util.AnnualizeFunding(avg)
+			cell = style.PnlColor(apr).Render(fmt.Sprintf("%.2f%%", apr))
+		}
+		avgs = append(avgs, fmt.Sprintf("%s %s", style.Dim.Render("avg "+w.label+" APR:"), cell))
+	}
+	b.WriteString("  " + strings.Join(avgs, "   "))
+	b.WriteString("\n")
+
+	series := make([]api.TimeValue, len(history))
+	for i, e := range history {
+		series[i] = api.TimeValue{Time: e.Time, Value: e.FundingRate}
+	}
+	b.WriteString(ui.RenderSparkline(series, 120))
+	b.WriteString("\n")
+	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d hourly points, last %s  [enter] details  [s] sort  [m] payments",
+		len(history), util.FormatTime(history[len(history)-1].Time))))
+	return b.String()
+}
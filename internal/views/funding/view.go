@@ -4,8 +4,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
 )
 
 const (
@@ -16,77 +23,116 @@ const (
 	colPos     = 16
 )
 
+var paymentColumns = []table.Column[api.FundingPayment]{
+	{
+		Key: "time", Title: "TIME", Width: colTime, Left: true,
+		Cell:  func(fp api.FundingPayment) string { return util.FormatTimeFull(fp.Time) },
+		Style: func(api.FundingPayment) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(fp api.FundingPayment) int64 { return fp.Time }),
+	},
+	{
+		Key: "coin", Title: "COIN", Width: colCoin, Left: true,
+		Cell:  func(fp api.FundingPayment) string { return fp.Coin },
+		Style: func(api.FundingPayment) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(fp api.FundingPayment) string { return fp.Coin }),
+	},
+	{
+		Key: "payment", Title: "PAYMENT", Width: colPayment, Desc: true,
+		Cell:  func(fp api.FundingPayment) string { return util.FormatSignedUSD(fp.Usdc) },
+		Style: func(fp api.FundingPayment) lipgloss.Style { return style.PnlColor(fp.Usdc.Float64()) },
+		Cmp:   table.ByDecimal(func(fp api.FundingPayment) decimal.Decimal { return fp.Usdc }),
+	},
+	{
+		Key: "rate", Title: "RATE/24H", Width: colRate, Desc: true,
+		Cell:  func(fp api.FundingPayment) string { return util.FormatFundingRate(fp.FundingRate.Float64()) },
+		Style: func(fp api.FundingPayment) lipgloss.Style { return style.PnlColor(fp.FundingRate.Float64()) },
+		Cmp:   table.ByDecimal(func(fp api.FundingPayment) decimal.Decimal { return fp.FundingRate }),
+	},
+	{
+		// Ordered by size, long or short
+		Key: "position", Title: "POSITION", Width: colPos, Desc: true,
+		Cell: func(fp api.FundingPayment) string { return formatPosition(fp.Szi.Float64()) },
+		Cmp:  table.ByDecimal(func(fp api.FundingPayment) decimal.Decimal { return fp.Szi.Abs() }),
+	},
+}
+
+var paymentFilterFields = []filter.Field[api.FundingPayment]{
+	filter.Text("coin", func(fp api.FundingPayment) string { return fp.Coin }),
+	filter.Text("side", func(fp api.FundingPayment) string {
+		if fp.Szi.Sign() < 0 {
+			return "short"
+		}
+		return "long"
+	}),
+	filter.Decimal("payment", func(fp api.FundingPayment) decimal.Decimal { return fp.Usdc }),
+	// As shown: percent per 24h
+	filter.Number("rate", func(fp api.FundingPayment) float64 { return fp.FundingRate.Float64() * 100 * 24 }),
+	filter.Decimal("position", func(fp api.FundingPayment) decimal.Decimal { return fp.Szi.Abs() }),
+	filter.Time("time", func(fp api.FundingPayment) int64 { return fp.Time }),
+}
+
+// paymentRows returns the funding payments the filter keeps, in the
+// table's order, and how many there are.
+func (m Model) paymentRows() ([]api.FundingPayment, int) {
+	payments := m.store.FundingPayments()
+	total := len(payments)
+	payments = m.filter.Apply(payments)
+	m.table.Sort(payments)
+	return payments, total
+}
+
 func (m Model) View() string {
-	m.store.RLock()
-	payments := m.store.FundingPayments
-	m.store.RUnlock()
+	if m.rates {
+		return m.ratesView()
+	}
+
+	payments, total := m.paymentRows()
 
-	if len(payments) == 0 {
-		return style.Dim.Render("  No funding payments")
+	if total == 0 {
+		empty := style.Dim.Render("  No funding payments")
+		if err := m.store.FetchError(store.SourceFunding); err != nil {
+			empty = ui.RenderLoadError("funding payments", err)
+		}
+		return empty + "\n\n" + style.Dim.Render("  [m] funding rates")
 	}
 
 	var b strings.Builder
 
-	// Header
-	header := padRight("TIME", colTime) + "  " +
-		padRight("COIN", colCoin) + "  " +
-		padLeft("PAYMENT", colPayment) + "  " +
-		padLeft("RATE/24H", colRate) + "  " +
-		padLeft("POSITION", colPos)
-	b.WriteString(style.TableHeader.Render(header))
-	b.WriteString("\n")
-
 	// Visible range
 	visibleRows := m.height - 5
-	if visibleRows < 1 {
-		visibleRows = len(payments)
-	}
-	start := m.scroll
-	if start >= len(payments) {
-		start = len(payments) - 1
-	}
-	if start < 0 {
-		start = 0
-	}
-	end := start + visibleRows
-	if end > len(payments) {
-		end = len(payments)
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(payments), total))
+		b.WriteString("\n")
+		visibleRows--
 	}
+	b.WriteString(m.table.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.cursor, len(payments), visibleRows)
 
-	var totalPayment float64
+	var totalPayment decimal.Decimal
 	for _, fp := range payments {
-		totalPayment += util.ParseFloat(fp.Usdc)
+		totalPayment = totalPayment.Add(fp.Usdc)
 	}
 
-	for _, fp := range payments[start:end] {
-		payment := util.ParseFloat(fp.Usdc)
-		payStyle := style.PnlColor(payment)
-		rate := util.ParseFloat(fp.FundingRate)
-		rateStyle := style.PnlColor(rate)
-
-		cells := []string{
-			style.Dim.Render(padRight(util.FormatTimeFull(fp.Time), colTime)),
-			"  ",
-			style.White.Render(padRight(fp.Coin, colCoin)),
-			"  ",
-			payStyle.Render(padLeft(util.FormatSignedUSD(payment), colPayment)),
-			"  ",
-			rateStyle.Render(padLeft(util.FormatFundingRate(rate), colRate)),
-			"  ",
-			padLeft(formatPosition(util.ParseFloat(fp.Szi)), colPos),
+	for i, fp := range payments[start:end] {
+		if start+i == cursor {
+			b.WriteString(m.table.SelectedRow(fp))
+		} else {
+			b.WriteString(m.table.Row(fp))
 		}
-		b.WriteString(strings.Join(cells, ""))
 		b.WriteString("\n")
 	}
 
 	// Footer
 	b.WriteString("\n")
-	payStyle := style.PnlColor(totalPayment)
+	payStyle := style.PnlColor(totalPayment.Float64())
 	b.WriteString(fmt.Sprintf("  %s %s  %s",
 		style.White.Render("Total Funding:"),
 		payStyle.Render(util.FormatSignedUSD(totalPayment)),
 		style.Dim.Render(fmt.Sprintf("(%d payments)", len(payments))),
 	))
+	b.WriteString(style.Dim.Render("  [enter] details  [m] funding rates"))
 
 	return b.String()
 }
@@ -107,17 +153,3 @@ func formatPosition(val float64) string {
 	}
 	return fmt.Sprintf("%.6f", val)
 }
-
-func padRight(s string, width int) string {
-	if len(s) >= width {
-		return s
-	}
-	return s + strings.Repeat(" ", width-len(s))
-}
-
-func padLeft(s string, width int) string {
-	if len(s) >= width {
-		return s
-	}
-	return strings.Repeat(" ", width-len(s)) + s
-}
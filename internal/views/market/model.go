@@ -1,7 +1,11 @@
 package market
 
 import (
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
 	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -21,14 +25,37 @@ const defaultOIIndex = 3 // $1M
 
 type Model struct {
 	store      *store.Store
-	scroll     int
+	cursor     int
 	height     int
-	sortAsc    bool
+	table      table.Table[*store.Asset]
+	filter     filter.Bar[*store.Asset]
 	oiFilterIdx int // index into oiThresholds
+
+	// Watchlist mode: only the starred coins, with optional columns picked
+	// in the column picker
+	watchlist    config.Watchlist
+	watchMode    bool
+	watchCols    []watchColumn
+	watchTable   table.Table[*store.Asset]
+	picking      bool
+	pickerCursor int
+
+	// Movers mode: the biggest rises and falls over a chosen window
+	moversMode bool
+	moverIdx   int // index into moverWindows
 }
 
 func New(s *store.Store) Model {
-	return Model{store: s, oiFilterIdx: defaultOIIndex}
+	watchCols := watchColumns(s)
+	return Model{
+		store:       s,
+		table:       table.New("market", 2, marketColumns(s), config.SortKey{Column: "chg", Desc: true}),
+		filter:      filter.NewBar(filterFields(s)),
+		oiFilterIdx: defaultOIIndex,
+		watchCols:   watchCols,
+		watchTable:  newWatchTable(watchCols, nil, nil),
+		moverIdx:    defaultMoverIdx,
+	}
 }
 
 func (m Model) Init() tea.Cmd { return nil }
@@ -40,26 +67,112 @@ func (m Model) OIThreshold() float64 {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.picking {
+			return m.updatePicker(msg)
+		}
+		var cmd tea.Cmd
+		var used bool
+		if m.filter, cmd, used = m.filter.Update(msg); used {
+			m.cursor = 0
+			return m, cmd
+		}
 		switch msg.String() {
 		case "j", "down":
-			m.scroll++
+			if m.cursor < len(m.cursorRows())-1 {
+				m.cursor++
+			}
 		case "k", "up":
-			if m.scroll > 0 {
-				m.scroll--
+			if m.cursor > 0 {
+				m.cursor--
 			}
 		case "g":
-			m.scroll = 0
-		case "s":
-			m.sortAsc = !m.sortAsc
-			m.scroll = 0
+			m.cursor = 0
+		case "enter":
+			if a := m.selected(); a != nil {
+				return m, asset.Open(a.Name)
+			}
+		case "*":
+			if a := m.selected(); a != nil {
+				return m.toggleStar(a.Name)
+			}
+		case "m":
+			m.watchMode = !m.watchMode
+			m.moversMode = false
+			m.cursor = 0
+		case "v":
+			m.moversMode = !m.moversMode
+			m.watchMode = false
+			m.cursor = 0
+		case "[", "]":
+			if m.moversMode {
+				step := 1
+				if msg.String() == "[" {
+					step = len(moverWindows) - 1
+				}
+				m.moverIdx = (m.moverIdx + step) % len(moverWindows)
+				m.cursor = 0
+			}
+		case "c":
+			if m.watchMode {
+				m.picking = true
+			}
 		case "f":
-			m.oiFilterIdx = (m.oiFilterIdx + 1) % len(oiThresholds)
-			m.scroll = 0
+			if !m.watchMode {
+				m.oiFilterIdx = (m.oiFilterIdx + 1) % len(oiThresholds)
+				m.cursor = 0
+			}
+		default:
+			if m.moversMode {
+				// Ranked by change; there is no column to sort by
+				return m, nil
+			}
+			var cmd tea.Cmd
+			if m.watchMode {
+				m.watchTable, cmd = m.watchTable.Update(msg)
+			} else {
+				m.table, cmd = m.table.Update(msg)
+			}
+			if cmd != nil {
+				m.cursor = 0
+			}
+			return m, cmd
 		}
 	}
 	return m, nil
 }
 
+// cursorRows is the rows the cursor moves over, in the order they are
+// drawn.
+func (m Model) cursorRows() []*store.Asset {
+	if m.moversMode {
+		return m.moverRows()
+	}
+	rows, _ := m.rows()
+	return rows
+}
+
+// selected is the asset under the cursor, or nil with no rows.
+func (m Model) selected() *store.Asset {
+	rows := m.cursorRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	c, _, _ := table.Window(m.cursor, len(rows), 0)
+	return rows[c]
+}
+
+// SetSortPrefs restores the saved sort order.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.table = m.table.Restore(prefs)
+	m.watchTable = m.watchTable.Restore(prefs)
+}
+
+// Filtering reports whether the filter bar or the column picker has the
+// keyboard.
+func (m Model) Filtering() bool {
+	return m.filter.Editing() || m.picking
+}
+
 func (m *Model) SetHeight(h int) {
 	m.height = h
 }
@@ -0,0 +1,209 @@
+package market
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// changeWindow is a span the mid change is shown over.
+type changeWindow struct {
+	key   string // column and filter field name
+	label string
+	span  time.Duration
+}
+
+var (
+	change5m  = changeWindow{"chg5m", "5M", 5 * time.Minute}
+	change15m = changeWindow{"chg15m", "15M", 15 * time.Minute}
+	change1h  = changeWindow{"chg1h", "1H", time.Hour}
+	change4h  = changeWindow{"chg4h", "4H", 4 * time.Hour}
+
+	// changeWindows are the market table's intraday change columns
+	changeWindows = []changeWindow{change5m, change1h, change4h}
+
+	// moverWindows are the spans [ and ] step through in Movers mode
+	moverWindows = []changeWindow{change5m, change15m, change1h, change4h}
+)
+
+const (
+	defaultMoverIdx = 2 // 1h
+
+	// trendWindow is the span of the market table's sparklines
+	trendWindow = time.Hour
+	trendWidth  = 10
+)
+
+// changeColumn is the % mid change over w, from s's mid history. Coins
+// without history reaching back that far show "-" and sort last.
+func changeColumn(s *store.Store, w changeWindow) table.Column[*store.Asset] {
+	change := func(a *store.Asset) (float64, bool) { return s.MidChange(a.Name, w.span) }
+	return optionalPct(w.key, w.label+" %", 9, change, formatChg)
+}
+
+// trendColumn draws the mids over span as a sparkline, green when the coin
+// is up over it.
+func trendColumn(s *store.Store, span time.Duration) table.Column[*store.Asset] {
+	return table.Column[*store.Asset]{
+		Key: "trend", Title: "TREND " + strings.ToUpper(util.FormatWindow(span)), Width: trendWidth, Left: true,
+		Cell: func(a *store.Asset) string {
+			samples := s.MidSeries(a.Name, span)
+			if len(samples) < 2 {
+				return ""
+			}
+			values := make([]float64, len(samples))
+			for i, x := range samples {
+				values[i] = x.Value
+			}
+			return ui.Sparkline(values, trendWidth)
+		},
+		Style: func(a *store.Asset) lipgloss.Style {
+			pct, _ := s.MidChange(a.Name, span)
+			return style.PnlColor(pct)
+		},
+	}
+}
+
+// MoversMode reports whether the view ranks the biggest movers.
+func (m Model) MoversMode() bool {
+	return m.moversMode
+}
+
+func (m Model) moverWindow() changeWindow {
+	return moverWindows[m.moverIdx]
+}
+
+// moversPerSide is how many gainers and how many losers fit: each list has
+// a heading and a table header, with a blank line between them and before
+// the footer.
+func (m Model) moversPerSide() int {
+	lines := m.height - 7
+	if m.filter.Shown() {
+		lines--
+	}
+	return max(lines/2, 3)
+}
+
+// movers returns the assets over the OI threshold that the filter keeps
+// with the biggest mid rises and falls over the mover window, biggest
+// first, and how many had history reaching back that far.
+func (m Model) movers() (gainers, losers []*store.Asset, total int) {
+	market := m.store.Market()
+	if market == nil {
+		return nil, nil, 0
+	}
+	w := m.moverWindow()
+	threshold := m.OIThreshold()
+	change := make(map[string]float64)
+	var rows []*store.Asset
+	for i := range market.Assets {
+		a := &market.Assets[i]
+		if a.OpenInterestUSD < threshold {
+			continue
+		}
+		if pct, ok := m.store.MidChange(a.Name, w.span); ok {
+			change[a.Name] = pct
+			rows = append(rows, a)
+		}
+	}
+	total = len(rows)
+	rows = m.filter.Apply(rows)
+	slices.SortStableFunc(rows, func(a, b *store.Asset) int {
+		return cmp.Or(cmp.Compare(change[b.Name], change[a.Name]), cmp.Compare(a.Name, b.Name))
+	})
+
+	n := m.moversPerSide()
+	for _, a := range rows {
+		if len(gainers) == n || change[a.Name] <= 0 {
+			break
+		}
+		gainers = append(gainers, a)
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if a := rows[i]; len(losers) < n && change[a.Name] < 0 {
+			losers = append(losers, a)
+		}
+	}
+	return gainers, losers, total
+}
+
+// moverRows is the movers in the order moversView draws them, the gainers
+// then the losers, so the cursor indexes the row it highlights.
+func (m Model) moverRows() []*store.Asset {
+	gainers, losers, _ := m.movers()
+	return append(gainers, losers...)
+}
+
+// moversTable lays out a list of movers: the change and trend over w
+// after the asset and price, then the 24h change and volume for context.
+func (m Model) moversTable(w changeWindow) table.Table[*store.Asset] {
+	change := changeColumn(m.store, w)
+	trend := trendColumn(m.store, w.span)
+	cols := []table.Column[*store.Asset]{baseColumns[0], baseColumns[1], change, trend, baseColumns[2], contextColumns[0]}
+	// Ranked by the change, so no column offers a sort
+	for i := range cols {
+		cols[i].Cmp = nil
+	}
+	return table.New("movers", 2, cols)
+}
+
+func (m Model) moversView() string {
+	w := m.moverWindow()
+	gainers, losers, total := m.movers()
+	t := m.moversTable(w)
+
+	var b strings.Builder
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(gainers)+len(losers), total))
+		b.WriteString("\n")
+	}
+	if len(gainers)+len(losers) == 0 {
+		b.WriteString(style.Dim.Render(fmt.Sprintf("  No mids history reaching back %s yet. Movers appear once the app has run that long;", util.FormatWindow(w.span))))
+		b.WriteString("\n")
+		b.WriteString(style.Dim.Render("  run with --keep-mids to keep the history across restarts."))
+		b.WriteString("\n")
+	}
+
+	cursor, _, _ := table.Window(m.cursor, len(gainers)+len(losers), 0)
+	section := func(title string, rows []*store.Asset, offset int) {
+		if len(rows) == 0 {
+			return
+		}
+		b.WriteString(style.Cyan.Render(fmt.Sprintf("  %s over %s", title, util.FormatWindow(w.span))))
+		b.WriteString("\n")
+		b.WriteString(style.TableHeader.Render(padRight("#", colRank)))
+		b.WriteString(t.Header())
+		b.WriteString("\n")
+		for i, r := range rows {
+			b.WriteString(style.Dim.Render(padRight(fmt.Sprintf("%d", i+1), colRank)))
+			if offset+i == cursor {
+				b.WriteString(t.SelectedRow(r))
+			} else {
+				b.WriteString(t.Row(r))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	section("Gainers", gainers, 0)
+	section("Losers", losers, len(gainers))
+
+	threshold := m.OIThreshold()
+	filterLabel := "OFF"
+	if threshold > 0 {
+		filterLabel = "≥" + formatCompact(threshold)
+	}
+	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d with %s of history  ", total, util.FormatWindow(w.span))))
+	b.WriteString(style.Yellow.Render(fmt.Sprintf("[[/]] window: %s  [f] OI filter: %s", util.FormatWindow(w.span), filterLabel)))
+	b.WriteString(style.Dim.Render("  [v] all markets  [enter] details"))
+	return b.String()
+}
@@ -3,164 +3,199 @@ package market
 import (
 	"fmt"
 	"math"
-	"sort"
+	"slices"
 	"strings"
 
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
 )
 
-const (
-	colRank   = 4
-	colAsset  = 10
-	colPrice  = 14
-	colChg    = 10
-	colVol    = 14
-	colFund   = 10
-	colOI     = 14
-	colOracle = 14
-)
-
-type assetRow struct {
-	name      string
-	midPx     float64
-	prevDayPx float64
-	chg24h    float64
-	volume    float64
-	funding   float64
-	openInt   float64
-	oraclePx  float64
+const colRank = 4
+
+// baseColumns lead both the market and the Watchlist tables.
+var baseColumns = []table.Column[*store.Asset]{
+	{
+		Key: "asset", Title: "ASSET", Width: 10, Left: true,
+		Cell:  func(a *store.Asset) string { return a.Name },
+		Style: func(*store.Asset) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(a *store.Asset) string { return a.Name }),
+	},
+	{
+		Key: "price", Title: "PRICE", Width: 12, Desc: true,
+		Cell: func(a *store.Asset) string { return formatPrice(a.Price) },
+		Cmp:  table.By(func(a *store.Asset) float64 { return a.Price }),
+	},
+	{
+		Key: "chg", Title: "24H %", Width: 10, Desc: true,
+		Cell:  func(a *store.Asset) string { return formatChg(a.Change24h) },
+		Style: func(a *store.Asset) lipgloss.Style { return style.PnlColor(a.Change24h) },
+		Cmp:   table.By(func(a *store.Asset) float64 { return a.Change24h }),
+	},
 }
 
-func (m Model) View() string {
-	m.store.RLock()
-	meta := m.store.MetaAndAssetCtxs
-	mids := m.store.AllMids
-	m.store.RUnlock()
+// contextColumns follow the intraday columns in the market table.
+var contextColumns = []table.Column[*store.Asset]{
+	{
+		Key: "vol", Title: "24H VOL", Width: 14, Desc: true,
+		Cell:  func(a *store.Asset) string { return formatCompact(a.Volume24h) },
+		Style: func(*store.Asset) lipgloss.Style { return style.Cyan },
+		Cmp:   table.By(func(a *store.Asset) float64 { return a.Volume24h }),
+	},
+	{
+		Key: "funding", Title: "FUND/24H", Width: 10, Desc: true,
+		Cell:  func(a *store.Asset) string { return util.FormatFundingRate(a.Funding) },
+		Style: func(a *store.Asset) lipgloss.Style { return style.PnlColor(a.Funding) },
+		Cmp:   table.By(func(a *store.Asset) float64 { return a.Funding }),
+	},
+	{
+		Key: "oi", Title: "OPEN INT", Width: 14, Desc: true,
+		Cell: func(a *store.Asset) string { return formatCompact(a.OpenInterestUSD) },
+		Cmp:  table.By(func(a *store.Asset) float64 { return a.OpenInterestUSD }),
+	},
+	{
+		Key: "oracle", Title: "ORACLE", Width: 14, Desc: true,
+		Cell:  func(a *store.Asset) string { return formatPrice(a.OraclePx) },
+		Style: func(*store.Asset) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(a *store.Asset) float64 { return a.OraclePx }),
+	},
+}
 
-	if meta == nil || len(meta.AssetCtxs) == 0 {
-		return style.Dim.Render("  Loading market data...")
+// marketColumns are the market table's columns over the snapshot's assets:
+// the base columns, the intraday changes and trend from s's mid history,
+// then the asset contexts.
+func marketColumns(s *store.Store) []table.Column[*store.Asset] {
+	cols := slices.Clone(baseColumns)
+	for _, w := range changeWindows {
+		cols = append(cols, changeColumn(s, w))
 	}
+	cols = append(cols, trendColumn(s, trendWindow))
+	return append(cols, contextColumns...)
+}
 
-	// Build rows
-	var rows []assetRow
-	for i, asset := range meta.Meta.Universe {
-		if i >= len(meta.AssetCtxs) {
-			break
-		}
-		ctx := meta.AssetCtxs[i]
-
-		midPx := 0.0
-		if v, ok := mids[asset.Name]; ok {
-			midPx = util.ParseFloat(v)
-		}
-
-		prevDay := util.ParseFloat(ctx.PrevDayPx)
-		chg := 0.0
-		if prevDay > 0 {
-			chg = ((midPx - prevDay) / prevDay) * 100
-		}
+// filterFields match what the table shows: funding per 24h and changes in
+// percent, open interest in USD.
+func filterFields(s *store.Store) []filter.Field[*store.Asset] {
+	fields := []filter.Field[*store.Asset]{
+		filter.Text("coin", func(a *store.Asset) string { return a.Name }),
+		filter.Number("price", func(a *store.Asset) float64 { return a.Price }),
+		filter.Number("chg", func(a *store.Asset) float64 { return a.Change24h }),
+	}
+	for _, w := range changeWindows {
+		fields = append(fields, filter.Number(w.key, func(a *store.Asset) float64 {
+			pct, _ := s.MidChange(a.Name, w.span)
+			return pct
+		}))
+	}
+	return append(fields,
+		filter.Number("vol", func(a *store.Asset) float64 { return a.Volume24h }),
+		filter.Number("funding", func(a *store.Asset) float64 { return a.Funding * 100 * 24 }),
+		filter.Number("oi", func(a *store.Asset) float64 { return a.OpenInterestUSD }),
+		filter.Number("lev", func(a *store.Asset) float64 { return float64(a.MaxLeverage) }),
+	)
+}
 
-		rows = append(rows, assetRow{
-			name:      asset.Name,
-			midPx:     midPx,
-			prevDayPx: prevDay,
-			chg24h:    chg,
-			volume:    util.ParseFloat(ctx.DayNtlVlm),
-			funding:   util.ParseFloat(ctx.Funding),
-			openInt:   util.ParseFloat(ctx.OpenInterest),
-			oraclePx:  util.ParseFloat(ctx.OraclePx),
-		})
+// rows returns the assets over the OI threshold, or in Watchlist mode the
+// starred ones, that the filter keeps, in the active table's order, and how
+// many there were before filtering. In Movers mode they are the gainers
+// then the losers. Rows point into the snapshot, which is
+// never modified.
+func (m Model) rows() ([]*store.Asset, int) {
+	market := m.store.Market()
+	if market == nil {
+		return nil, 0
 	}
-
-	// Filter by OI threshold
 	threshold := m.OIThreshold()
-	if threshold > 0 {
-		filtered := rows[:0]
-		for _, r := range rows {
-			if r.openInt*r.midPx >= threshold {
-				filtered = append(filtered, r)
-			}
+	rows := make([]*store.Asset, 0, len(market.Assets))
+	for i := range market.Assets {
+		a := &market.Assets[i]
+		if m.watchMode && m.watched(a.Name) || !m.watchMode && a.OpenInterestUSD >= threshold {
+			rows = append(rows, a)
 		}
-		rows = filtered
 	}
+	total := len(rows)
+	rows = m.filter.Apply(rows)
+	m.activeTable().Sort(rows)
+	return rows, total
+}
 
-	// Sort by 24H % change
-	sort.Slice(rows, func(i, j int) bool {
-		if m.sortAsc {
-			return rows[i].chg24h < rows[j].chg24h
-		}
-		return rows[i].chg24h > rows[j].chg24h
-	})
+func (m Model) activeTable() table.Table[*store.Asset] {
+	if m.watchMode {
+		return m.watchTable
+	}
+	return m.table
+}
 
-	arrow := " ▼"
-	if m.sortAsc {
-		arrow = " ▲"
+func (m Model) View() string {
+	if m.store.Market() == nil {
+		if err := m.store.FetchError(store.SourceMeta); err != nil {
+			return ui.RenderLoadError("market data", err)
+		}
+		return style.Dim.Render("  Loading market data...")
 	}
+	if m.picking {
+		return m.pickerView()
+	}
+	if m.moversMode {
+		return m.moversView()
+	}
+	rows, total := m.rows()
+	t := m.activeTable()
 
 	var b strings.Builder
+	if m.watchMode && len(m.watchlist.Coins) == 0 {
+		b.WriteString(style.Dim.Render("  No coins in the watchlist. Press [*] on a row in all markets to star one."))
+		b.WriteString("\n\n")
+		b.WriteString(style.Dim.Render("  [m] all markets"))
+		return b.String()
+	}
 
-	// Header
-	header := padRight("#", colRank) +
-		padRight("ASSET", colAsset) +
-		padLeft("PRICE", colPrice) +
-		"  " + padLeft("24H %"+arrow, colChg) +
-		"  " + padLeft("24H VOL", colVol) +
-		"  " + padLeft("FUND/24H", colFund) +
-		"  " + padLeft("OPEN INT", colOI) +
-		"  " + padLeft("ORACLE", colOracle)
-	b.WriteString(style.TableHeader.Render(header))
-	b.WriteString("\n")
-
-	// Visible range
 	visibleRows := m.height - 3
-	if visibleRows < 1 {
-		visibleRows = len(rows)
-	}
-	start := m.scroll
-	if start >= len(rows) {
-		start = len(rows) - 1
-	}
-	if start < 0 {
-		start = 0
-	}
-	end := start + visibleRows
-	if end > len(rows) {
-		end = len(rows)
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(rows), total))
+		b.WriteString("\n")
+		visibleRows--
 	}
 
+	// Header
+	b.WriteString(style.TableHeader.Render(padRight("#", colRank)))
+	b.WriteString(t.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.cursor, len(rows), visibleRows)
 	for i, r := range rows[start:end] {
-		rank := start + i + 1
-		chgStyle := style.PnlColor(r.chg24h)
-		fundStyle := style.PnlColor(r.funding)
-
-		cells := []string{
-			style.Dim.Render(padRight(fmt.Sprintf("%d", rank), colRank)),
-			style.White.Render(padRight(r.name, colAsset)),
-			padLeft(formatPrice(r.midPx), colPrice),
-			"  ",
-			chgStyle.Render(padLeft(formatChg(r.chg24h), colChg)),
-			"  ",
-			style.Cyan.Render(padLeft(formatCompact(r.volume), colVol)),
-			"  ",
-			fundStyle.Render(padLeft(util.FormatFundingRate(r.funding), colFund)),
-			"  ",
-			padLeft(formatCompact(r.openInt), colOI),
-			"  ",
-			style.Dim.Render(padLeft(formatPrice(r.oraclePx), colOracle)),
+		rank := fmt.Sprintf("%d", start+i+1)
+		if !m.watchMode && m.watched(r.Name) {
+			b.WriteString(style.Dim.Render(rank) + style.Yellow.Render("★") + strings.Repeat(" ", max(colRank-len(rank)-1, 0)))
+		} else {
+			b.WriteString(style.Dim.Render(padRight(rank, colRank)))
+		}
+		if start+i == cursor {
+			b.WriteString(t.SelectedRow(r))
+		} else {
+			b.WriteString(t.Row(r))
 		}
-		b.WriteString(strings.Join(cells, ""))
 		b.WriteString("\n")
 	}
 
 	// Footer
 	b.WriteString("\n")
+	if m.watchMode {
+		b.WriteString(style.Dim.Render(fmt.Sprintf("  %d watched  (sorted by %s)  [*] unstar  [c] columns  [m] all markets  [enter] details", len(rows), t.SortLabel())))
+		return b.String()
+	}
+	threshold := m.OIThreshold()
 	filterLabel := "OFF"
 	if threshold > 0 {
 		filterLabel = "≥" + formatCompact(threshold)
 	}
-	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d assets  (sorted by 24h %%)  ", len(rows))))
+	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d assets  (sorted by %s)  ", len(rows), t.SortLabel())))
 	b.WriteString(style.Yellow.Render(fmt.Sprintf("[f] OI filter: %s", filterLabel)))
+	b.WriteString(style.Dim.Render("  [*] star  [m] watchlist  [v] movers  [enter] details"))
 
 	return b.String()
 }
@@ -218,10 +253,3 @@ func padRight(s string, width int) string {
 	}
 	return s + strings.Repeat(" ", width-len(s))
 }
-
-func padLeft(s string, width int) string {
-	if len(s) >= width {
-		return s
-	}
-	return strings.Repeat(" ", width-len(s)) + s
-}
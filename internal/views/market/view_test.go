@@ -0,0 +1,165 @@
+package market
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestWatchlistView(t *testing.T) {
+	s := viewtest.Store(t)
+
+	// An hour after the store loaded, BTC and ETH have moved and BTC's open
+	// interest has grown
+	later := viewtest.Clock.Add(time.Hour)
+	util.Now = func() time.Time { return later }
+	s.UpdateMids(api.AllMids{"BTC": decimal.MustParse("98222.5"), "ETH": decimal.MustParse("3604")})
+	meta := &api.MetaAndAssetCtxs{}
+	for _, a := range s.Market().Assets {
+		if a.Name == "BTC" {
+			a.Ctx.OpenInterest = a.Ctx.OpenInterest.Mul(decimal.MustParse("1.05"))
+		}
+		meta.Meta.Universe = append(meta.Meta.Universe, a.AssetMeta)
+		meta.AssetCtxs = append(meta.AssetCtxs, a.Ctx)
+	}
+	s.SetMetaAndAssetCtxs(meta)
+
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m.SetWatchlist(config.Watchlist{Coins: []string{"BTC", "ETH", "HYPE"}, Hidden: []string{"premium"}})
+		m, _ = m.Update(viewtest.Key("m"))
+		return m.View()
+	})
+}
+
+func TestStarAndColumns(t *testing.T) {
+	s := viewtest.Store(t)
+	m := New(s)
+	m.SetHeight(30)
+
+	watchlist := func(cmd tea.Cmd) config.Watchlist {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("no watchlist change")
+		}
+		msg, ok := cmd().(WatchlistChangedMsg)
+		if !ok {
+			t.Fatalf("got %T, want WatchlistChangedMsg", cmd())
+		}
+		return msg.Watchlist
+	}
+
+	// The top row by 24h change
+	rows, _ := m.rows()
+	top := rows[0].Name
+	m, cmd := m.Update(viewtest.Key("*"))
+	if w := watchlist(cmd); !slices.Equal(w.Coins, []string{top}) {
+		t.Fatalf("watchlist = %v, want [%s]", w.Coins, top)
+	}
+
+	m, _ = m.Update(viewtest.Key("m"))
+	if rows, _ := m.rows(); len(rows) != 1 || rows[0].Name != top {
+		t.Fatalf("watchlist rows = %v", rows)
+	}
+
+	m, _ = m.Update(viewtest.Key("c"))
+	if !m.Filtering() {
+		t.Fatal("column picker doesn't hold the keyboard")
+	}
+	m, _ = m.Update(viewtest.Key("j"))
+	m, cmd = m.Update(viewtest.Key(" "))
+	if w := watchlist(cmd); !slices.Equal(w.Hidden, []string{"basis"}) {
+		t.Errorf("hidden = %v, want [basis]", w.Hidden)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Filtering() || strings.Contains(viewtest.Strip(m.View()), "BASIS") {
+		t.Error("basis column still shown after hiding it")
+	}
+
+	m, cmd = m.Update(viewtest.Key("*"))
+	if w := watchlist(cmd); len(w.Coins) != 0 {
+		t.Errorf("watchlist after unstarring = %v", w.Coins)
+	}
+}
+
+func TestMoversView(t *testing.T) {
+	s := viewtest.Store(t)
+
+	// An hour of mids after the store loaded, every 10 minutes
+	start := map[string]float64{}
+	for _, a := range s.Market().Assets {
+		start[a.Name] = a.Price
+	}
+	moves := map[string]float64{"BTC": 1.2, "SOL": 3.5, "HYPE": -2.1, "ETH": -0.8, "DOGE": 0.4, "AVAX": -4.2}
+	for step := 1; step <= 6; step++ {
+		at := viewtest.Clock.Add(time.Duration(step) * 10 * time.Minute)
+		util.Now = func() time.Time { return at }
+		mids := api.AllMids{}
+		for coin, pct := range moves {
+			// Up or down to the final move, with a wobble on the way
+			f := float64(step)/6 + 0.1*float64(step%2)
+			if step == 6 {
+				f = 1
+			}
+			mids[coin] = decimal.NewFromFloat(start[coin] * (1 + pct/100*f))
+		}
+		s.UpdateMids(mids)
+	}
+
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m, _ = m.Update(viewtest.Key("v"))
+		return m.View()
+	})
+
+	m := New(s)
+	m.SetHeight(30)
+	m, _ = m.Update(viewtest.Key("v"))
+	rows := m.moverRows()
+	if len(rows) == 0 || rows[0].Name != "SOL" || rows[len(rows)-1].Name != "ETH" {
+		t.Errorf("movers = %v", rows)
+	}
+
+	// The cursor runs down the gainers into the losers: three gainers, so
+	// the fourth row is the biggest loser
+	for range 3 {
+		m, _ = m.Update(viewtest.Key("j"))
+	}
+	if !strings.Contains(viewtest.Strip(m.View()), "Losers") {
+		t.Fatal("no losers section")
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a losers row did nothing")
+	}
+	if msg, ok := cmd().(asset.OpenMsg); !ok || msg.Coin != "AVAX" {
+		t.Errorf("enter = %#v, want asset.OpenMsg for AVAX", cmd())
+	}
+
+	// [ steps back through the shorter windows
+	m, _ = m.Update(viewtest.Key("["))
+	m, _ = m.Update(viewtest.Key("["))
+	if m.moverWindow() != change5m {
+		t.Errorf("window after [[ = %v, want 5m", m.moverWindow())
+	}
+}
@@ -0,0 +1,193 @@
+package market
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WatchlistChangedMsg reports a starred coin or column change so the app
+// can save it.
+type WatchlistChangedMsg struct {
+	Watchlist config.Watchlist
+}
+
+// watchColumn is an optional column of the Watchlist mode.
+type watchColumn struct {
+	table.Column[*store.Asset]
+	About string // what the column picker says it shows
+}
+
+// oiChangeWindow is the span of the Watchlist mode's open interest change
+// column.
+const oiChangeWindow = time.Hour
+
+// watchColumns returns the Watchlist mode's optional columns. The change
+// columns read the store's mid and open interest histories, so they show
+// "-" until the app has run for an hour.
+func watchColumns(s *store.Store) []watchColumn {
+	oiChange := func(a *store.Asset) (float64, bool) { return s.OpenInterestChange(a.Name, oiChangeWindow) }
+	return []watchColumn{
+		{changeColumn(s, change1h), "mid change over the last hour"},
+		{optionalPct("basis", "BASIS", 10, basis, func(v float64) string { return fmt.Sprintf("%+.3f%%", v) }), "mark less oracle, % of oracle"},
+		{optionalPct("premium", "PREMIUM", 10, premium, func(v float64) string { return fmt.Sprintf("%+.4f%%", v) }), "premium the funding rate is derived from"},
+		{optionalPct("fundapr", "FUND APR", 10, fundingAPR, func(v float64) string { return fmt.Sprintf("%.2f%%", v) }), "current funding, annualized"},
+		{optionalPct("oichg", "OI 1H %", 9, oiChange, formatChg), "open interest change over the last hour"},
+		{table.Column[*store.Asset]{
+			Key: "voloi", Title: "VOL/OI", Width: 8, Desc: true,
+			Cell: func(a *store.Asset) string {
+				if v, ok := volumeToOI(a); ok {
+					return fmt.Sprintf("%.2fx", v)
+				}
+				return "-"
+			},
+			Cmp:     table.By(func(a *store.Asset) float64 { v, _ := volumeToOI(a); return v }),
+			Missing: func(a *store.Asset) bool { _, ok := volumeToOI(a); return !ok },
+		}, "24h volume over open interest"},
+	}
+}
+
+// optionalPct returns a signed, colored column of a value rows may lack.
+func optionalPct(key, title string, width int, value func(*store.Asset) (float64, bool), format func(float64) string) table.Column[*store.Asset] {
+	return table.Column[*store.Asset]{
+		Key: key, Title: title, Width: width, Desc: true,
+		Cell: func(a *store.Asset) string {
+			if v, ok := value(a); ok {
+				return format(v)
+			}
+			return "-"
+		},
+		Style: func(a *store.Asset) lipgloss.Style {
+			v, _ := value(a)
+			return style.PnlColor(v)
+		},
+		Cmp:     table.By(func(a *store.Asset) float64 { v, _ := value(a); return v }),
+		Missing: func(a *store.Asset) bool { _, ok := value(a); return !ok },
+	}
+}
+
+func basis(a *store.Asset) (float64, bool) {
+	if a.OraclePx == 0 {
+		return 0, false
+	}
+	return (a.Ctx.MarkPx.Float64() - a.OraclePx) / a.OraclePx * 100, true
+}
+
+func premium(a *store.Asset) (float64, bool) {
+	return a.Ctx.Premium.Float64() * 100, true
+}
+
+func fundingAPR(a *store.Asset) (float64, bool) {
+	return util.AnnualizeFunding(a.Funding), true
+}
+
+func volumeToOI(a *store.Asset) (float64, bool) {
+	if a.OpenInterestUSD == 0 {
+		return 0, false
+	}
+	return a.Volume24h / a.OpenInterestUSD, true
+}
+
+// newWatchTable returns the Watchlist mode's table: asset, price and 24h
+// change, then the optional columns not hidden, sorted by keys where they
+// still name a column.
+func newWatchTable(optional []watchColumn, hidden []string, keys []config.SortKey) table.Table[*store.Asset] {
+	cols := slices.Clone(baseColumns)
+	for _, c := range optional {
+		if !slices.Contains(hidden, c.Key) {
+			cols = append(cols, c.Column)
+		}
+	}
+	t := table.New("watchlist", 2, cols, config.SortKey{Column: "chg", Desc: true})
+	if len(keys) > 0 {
+		t = t.Restore(config.SortPrefs{t.Name(): keys})
+	}
+	return t
+}
+
+// SetWatchlist restores the saved watchlist.
+func (m *Model) SetWatchlist(w config.Watchlist) {
+	m.watchlist = config.Watchlist{Coins: slices.Clone(w.Coins), Hidden: slices.Clone(w.Hidden)}
+	m.watchTable = newWatchTable(m.watchCols, m.watchlist.Hidden, m.watchTable.Keys())
+}
+
+// Watchlist returns the starred coins and hidden columns.
+func (m Model) Watchlist() config.Watchlist {
+	return config.Watchlist{Coins: slices.Clone(m.watchlist.Coins), Hidden: slices.Clone(m.watchlist.Hidden)}
+}
+
+// WatchMode reports whether the view shows only the watchlist.
+func (m Model) WatchMode() bool {
+	return m.watchMode
+}
+
+func (m Model) watched(coin string) bool {
+	return slices.Contains(m.watchlist.Coins, coin)
+}
+
+// toggleStar adds coin to the watchlist or removes it.
+func (m Model) toggleStar(coin string) (Model, tea.Cmd) {
+	if i := slices.Index(m.watchlist.Coins, coin); i >= 0 {
+		m.watchlist.Coins = slices.Delete(slices.Clone(m.watchlist.Coins), i, i+1)
+	} else {
+		m.watchlist.Coins = append(slices.Clone(m.watchlist.Coins), coin)
+	}
+	return m, m.watchlistChanged()
+}
+
+func (m Model) watchlistChanged() tea.Cmd {
+	w := m.Watchlist()
+	return func() tea.Msg { return WatchlistChangedMsg{Watchlist: w} }
+}
+
+// updatePicker handles keys while the column picker is open.
+func (m Model) updatePicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.pickerCursor = min(m.pickerCursor+1, len(m.watchCols)-1)
+	case "k", "up":
+		m.pickerCursor = max(m.pickerCursor-1, 0)
+	case " ", "enter", "x":
+		key := m.watchCols[m.pickerCursor].Key
+		hidden := slices.Clone(m.watchlist.Hidden)
+		if i := slices.Index(hidden, key); i >= 0 {
+			hidden = slices.Delete(hidden, i, i+1)
+		} else {
+			hidden = append(hidden, key)
+		}
+		m.watchlist.Hidden = hidden
+		m.watchTable = newWatchTable(m.watchCols, hidden, m.watchTable.Keys())
+		return m, m.watchlistChanged()
+	case "esc", "c", "q":
+		m.picking = false
+	}
+	return m, nil
+}
+
+// pickerView lists the optional columns with whether each is shown.
+func (m Model) pickerView() string {
+	var b []string
+	b = append(b, style.Cyan.Render("  Watchlist columns"), "")
+	for i, c := range m.watchCols {
+		mark := "[x]"
+		if slices.Contains(m.watchlist.Hidden, c.Key) {
+			mark = "[ ]"
+		}
+		line := fmt.Sprintf("  %s %-9s %s", mark, c.Title, style.Dim.Render(c.About))
+		if i == m.pickerCursor {
+			line = fmt.Sprintf("  %s %-9s ", mark, c.Title)
+			line = style.Selected.Render(line) + style.Dim.Render(c.About)
+		}
+		b = append(b, line)
+	}
+	b = append(b, "", style.Dim.Render("  [space] show/hide  [j/k] move  [esc] done"))
+	return lipgloss.JoinVertical(lipgloss.Left, b...)
+}
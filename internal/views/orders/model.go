@@ -1,18 +1,29 @@
 package orders
 
 import (
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
 	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type Model struct {
 	store  *store.Store
-	scroll int
+	cursor int
 	height int
+	table  table.Table[api.OpenOrder]
+	filter filter.Bar[api.OpenOrder]
 }
 
 func New(s *store.Store) Model {
-	return Model{store: s}
+	return Model{
+		store:  s,
+		table:  table.New("orders", 1, columns, config.SortKey{Column: "time", Desc: true}),
+		filter: filter.NewBar(filterFields),
+	}
 }
 
 func (m Model) Init() tea.Cmd { return nil }
@@ -20,18 +31,49 @@ func (m Model) Init() tea.Cmd { return nil }
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		var cmd tea.Cmd
+		var used bool
+		if m.filter, cmd, used = m.filter.Update(msg); used {
+			m.cursor = 0
+			return m, cmd
+		}
 		switch msg.String() {
 		case "j", "down":
-			m.scroll++
+			if rows, _ := m.rows(); m.cursor < len(rows)-1 {
+				m.cursor++
+			}
 		case "k", "up":
-			if m.scroll > 0 {
-				m.scroll--
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g":
+			m.cursor = 0
+		case "enter":
+			if rows, _ := m.rows(); len(rows) > 0 {
+				c, _, _ := table.Window(m.cursor, len(rows), 0)
+				return m, asset.Open(rows[c].Coin)
+			}
+		default:
+			var cmd tea.Cmd
+			if m.table, cmd = m.table.Update(msg); cmd != nil {
+				m.cursor = 0
 			}
+			return m, cmd
 		}
 	}
 	return m, nil
 }
 
+// SetSortPrefs restores the saved sort order.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.table = m.table.Restore(prefs)
+}
+
+// Filtering reports whether the filter bar has the keyboard.
+func (m Model) Filtering() bool {
+	return m.filter.Editing()
+}
+
 func (m *Model) SetHeight(h int) {
 	m.height = h
 }
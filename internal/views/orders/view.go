@@ -4,84 +4,154 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
 )
 
-func (m Model) View() string {
-	m.store.RLock()
-	orders := m.store.OpenOrders
-	m.store.RUnlock()
+func isSell(o api.OpenOrder) bool {
+	return o.Side == "A" || o.Side == "sell"
+}
 
-	if len(orders) == 0 {
-		return style.Dim.Render("  No open orders")
+func orderType(o api.OpenOrder) string {
+	if o.IsTrigger {
+		return "trigger"
 	}
+	return o.OrderType
+}
 
-	var b strings.Builder
-
-	header := fmt.Sprintf("%-9s %-6s %-8s %12s %12s %12s %-7s %-16s",
-		"COIN", "SIDE", "TYPE", "SIZE", "PRICE", "TRIGGER", "REDUCE", "TIME",
-	)
-	b.WriteString(style.TableHeader.Render(header))
-	b.WriteString("\n")
-
-	visibleRows := m.height - 3
-	if visibleRows < 1 {
-		visibleRows = len(orders)
-	}
-	start := m.scroll
-	if start >= len(orders) {
-		start = len(orders) - 1
-	}
-	if start < 0 {
-		start = 0
-	}
-	end := start + visibleRows
-	if end > len(orders) {
-		end = len(orders)
+func reduceOnly(o api.OpenOrder) string {
+	if o.ReduceOnly {
+		return "yes"
 	}
+	return ""
+}
 
-	for _, o := range orders[start:end] {
-		sideStyle := style.Green
-		if o.Side == "A" || o.Side == "sell" {
-			sideStyle = style.Red
-		}
-		side := "BUY"
-		if o.Side == "A" || o.Side == "sell" {
-			side = "SELL"
-		}
+var columns = []table.Column[api.OpenOrder]{
+	{
+		Key: "coin", Title: "COIN", Width: 9, Left: true,
+		Cell:  func(o api.OpenOrder) string { return o.Coin },
+		Style: func(api.OpenOrder) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(o api.OpenOrder) string { return o.Coin }),
+	},
+	{
+		Key: "side", Title: "SIDE", Width: 6, Left: true,
+		Cell: func(o api.OpenOrder) string {
+			if isSell(o) {
+				return "SELL"
+			}
+			return "BUY"
+		},
+		Style: func(o api.OpenOrder) lipgloss.Style {
+			if isSell(o) {
+				return style.Red
+			}
+			return style.Green
+		},
+		Cmp: table.By(func(o api.OpenOrder) string { return o.Side }),
+	},
+	{
+		Key: "type", Title: "TYPE", Width: 8, Left: true,
+		Cell: orderType,
+		Cmp:  table.By(orderType),
+	},
+	{
+		Key: "size", Title: "SIZE", Width: 12, Desc: true,
+		Cell: func(o api.OpenOrder) string { return util.FormatSize(o.Sz) },
+		Cmp:  table.ByDecimal(func(o api.OpenOrder) decimal.Decimal { return o.Sz }),
+	},
+	{
+		Key: "price", Title: "PRICE", Width: 12, Desc: true,
+		Cell: func(o api.OpenOrder) string { return util.FormatPrice(o.LimitPx) },
+		Cmp:  table.ByDecimal(func(o api.OpenOrder) decimal.Decimal { return o.LimitPx }),
+	},
+	{
+		Key: "trigger", Title: "TRIGGER", Width: 12, Desc: true,
+		Cell: func(o api.OpenOrder) string {
+			if o.TriggerPx.IsZero() {
+				return "-"
+			}
+			return util.FormatPrice(o.TriggerPx)
+		},
+		Cmp:     table.ByDecimal(func(o api.OpenOrder) decimal.Decimal { return o.TriggerPx }),
+		Missing: func(o api.OpenOrder) bool { return o.TriggerPx.IsZero() },
+	},
+	{
+		Key: "reduce", Title: "REDUCE", Width: 7, Left: true,
+		Cell: reduceOnly,
+		Cmp:  table.By(reduceOnly),
+	},
+	{
+		Key: "time", Title: "TIME", Width: 16, Left: true, Desc: true,
+		Cell: func(o api.OpenOrder) string { return util.FormatTime(o.Timestamp) },
+		Cmp:  table.By(func(o api.OpenOrder) int64 { return o.Timestamp }),
+	},
+}
 
-		trigger := "-"
-		if o.TriggerPx != "" && o.TriggerPx != "0" {
-			trigger = util.FormatPrice(util.ParseFloat(o.TriggerPx))
+var filterFields = []filter.Field[api.OpenOrder]{
+	filter.Text("coin", func(o api.OpenOrder) string { return o.Coin }),
+	filter.Text("side", func(o api.OpenOrder) string {
+		if isSell(o) {
+			return "sell"
 		}
+		return "buy"
+	}),
+	filter.Text("type", orderType),
+	filter.Decimal("size", func(o api.OpenOrder) decimal.Decimal { return o.Sz }),
+	filter.Decimal("price", func(o api.OpenOrder) decimal.Decimal { return o.LimitPx }),
+	filter.Decimal("trigger", func(o api.OpenOrder) decimal.Decimal { return o.TriggerPx }),
+	filter.Time("time", func(o api.OpenOrder) int64 { return o.Timestamp }),
+}
 
-		reduce := " "
-		if o.ReduceOnly {
-			reduce = "yes"
-		}
+// rows returns the open orders the filter keeps, in the table's order, and
+// how many there are.
+func (m Model) rows() ([]api.OpenOrder, int) {
+	orders := m.store.OpenOrders()
+	total := len(orders)
+	orders = m.filter.Apply(orders)
+	m.table.Sort(orders)
+	return orders, total
+}
+
+func (m Model) View() string {
+	orders, total := m.rows()
 
-		orderType := o.OrderType
-		if o.IsTrigger {
-			orderType = "trigger"
+	if total == 0 {
+		if err := m.store.FetchError(store.SourceOrders); err != nil {
+			return ui.RenderLoadError("open orders", err)
 		}
+		return style.Dim.Render("  No open orders")
+	}
+	var b strings.Builder
 
-		row := fmt.Sprintf("%-9s %s %-8s %12s %12s %12s %-7s %-16s",
-			style.White.Render(o.Coin),
-			sideStyle.Render(fmt.Sprintf("%-6s", side)),
-			orderType,
-			util.FormatSize(util.ParseFloat(o.Sz)),
-			util.FormatPrice(util.ParseFloat(o.LimitPx)),
-			trigger,
-			reduce,
-			util.FormatTime(o.Timestamp),
-		)
-		b.WriteString(row)
+	visibleRows := m.height - 3
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(orders), total))
+		b.WriteString("\n")
+		visibleRows--
+	}
+	b.WriteString(m.table.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.cursor, len(orders), visibleRows)
+
+	for i, o := range orders[start:end] {
+		if start+i == cursor {
+			b.WriteString(m.table.SelectedRow(o))
+		} else {
+			b.WriteString(m.table.Row(o))
+		}
 		b.WriteString("\n")
 	}
 
 	b.WriteString("\n")
-	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d open orders", len(orders))))
+	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d open orders  [enter] details", len(orders))))
 
 	return b.String()
 }
@@ -2,11 +2,13 @@ package portfolio
 
 import (
 	"fmt"
-	"math"
 	"strings"
 
 	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
 )
 
@@ -15,10 +17,8 @@ var separator50 = strings.Repeat("─", 50)
 func (m Model) View() string {
 	var b strings.Builder
 
-	m.store.RLock()
-	periods := m.store.Portfolio
-	fees := m.store.UserFees
-	m.store.RUnlock()
+	periods := m.store.Portfolio()
+	fees := m.store.UserFees()
 
 	// Performance summary
 	b.WriteString(style.White.Render("Performance Summary"))
@@ -34,12 +34,12 @@ func (m Model) View() string {
 		}
 
 		// Helper to compute total PnL from a period's pnlHistory
-		calcTotalPnl := func(p *api.PortfolioPeriod) float64 {
+		calcTotalPnl := func(p *api.PortfolioPeriod) decimal.Decimal {
 			if p == nil || len(p.PnlHistory) == 0 {
-				return 0
+				return decimal.Zero
 			}
 			// Last entry in pnlHistory is cumulative
-			return util.ParseFloat(p.PnlHistory[len(p.PnlHistory)-1].Value)
+			return p.PnlHistory[len(p.PnlHistory)-1].Value
 		}
 
 		dayPnl := calcTotalPnl(periodMap["perpDay"])
@@ -47,10 +47,10 @@ func (m Model) View() string {
 		monthPnl := calcTotalPnl(periodMap["perpMonth"])
 		allTimePnl := calcTotalPnl(periodMap["perpAllTime"])
 
-		dayStyle := style.PnlColor(dayPnl)
-		weekStyle := style.PnlColor(weekPnl)
-		monthStyle := style.PnlColor(monthPnl)
-		allTimeStyle := style.PnlColor(allTimePnl)
+		dayStyle := style.PnlColor(dayPnl.Float64())
+		weekStyle := style.PnlColor(weekPnl.Float64())
+		monthStyle := style.PnlColor(monthPnl.Float64())
+		allTimeStyle := style.PnlColor(allTimePnl.Float64())
 
 		fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("Today PnL:"), dayStyle.Render(util.FormatSignedUSD(dayPnl)))
 		fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("7-Day PnL:"), weekStyle.Render(util.FormatSignedUSD(weekPnl)))
@@ -58,9 +58,8 @@ func (m Model) View() string {
 		fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("All-Time PnL:"), allTimeStyle.Render(util.FormatSignedUSD(allTimePnl)))
 
 		// Volume info
-		if allTime := periodMap["perpAllTime"]; allTime != nil && allTime.Vlm != "" {
-			vlm := util.ParseFloat(allTime.Vlm)
-			fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("All-Time Vol:"), style.Cyan.Render(util.FormatUSD(vlm)))
+		if allTime := periodMap["perpAllTime"]; allTime != nil && !allTime.Vlm.IsZero() {
+			fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("All-Time Vol:"), style.Cyan.Render(util.FormatUSD(allTime.Vlm)))
 		}
 
 		// Sparkline for daily PnL
@@ -68,7 +67,7 @@ func (m Model) View() string {
 			b.WriteString("\n")
 			b.WriteString(style.White.Render("PnL History (Today)"))
 			b.WriteString("\n")
-			b.WriteString(renderSparkline(day.PnlHistory))
+			b.WriteString(ui.RenderSparkline(day.PnlHistory, 60))
 			b.WriteString("\n")
 		}
 
@@ -77,9 +76,12 @@ func (m Model) View() string {
 			b.WriteString("\n")
 			b.WriteString(style.White.Render("Account Value History"))
 			b.WriteString("\n")
-			b.WriteString(renderSparkline(allTime.AccountValueHistory))
+			b.WriteString(ui.RenderSparkline(allTime.AccountValueHistory, 60))
 			b.WriteString("\n")
 		}
+	} else if err := m.store.FetchError(store.SourcePortfolio); err != nil {
+		b.WriteString(ui.RenderLoadError("portfolio", err))
+		b.WriteString("\n")
 	} else {
 		b.WriteString(style.Dim.Render("  No portfolio data available"))
 		b.WriteString("\n")
@@ -93,14 +95,14 @@ func (m Model) View() string {
 	b.WriteString("\n\n")
 
 	if fees != nil {
-		crossRate := util.ParseFloat(fees.UserCrossRate) * 100
-		addRate := util.ParseFloat(fees.UserAddRate) * 100
+		crossRate := fees.UserCrossRate.Float64() * 100
+		addRate := fees.UserAddRate.Float64() * 100
 		fmt.Fprintf(&b, "  %s  %.4f%%\n", style.SummaryLabel.Render("Taker Rate:"), crossRate)
 		fmt.Fprintf(&b, "  %s  %.4f%%\n", style.SummaryLabel.Render("Maker Rate:"), addRate)
 
-		if fees.FeeSchedule.Cross != "" {
-			schedCross := util.ParseFloat(fees.FeeSchedule.Cross) * 100
-			schedAdd := util.ParseFloat(fees.FeeSchedule.Add) * 100
+		if !fees.FeeSchedule.Cross.IsZero() {
+			schedCross := fees.FeeSchedule.Cross.Float64() * 100
+			schedAdd := fees.FeeSchedule.Add.Float64() * 100
 			fmt.Fprintf(&b, "  %s  %.4f%%\n", style.SummaryLabel.Render("Sched Taker:"), schedCross)
 			fmt.Fprintf(&b, "  %s  %.4f%%\n", style.SummaryLabel.Render("Sched Maker:"), schedAdd)
 		}
@@ -110,57 +112,3 @@ func (m Model) View() string {
 
 	return b.String()
 }
-
-func renderSparkline(history []api.TimeValue) string {
-	if len(history) == 0 {
-		return ""
-	}
-
-	// Use last N entries
-	maxBars := 60
-	start := len(history) - maxBars
-	if start < 0 {
-		start = 0
-	}
-	recent := history[start:]
-
-	vals := make([]float64, len(recent))
-	for i, tv := range recent {
-		vals[i] = util.ParseFloat(tv.Value)
-	}
-
-	bars := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
-
-	minVal, maxVal := vals[0], vals[0]
-	for _, v := range vals {
-		if v < minVal {
-			minVal = v
-		}
-		if v > maxVal {
-			maxVal = v
-		}
-	}
-
-	rng := maxVal - minVal
-	if rng == 0 {
-		rng = 1
-	}
-
-	var b strings.Builder
-	b.WriteString("  ")
-	for _, v := range vals {
-		idx := int(math.Round((v - minVal) / rng * 7))
-		if idx > 7 {
-			idx = 7
-		}
-		if idx < 0 {
-			idx = 0
-		}
-		if v >= 0 {
-			b.WriteString(style.Green.Render(string(bars[idx])))
-		} else {
-			b.WriteString(style.Red.Render(string(bars[idx])))
-		}
-	}
-	return b.String()
-}
@@ -0,0 +1,16 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+)
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
@@ -0,0 +1,102 @@
+package positions
+
+import (
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+// carryRows returns the positions' carry the filter keeps, in the table's
+// order, and how many positions there are.
+func (m Model) carryRows() ([]store.PositionCarry, int) {
+	carries := m.store.PositionCarry(util.Now())
+	total := len(carries)
+	carries = m.carryFilter.Apply(carries)
+	m.carryTable.Sort(carries)
+	return carries, total
+}
+
+// carryView shows projected funding per position over 8h/24h/7d, funding
+// received since open and how much of the uPnL it has eaten.
+func (m Model) carryView() string {
+	carries, total := m.carryRows()
+	if total == 0 {
+		return style.Dim.Render("  No open positions")
+	}
+
+	var b strings.Builder
+	visibleRows := m.height - 7
+	if m.carryFilter.Shown() {
+		b.WriteString(m.carryFilter.View(len(carries), total))
+		b.WriteString("\n")
+		visibleRows--
+	}
+	b.WriteString(m.carryTable.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.cursor, len(carries), visibleRows)
+
+	var book store.PositionCarry
+	missingHistory := false
+	for i, c := range carries {
+		book.Notional = book.Notional.Add(c.Notional)
+		book.Proj8h = book.Proj8h.Add(c.Proj8h)
+		book.Proj24h = book.Proj24h.Add(c.Proj24h)
+		book.Proj7d = book.Proj7d.Add(c.Proj7d)
+		book.FundingPaid = book.FundingPaid.Add(c.FundingPaid)
+		book.UnrealizedPnl = book.UnrealizedPnl.Add(c.UnrealizedPnl)
+		if !c.HasTrailing {
+			missingHistory = true
+		}
+
+		switch {
+		case i < start || i >= end:
+			continue
+		case i == cursor:
+			b.WriteString(m.carryTable.SelectedRow(c))
+		default:
+			b.WriteString(m.carryTable.Row(c))
+		}
+		b.WriteString("\n")
+	}
+
+	// Book totals
+	b.WriteString(style.Dim.Render(separator80))
+	b.WriteString("\n")
+	totals := []string{
+		style.White.Bold(true).Render(padRight("BOOK", ColCoin)),
+		padRight("", ColSide),
+		padLeft(util.FormatUSD(book.Notional), colCNotional),
+		padLeft("", colCAPR),
+		padLeft("", colCAPR),
+		projCell(book.Proj8h, colCProj),
+		projCell(book.Proj24h, colCProj),
+		projCell(book.Proj7d, colCProj),
+		projCell(book.FundingPaid.Neg(), colCFunding),
+		shareCell(book),
+	}
+	b.WriteString(strings.Join(totals, " "))
+	b.WriteString("\n\n")
+
+	note := "  Projections: predicted HL rate for 8h, 7d average after. FUNDING = received since open (- = paid)."
+	if missingHistory {
+		note += " 7D APR \"-\": history loading, predicted rate used."
+	}
+	b.WriteString(style.Dim.Render(note))
+	b.WriteString("\n")
+	b.WriteString(style.Dim.Render("  [m] positions  [enter] details  [</>] column  [s] sort  [S] then by"))
+	return b.String()
+}
+
+func projCell(v decimal.Decimal, width int) string {
+	return style.PnlColor(v.Float64()).Render(padLeft(util.FormatSignedUSD(v), width))
+}
+
+// shareCell renders funding paid as a percentage of |uPnL|.
+func shareCell(c store.PositionCarry) string {
+	return shareStyle(c).Render(padLeft(shareText(c), colCShare))
+}
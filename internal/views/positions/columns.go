@@ -1,5 +1,19 @@
 package positions
 
+import (
+	"fmt"
+	"math"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
 // Column widths for positions table
 const (
 	ColCoin    = 9
@@ -13,4 +27,265 @@ const (
 	ColEntry   = 12
 	ColCurrent = 12
 	ColLiq     = 12
+	ColLiqDist = 9
+)
+
+// row is a position with its coin's funding rate.
+type row struct {
+	store.LivePosition
+	fundRate float64
+}
+
+func (r row) fundingFee() decimal.Decimal {
+	if r.Position.CumFunding == nil {
+		return decimal.Zero
+	}
+	return r.Position.CumFunding.SinceOpen
+}
+
+func (r row) liqPx() (decimal.Decimal, bool) {
+	if p := r.Position.LiquidationPx; p != nil && !p.IsZero() {
+		return *p, true
+	}
+	return decimal.Zero, false
+}
+
+func side(szi decimal.Decimal) (string, lipgloss.Style) {
+	if szi.Sign() < 0 {
+		return "SHORT", style.Red
+	}
+	return "LONG", style.Green
+}
+
+var positionColumns = []table.Column[row]{
+	{
+		Key: "coin", Title: "COIN", Width: ColCoin, Left: true,
+		Cell:  func(r row) string { return r.Position.Coin },
+		Style: func(row) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(r row) string { return r.Position.Coin }),
+	},
+	{
+		Key: "side", Title: "SIDE", Width: ColSide, Left: true,
+		Cell:  func(r row) string { s, _ := side(r.Position.Szi); return s },
+		Style: func(r row) lipgloss.Style { _, st := side(r.Position.Szi); return st },
+		Cmp:   table.By(func(r row) int { return r.Position.Szi.Sign() }),
+	},
+	{
+		Key: "lev", Title: "LEV", Width: ColLev, Left: true, Desc: true,
+		Cell:  func(r row) string { return util.FormatLeverage(r.Position.Leverage.Value) },
+		Style: func(row) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(r row) float64 { return r.Position.Leverage.Value }),
+	},
+	{
+		Key: "value", Title: "VALUE", Width: ColValue, Desc: true,
+		Cell: func(r row) string { return util.FormatUSD(r.Value) },
+		Cmp:  table.ByDecimal(func(r row) decimal.Decimal { return r.Value }),
+	},
+	{
+		Key: "fundrate", Title: "FUND/24H", Width: ColFundR, Desc: true,
+		Cell:  func(r row) string { return util.FormatFundingRate(r.fundRate) },
+		Style: func(r row) lipgloss.Style { return style.PnlColor(r.fundRate) },
+		Cmp:   table.By(func(r row) float64 { return r.fundRate }),
+	},
+	{
+		Key: "fundfee", Title: "FUND FEE", Width: ColFundFee, Desc: true,
+		Cell:  func(r row) string { return util.FormatSignedUSD(r.fundingFee()) },
+		Style: func(r row) lipgloss.Style { return style.PnlColor(r.fundingFee().Float64()) },
+		Cmp:   table.ByDecimal(row.fundingFee),
+	},
+	{
+		Key: "pnl", Title: "PNL", Width: ColPnl, Desc: true,
+		Cell: func(r row) string {
+			s := util.FormatSignedUSD(r.UnrealizedPnl)
+			if !r.Drift.IsZero() {
+				s += "*"
+			}
+			return s
+		},
+		Style: func(r row) lipgloss.Style {
+			if !r.Drift.IsZero() {
+				return style.Yellow
+			}
+			return style.PnlColor(r.UnrealizedPnl.Float64())
+		},
+		Cmp: table.ByDecimal(func(r row) decimal.Decimal { return r.UnrealizedPnl }),
+	},
+	{
+		Key: "roe", Title: "ROE", Width: ColRoe, Desc: true,
+		Cell:  func(r row) string { return util.FormatPercent(r.ReturnOnEquity * 100) },
+		Style: func(r row) lipgloss.Style { return style.PnlColor(r.ReturnOnEquity) },
+		Cmp:   table.By(func(r row) float64 { return r.ReturnOnEquity }),
+	},
+	{
+		Key: "entry", Title: "ENTRY", Width: ColEntry, Desc: true,
+		Cell: func(r row) string { return util.FormatPrice(r.Position.EntryPx) },
+		Cmp:  table.ByDecimal(func(r row) decimal.Decimal { return r.Position.EntryPx }),
+	},
+	{
+		Key: "current", Title: "CURRENT", Width: ColCurrent, Desc: true,
+		Cell: func(r row) string { return util.FormatPrice(r.MarkPx) },
+		Cmp:  table.ByDecimal(func(r row) decimal.Decimal { return r.MarkPx }),
+	},
+	{
+		Key: "liq", Title: "LIQ", Width: ColLiq, Desc: true,
+		Cell: func(r row) string {
+			if px, ok := r.liqPx(); ok {
+				return util.FormatPrice(px)
+			}
+			return "-"
+		},
+		Style:   func(row) lipgloss.Style { return style.Dim },
+		Cmp:     table.ByDecimal(func(r row) decimal.Decimal { px, _ := r.liqPx(); return px }),
+		Missing: func(r row) bool { _, ok := r.liqPx(); return !ok },
+	},
+	{
+		// Nearest to liquidation first
+		Key: "liqdist", Title: "LIQ DIST", Width: ColLiqDist,
+		Cell: func(r row) string {
+			if pct, ok := r.LiqDistance(); ok {
+				return fmt.Sprintf("%.1f%%", pct)
+			}
+			return "-"
+		},
+		Style:   func(row) lipgloss.Style { return style.Dim },
+		Cmp:     table.By(func(r row) float64 { pct, _ := r.LiqDistance(); return pct }),
+		Missing: func(r row) bool { _, ok := r.LiqDistance(); return !ok },
+	},
+}
+
+// Column widths for the funding carry table
+const (
+	colCNotional = 14
+	colCAPR      = 10
+	colCProj     = 12
+	colCFunding  = 14
+	colCShare    = 10
 )
+
+var carryColumns = []table.Column[store.PositionCarry]{
+	{
+		Key: "coin", Title: "COIN", Width: ColCoin, Left: true,
+		Cell:  func(c store.PositionCarry) string { return c.Coin },
+		Style: func(store.PositionCarry) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(c store.PositionCarry) string { return c.Coin }),
+	},
+	{
+		Key: "side", Title: "SIDE", Width: ColSide, Left: true,
+		Cell:  func(c store.PositionCarry) string { s, _ := side(c.Szi); return s },
+		Style: func(c store.PositionCarry) lipgloss.Style { _, st := side(c.Szi); return st },
+		Cmp:   table.By(func(c store.PositionCarry) int { return c.Szi.Sign() }),
+	},
+	{
+		Key: "notional", Title: "NOTIONAL", Width: colCNotional, Desc: true,
+		Cell: func(c store.PositionCarry) string { return util.FormatUSD(c.Notional) },
+		Cmp:  table.ByDecimal(func(c store.PositionCarry) decimal.Decimal { return c.Notional }),
+	},
+	{
+		Key: "predapr", Title: "PRED APR", Width: colCAPR, Desc: true,
+		Cell: func(c store.PositionCarry) string {
+			return fmt.Sprintf("%.2f%%", util.AnnualizeFunding(c.PredictedRate))
+		},
+		Style: func(c store.PositionCarry) lipgloss.Style { return style.PnlColor(c.PredictedRate) },
+		Cmp:   table.By(func(c store.PositionCarry) float64 { return c.PredictedRate }),
+	},
+	{
+		Key: "trailapr", Title: "7D APR", Width: colCAPR, Desc: true,
+		Cell: func(c store.PositionCarry) string {
+			if !c.HasTrailing {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f%%", util.AnnualizeFunding(c.TrailingRate))
+		},
+		Style: func(c store.PositionCarry) lipgloss.Style {
+			if !c.HasTrailing {
+				return style.Dim
+			}
+			return style.PnlColor(c.TrailingRate)
+		},
+		Cmp:     table.By(func(c store.PositionCarry) float64 { return c.TrailingRate }),
+		Missing: func(c store.PositionCarry) bool { return !c.HasTrailing },
+	},
+	projColumn("proj8h", "PROJ 8H", colCProj, func(c store.PositionCarry) decimal.Decimal { return c.Proj8h }),
+	projColumn("proj24h", "PROJ 24H", colCProj, func(c store.PositionCarry) decimal.Decimal { return c.Proj24h }),
+	projColumn("proj7d", "PROJ 7D", colCProj, func(c store.PositionCarry) decimal.Decimal { return c.Proj7d }),
+	projColumn("funding", "FUNDING", colCFunding, func(c store.PositionCarry) decimal.Decimal { return c.FundingPaid.Neg() }),
+	{
+		Key: "share", Title: "PAID/uPNL", Width: colCShare, Desc: true,
+		Cell:  shareText,
+		Style: shareStyle,
+		Cmp: table.By(func(c store.PositionCarry) float64 {
+			pct, _ := c.PaidShareOfPnl()
+			return pct
+		}),
+		Missing: func(c store.PositionCarry) bool { _, ok := c.PaidShareOfPnl(); return !ok },
+	},
+}
+
+// projColumn is a signed USD funding amount: received is positive.
+func projColumn(key, title string, width int, v func(store.PositionCarry) decimal.Decimal) table.Column[store.PositionCarry] {
+	return table.Column[store.PositionCarry]{
+		Key: key, Title: title, Width: width,
+		Cell:  func(c store.PositionCarry) string { return util.FormatSignedUSD(v(c)) },
+		Style: func(c store.PositionCarry) lipgloss.Style { return style.PnlColor(v(c).Float64()) },
+		Cmp:   table.ByDecimal(v),
+	}
+}
+
+func shareText(c store.PositionCarry) string {
+	if pct, ok := c.PaidShareOfPnl(); ok {
+		return fmt.Sprintf("%.1f%%", pct)
+	}
+	return "-"
+}
+
+// shareStyle colors funding paid as a share of |uPnL|: red when funding has
+// eaten into the position, green when it has added to it.
+func shareStyle(c store.PositionCarry) lipgloss.Style {
+	pct, ok := c.PaidShareOfPnl()
+	if !ok {
+		return style.Dim
+	}
+	return style.PnlColor(-pct)
+}
+
+var positionFilterFields = []filter.Field[row]{
+	filter.Text("coin", func(r row) string { return r.Position.Coin }),
+	filter.Text("side", func(r row) string { s, _ := side(r.Position.Szi); return s }),
+	filter.Number("lev", func(r row) float64 { return r.Position.Leverage.Value }),
+	filter.Decimal("value", func(r row) decimal.Decimal { return r.Value }),
+	filter.Number("funding", func(r row) float64 { return r.fundRate * 100 * 24 }),
+	filter.Decimal("fee", row.fundingFee),
+	filter.Decimal("pnl", func(r row) decimal.Decimal { return r.UnrealizedPnl }),
+	filter.Number("roe", func(r row) float64 { return r.ReturnOnEquity * 100 }),
+	filter.Decimal("entry", func(r row) decimal.Decimal { return r.Position.EntryPx }),
+	filter.Decimal("mark", func(r row) decimal.Decimal { return r.MarkPx }),
+	filter.Number("liqdist", func(r row) float64 {
+		if pct, ok := r.LiqDistance(); ok {
+			return pct
+		}
+		return math.NaN()
+	}),
+}
+
+var carryFilterFields = []filter.Field[store.PositionCarry]{
+	filter.Text("coin", func(c store.PositionCarry) string { return c.Coin }),
+	filter.Text("side", func(c store.PositionCarry) string { s, _ := side(c.Szi); return s }),
+	filter.Decimal("notional", func(c store.PositionCarry) decimal.Decimal { return c.Notional }),
+	filter.Number("apr", func(c store.PositionCarry) float64 { return util.AnnualizeFunding(c.PredictedRate) }),
+	filter.Number("trail", func(c store.PositionCarry) float64 {
+		if !c.HasTrailing {
+			return math.NaN()
+		}
+		return util.AnnualizeFunding(c.TrailingRate)
+	}),
+	filter.Decimal("proj8h", func(c store.PositionCarry) decimal.Decimal { return c.Proj8h }),
+	filter.Decimal("proj24h", func(c store.PositionCarry) decimal.Decimal { return c.Proj24h }),
+	filter.Decimal("proj7d", func(c store.PositionCarry) decimal.Decimal { return c.Proj7d }),
+	filter.Decimal("funding", func(c store.PositionCarry) decimal.Decimal { return c.FundingPaid.Neg() }),
+}
+
+func newTables() (table.Table[row], table.Table[store.PositionCarry]) {
+	return table.New("positions", 1, positionColumns, config.SortKey{Column: "pnl", Desc: true}),
+		// Biggest projected cost first
+		table.New("carry", 1, carryColumns, config.SortKey{Column: "proj24h"})
+}
@@ -1,43 +1,132 @@
 package positions
 
 import (
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
 	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// CarryOpenedMsg is emitted when the funding carry mode is shown so the app
+// can load predicted fundings and trailing history for the open positions.
+type CarryOpenedMsg struct {
+	Coins []string
+}
+
 type Model struct {
-	store   *store.Store
-	scroll  int
-	height  int
-	sortAsc bool
+	store       *store.Store
+	cursor      int
+	height      int
+	carry       bool
+	table       table.Table[row]
+	carryTable  table.Table[store.PositionCarry]
+	filter      filter.Bar[row]
+	carryFilter filter.Bar[store.PositionCarry]
 }
 
 func New(s *store.Store) Model {
-	return Model{store: s}
+	m := Model{
+		store:       s,
+		filter:      filter.NewBar(positionFilterFields),
+		carryFilter: filter.NewBar(carryFilterFields),
+	}
+	m.table, m.carryTable = newTables()
+	return m
 }
 
 func (m Model) Init() tea.Cmd { return nil }
 
+// CarryMode reports whether the funding carry mode is showing.
+func (m Model) CarryMode() bool {
+	return m.carry
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		var cmd tea.Cmd
+		var used bool
+		if m.carry {
+			m.carryFilter, cmd, used = m.carryFilter.Update(msg)
+		} else {
+			m.filter, cmd, used = m.filter.Update(msg)
+		}
+		if used {
+			m.cursor = 0
+			return m, cmd
+		}
 		switch msg.String() {
 		case "j", "down":
-			m.scroll++
+			if m.cursor < len(m.coins())-1 {
+				m.cursor++
+			}
 		case "k", "up":
-			if m.scroll > 0 {
-				m.scroll--
+			if m.cursor > 0 {
+				m.cursor--
 			}
 		case "g":
-			m.scroll = 0
-		case "s":
-			m.sortAsc = !m.sortAsc
-			m.scroll = 0
+			m.cursor = 0
+		case "enter":
+			if coins := m.coins(); len(coins) > 0 {
+				c, _, _ := table.Window(m.cursor, len(coins), 0)
+				return m, asset.Open(coins[c])
+			}
+		case "m":
+			m.carry = !m.carry
+			m.cursor = 0
+			if m.carry {
+				coins := m.store.PositionCoins()
+				return m, func() tea.Msg { return CarryOpenedMsg{Coins: coins} }
+			}
+		default:
+			var cmd tea.Cmd
+			if m.carry {
+				m.carryTable, cmd = m.carryTable.Update(msg)
+			} else {
+				m.table, cmd = m.table.Update(msg)
+			}
+			if cmd != nil {
+				m.cursor = 0
+			}
+			return m, cmd
 		}
 	}
 	return m, nil
 }
 
+// coins lists the showing mode's rows by coin, in display order.
+func (m Model) coins() []string {
+	var coins []string
+	if m.carry {
+		carries, _ := m.carryRows()
+		for _, c := range carries {
+			coins = append(coins, c.Coin)
+		}
+		return coins
+	}
+	rows, _ := m.rows()
+	for _, r := range rows {
+		coins = append(coins, r.Position.Coin)
+	}
+	return coins
+}
+
+// SetSortPrefs restores the saved sort orders.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.table = m.table.Restore(prefs)
+	m.carryTable = m.carryTable.Restore(prefs)
+}
+
+// Filtering reports whether the showing mode's filter bar has the keyboard.
+func (m Model) Filtering() bool {
+	if m.carry {
+		return m.carryFilter.Editing()
+	}
+	return m.filter.Editing()
+}
+
 func (m *Model) SetHeight(h int) {
 	m.height = h
 }
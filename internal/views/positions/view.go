@@ -4,113 +4,90 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
 	"github.com/charmbracelet/lipgloss"
 )
 
 var separator80 = strings.Repeat("─", 80)
 
+// rows returns the positions the filter keeps, in the table's order, and
+// every position.
+func (m Model) rows() ([]row, []store.LivePosition) {
+	positions, fundingRates := m.store.LivePositions(false)
+	rows := make([]row, len(positions))
+	for i, lp := range positions {
+		rows[i] = row{LivePosition: lp, fundRate: fundingRates[lp.Position.Coin]}
+	}
+	rows = m.filter.Apply(rows)
+	m.table.Sort(rows)
+	return rows, positions
+}
+
 func (m Model) View() string {
-	positions, mids, fundingRates := m.store.PositionsSorted(m.sortAsc)
-	if len(positions) == 0 {
-		return style.Dim.Render("  No open positions")
+	if m.carry {
+		return m.carryView()
 	}
 
-	arrow := " ▼"
-	if m.sortAsc {
-		arrow = " ▲"
+	rows, positions := m.rows()
+	if len(positions) == 0 {
+		if err := m.store.FetchError(store.SourceAccount); err != nil {
+			return ui.RenderLoadError("positions", err)
+		}
+		return style.Dim.Render("  No open positions")
 	}
 
 	var b strings.Builder
 
+	visibleRows := m.height - 6
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(rows), len(positions)))
+		b.WriteString("\n")
+		visibleRows--
+	}
+
 	// Header
-	header := fmt.Sprintf("%-9s %-6s %-5s %14s %11s %14s %16s %12s %12s %12s %12s",
-		"COIN", "SIDE", "LEV", "VALUE", "FUND/24H", "FUND FEE", "PNL"+arrow, "ROE", "ENTRY", "CURRENT", "LIQ",
-	)
-	b.WriteString(style.TableHeader.Render(header))
+	b.WriteString(m.table.Header())
 	b.WriteString("\n")
 
 	// Track totals for summary
-	var totalPnl, winners, losers float64
-
-	// Determine visible range
-	visibleRows := m.height - 6
-	if visibleRows < 1 {
-		visibleRows = len(positions)
-	}
-	start := m.scroll
-	if start >= len(positions) {
-		start = len(positions) - 1
-	}
-	if start < 0 {
-		start = 0
-	}
-	end := start + visibleRows
-	if end > len(positions) {
-		end = len(positions)
-	}
+	var totalPnl, winners, losers decimal.Decimal
 
-	for i, ap := range positions {
-		p := ap.Position
-		szi := util.ParseFloat(p.Szi)
-		pnl := util.ParseFloat(p.UnrealizedPnl)
-		roe := util.ParseFloat(p.ReturnOnEquity) * 100
-		entryPx := util.ParseFloat(p.EntryPx)
-		posValue := util.ParseFloat(p.PositionValue)
-		lev := p.Leverage.Value
-
-		fundingFee := 0.0
-		if p.CumFunding != nil {
-			fundingFee = util.ParseFloat(p.CumFunding.SinceOpen)
+	// Positions whose server uPnL disagreed with ours at the last snapshot
+	var drifted []store.LivePosition
+	for _, lp := range positions {
+		if !lp.Drift.IsZero() {
+			drifted = append(drifted, lp)
 		}
+	}
 
-		currentPx := util.ParseFloat(mids[p.Coin])
-		fundRate := fundingRates[p.Coin]
+	// Determine visible range
+	if len(drifted) > 0 {
+		visibleRows--
+	}
+	cursor, start, end := table.Window(m.cursor, len(rows), visibleRows)
 
-		totalPnl += pnl
-		if pnl > 0 {
-			winners += pnl
+	for i, r := range rows {
+		pnl := r.UnrealizedPnl
+		totalPnl = totalPnl.Add(pnl)
+		if pnl.Sign() > 0 {
+			winners = winners.Add(pnl)
 		} else {
-			losers += pnl
+			losers = losers.Add(pnl)
 		}
 
-		if i < start || i >= end {
+		switch {
+		case i < start || i >= end:
 			continue
+		case i == cursor:
+			b.WriteString(m.table.SelectedRow(r))
+		default:
+			b.WriteString(m.table.Row(r))
 		}
-
-		side := "LONG"
-		sideStyle := style.Green
-		if szi < 0 {
-			side = "SHORT"
-			sideStyle = style.Red
-		}
-
-		pnlStyle := style.PnlColor(pnl)
-		roeStyle := style.PnlColor(roe)
-		fundFeeStyle := style.PnlColor(fundingFee)
-		fundRateStyle := style.PnlColor(fundRate)
-
-		// Handle nullable liquidation price
-		liqStr := "-"
-		if p.LiquidationPx != nil && *p.LiquidationPx != "" {
-			liqStr = util.FormatPrice(util.ParseFloat(*p.LiquidationPx))
-		}
-
-		cells := []string{
-			style.White.Render(padRight(p.Coin, 9)),
-			sideStyle.Render(padRight(side, 6)),
-			style.Dim.Render(padRight(util.FormatLeverage(lev), 5)),
-			padLeft(util.FormatUSD(posValue), 14),
-			fundRateStyle.Render(padLeft(util.FormatFundingRate(fundRate), 11)),
-			fundFeeStyle.Render(padLeft(util.FormatSignedUSD(fundingFee), 14)),
-			pnlStyle.Render(padLeft(util.FormatSignedUSD(pnl), 16)),
-			roeStyle.Render(padLeft(util.FormatPercent(roe), 12)),
-			padLeft(util.FormatPrice(entryPx), 12),
-			padLeft(util.FormatPrice(currentPx), 12),
-			style.Dim.Render(padLeft(liqStr, 12)),
-		}
-		b.WriteString(strings.Join(cells, " "))
 		b.WriteString("\n")
 	}
 
@@ -129,11 +106,20 @@ func (m Model) View() string {
 	)
 	b.WriteString(summaryLine)
 
+	if len(drifted) > 0 {
+		parts := make([]string, len(drifted))
+		for i, lp := range drifted {
+			parts[i] = lp.Position.Coin + " " + util.FormatSignedUSD(lp.Drift)
+		}
+		b.WriteString("\n")
+		b.WriteString(style.Yellow.Render("  * Server PnL differs from live revaluation: " + strings.Join(parts, ", ")))
+	}
+
 	return b.String()
 }
 
-func pnlSummaryStyle(val float64) lipgloss.Style {
-	s := style.PnlColor(val)
+func pnlSummaryStyle(val decimal.Decimal) lipgloss.Style {
+	s := style.PnlColor(val.Float64())
 	return s.Bold(true)
 }
 
@@ -0,0 +1,118 @@
+package scanner
+
+import (
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/anomaly"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ToggleAlertsMsg asks the app to flip scanner alerts.
+type ToggleAlertsMsg struct{}
+
+// windows are the spans [ and ] step through.
+var windows = []time.Duration{15 * time.Minute, time.Hour, 4 * time.Hour}
+
+const defaultWindowIdx = 1 // 1h
+
+type Model struct {
+	store      *store.Store
+	cursor     int
+	height     int
+	windowIdx  int
+	thresholds anomaly.Thresholds
+	alerts     bool
+	table      table.Table[anomaly.Flag]
+	filter     filter.Bar[anomaly.Flag]
+}
+
+func New(s *store.Store) Model {
+	return Model{
+		store:      s,
+		windowIdx:  defaultWindowIdx,
+		thresholds: anomaly.DefaultThresholds,
+		table:      table.New("scanner", 2, columns(s), config.SortKey{Column: "score", Desc: true}),
+		filter:     filter.NewBar(filterFields),
+	}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		var cmd tea.Cmd
+		var used bool
+		if m.filter, cmd, used = m.filter.Update(msg); used {
+			m.cursor = 0
+			return m, cmd
+		}
+		switch msg.String() {
+		case "j", "down":
+			if rows, _ := m.rows(); m.cursor < len(rows)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g":
+			m.cursor = 0
+		case "[":
+			m.windowIdx = max(m.windowIdx-1, 0)
+			m.cursor = 0
+		case "]":
+			m.windowIdx = min(m.windowIdx+1, len(windows)-1)
+			m.cursor = 0
+		case "a":
+			return m, func() tea.Msg { return ToggleAlertsMsg{} }
+		case "enter":
+			if rows, _ := m.rows(); len(rows) > 0 {
+				c, _, _ := table.Window(m.cursor, len(rows), 0)
+				return m, asset.Open(rows[c].Coin)
+			}
+		default:
+			var cmd tea.Cmd
+			if m.table, cmd = m.table.Update(msg); cmd != nil {
+				m.cursor = 0
+			}
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// Rules are what the view flags: the configured thresholds over the
+// selected window. The app raises alerts under the same rules.
+func (m Model) Rules() anomaly.Rules {
+	return anomaly.Rules{Window: windows[m.windowIdx], Thresholds: m.thresholds}
+}
+
+// SetThresholds sets the moves that are flagged.
+func (m *Model) SetThresholds(t anomaly.Thresholds) {
+	m.thresholds = t
+}
+
+// SetAlerts reflects whether scanner alerts are enabled.
+func (m *Model) SetAlerts(on bool) {
+	m.alerts = on
+}
+
+// SetSortPrefs restores the saved sort order.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.table = m.table.Restore(prefs)
+}
+
+// Filtering reports whether the filter bar has the keyboard.
+func (m Model) Filtering() bool {
+	return m.filter.Editing()
+}
+
+func (m *Model) SetHeight(h int) {
+	m.height = h
+}
@@ -0,0 +1,202 @@
+package scanner
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/anomaly"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// columns lays out a flag: what tripped and by how much, then the coin's
+// price from s's market.
+func columns(s *store.Store) []table.Column[anomaly.Flag] {
+	price := func(f anomaly.Flag) float64 {
+		a, _ := s.Market().Asset(f.Coin)
+		return a.Price
+	}
+	return []table.Column[anomaly.Flag]{
+		{
+			Key: "coin", Title: "COIN", Width: 9, Left: true,
+			Cell:  func(f anomaly.Flag) string { return f.Coin },
+			Style: func(anomaly.Flag) lipgloss.Style { return style.White },
+			Cmp:   table.By(func(f anomaly.Flag) string { return f.Coin }),
+		},
+		{
+			Key: "signal", Title: "SIGNAL", Width: 8, Left: true,
+			Cell:  func(f anomaly.Flag) string { return f.Signal.Label() },
+			Style: func(anomaly.Flag) lipgloss.Style { return style.Yellow },
+			Cmp:   table.By(func(f anomaly.Flag) anomaly.Signal { return f.Signal }),
+		},
+		{
+			Key: "move", Title: "MOVE", Width: 10, Desc: true,
+			Cell: formatMove,
+			Style: func(f anomaly.Flag) lipgloss.Style {
+				switch f.Signal {
+				case anomaly.Funding:
+					return style.PnlColor(f.Move)
+				case anomaly.Basis:
+					return style.PnlColor(f.Now) // premium or discount
+				}
+				return style.Green
+			},
+			Cmp: table.By(func(f anomaly.Flag) float64 { return f.Move }),
+		},
+		{
+			Key: "before", Title: "BEFORE", Width: 10,
+			Cell: func(f anomaly.Flag) string { return formatValue(f, f.Before, price(f)) },
+		},
+		{
+			Key: "now", Title: "NOW", Width: 10,
+			Cell: func(f anomaly.Flag) string { return formatValue(f, f.Now, price(f)) },
+		},
+		{
+			Key: "score", Title: "SCORE", Width: 6, Desc: true,
+			Cell: func(f anomaly.Flag) string { return fmt.Sprintf("%.1f", f.Score) },
+			Style: func(f anomaly.Flag) lipgloss.Style {
+				if f.Score >= 2 {
+					return style.Red
+				}
+				return style.Yellow
+			},
+			Cmp: table.By(func(f anomaly.Flag) float64 { return f.Score }),
+		},
+		{
+			Key: "price", Title: "PRICE", Width: 11, Desc: true,
+			Cell: func(f anomaly.Flag) string {
+				a, ok := s.Market().Asset(f.Coin)
+				if !ok {
+					return "-"
+				}
+				return util.FormatPrice(a.LivePrice())
+			},
+			Cmp: table.By(price),
+		},
+	}
+}
+
+var filterFields = []filter.Field[anomaly.Flag]{
+	filter.Text("coin", func(f anomaly.Flag) string { return f.Coin }),
+	filter.Text("signal", func(f anomaly.Flag) string { return strings.ToLower(f.Signal.Label()) }),
+	filter.Number("move", func(f anomaly.Flag) float64 { return f.Move }),
+	filter.Number("score", func(f anomaly.Flag) float64 { return f.Score }),
+}
+
+// formatMove renders how far a flag moved, in its threshold's unit.
+func formatMove(f anomaly.Flag) string {
+	switch f.Signal {
+	case anomaly.OpenInterest:
+		return fmt.Sprintf("%+.1f%%", f.Move)
+	case anomaly.Volume:
+		return fmt.Sprintf("%.2fx", f.Move)
+	case anomaly.Funding:
+		return fmt.Sprintf("%+.1f pts", f.Move)
+	}
+	return fmt.Sprintf("+%.3f pts", f.Move)
+}
+
+// formatValue renders one of a flag's values: open interest in USD at the
+// current price, volume in USD an hour, funding as an annualized % and basis as a
+// % of the oracle price.
+func formatValue(f anomaly.Flag, v, price float64) string {
+	switch f.Signal {
+	case anomaly.OpenInterest:
+		return formatCompact(v * price)
+	case anomaly.Volume:
+		return formatCompact(v) + "/h"
+	case anomaly.Funding:
+		return fmt.Sprintf("%.2f%%", v)
+	}
+	return fmt.Sprintf("%+.3f%%", v)
+}
+
+func formatCompact(val float64) string {
+	abs := math.Abs(val)
+	if abs >= 1_000_000_000 {
+		return fmt.Sprintf("$%.2fB", val/1_000_000_000)
+	}
+	if abs >= 1_000_000 {
+		return fmt.Sprintf("$%.2fM", val/1_000_000)
+	}
+	if abs >= 1_000 {
+		return fmt.Sprintf("$%.1fK", val/1_000)
+	}
+	return fmt.Sprintf("$%.0f", val)
+}
+
+// rows returns the flags the filter keeps, in the table's order, and how
+// many were raised.
+func (m Model) rows() ([]anomaly.Flag, int) {
+	flags := anomaly.Scan(m.store, m.Rules())
+	total := len(flags)
+	flags = m.filter.Apply(flags)
+	m.table.Sort(flags)
+	return flags, total
+}
+
+func (m Model) View() string {
+	if m.store.Market() == nil {
+		if err := m.store.FetchError(store.SourceMeta); err != nil {
+			return ui.RenderLoadError("market data", err)
+		}
+		return style.Dim.Render("  Loading market data...")
+	}
+	rules := m.Rules()
+	window := util.FormatWindow(rules.Window)
+	flags, total := m.rows()
+
+	var b strings.Builder
+	b.WriteString(style.Dim.Render(fmt.Sprintf("  Over %s: OI ≥%+g%%, volume ≥%gx avg, funding ±%g APR pts, basis ≥%+g pts",
+		window, rules.OIJump, rules.VolumeRatio, rules.FundingSpike, rules.BasisWiden)))
+	b.WriteString("\n")
+
+	if total == 0 {
+		b.WriteString("\n")
+		b.WriteString(style.Dim.Render(fmt.Sprintf("  Nothing flagged over %s. Asset contexts are sampled while the app runs,", window)))
+		b.WriteString("\n")
+		b.WriteString(style.Dim.Render(fmt.Sprintf("  so a market can only be flagged once it has %s of history.", window)))
+		b.WriteString("\n\n")
+		b.WriteString(m.footer(0, window))
+		return b.String()
+	}
+
+	visibleRows := m.height - 4
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(flags), total))
+		b.WriteString("\n")
+		visibleRows--
+	}
+	b.WriteString(m.table.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.cursor, len(flags), visibleRows)
+	for i, f := range flags[start:end] {
+		if start+i == cursor {
+			b.WriteString(m.table.SelectedRow(f))
+		} else {
+			b.WriteString(m.table.Row(f))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.footer(len(flags), window))
+	return b.String()
+}
+
+func (m Model) footer(n int, window string) string {
+	alerts := "OFF"
+	if m.alerts {
+		alerts = "ON"
+	}
+	return style.Dim.Render(fmt.Sprintf("  %d flags  (sorted by %s)  ", n, m.table.SortLabel())) +
+		style.Yellow.Render(fmt.Sprintf("[[/]] window: %s  [a] alerts: %s", window, alerts)) +
+		style.Dim.Render("  [enter] details")
+}
@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/anomaly"
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/born1337/hyperliquid-terminal/internal/views/asset"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+)
+
+// scannedStore returns the demo store after an hour of asset contexts in
+// which a few markets moved: SOL's open interest, ETH's volume, HYPE's
+// funding and BTC's basis.
+func scannedStore(t *testing.T) *store.Store {
+	s := viewtest.Store(t)
+	start := s.Market()
+	for step := 1; step <= 2; step++ {
+		at := viewtest.Clock.Add(time.Duration(step) * 30 * time.Minute)
+		util.Now = func() time.Time { return at }
+		meta := &api.MetaAndAssetCtxs{}
+		for _, a := range start.Assets {
+			ctx := a.Ctx
+			if step == 2 {
+				switch a.Name {
+				case "SOL":
+					ctx.OpenInterest = decimal.NewFromFloat(a.OpenInterest * 1.18)
+				case "ETH":
+					ctx.DayNtlVlm = decimal.NewFromFloat(a.Volume24h * 1.1)
+				case "HYPE":
+					ctx.Funding = decimal.NewFromFloat(0.00009)
+				case "BTC":
+					ctx.MarkPx = decimal.NewFromFloat(a.OraclePx * 1.0035)
+				}
+			}
+			meta.Meta.Universe = append(meta.Meta.Universe, a.AssetMeta)
+			meta.AssetCtxs = append(meta.AssetCtxs, ctx)
+		}
+		s.SetMetaAndAssetCtxs(meta)
+	}
+	return s
+}
+
+func TestView(t *testing.T) {
+	s := scannedStore(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m.SetAlerts(true)
+		return m.View()
+	})
+}
+
+func TestKeys(t *testing.T) {
+	s := scannedStore(t)
+	m := New(s)
+	m.SetHeight(30)
+
+	if rows, _ := m.rows(); len(rows) != 4 || rows[0].Score < rows[len(rows)-1].Score {
+		t.Fatalf("flags = %+v", rows)
+	}
+	// Nothing has four hours of history
+	m, _ = m.Update(viewtest.Key("]"))
+	if rows, _ := m.rows(); m.Rules().Window != 4*time.Hour || len(rows) != 0 {
+		t.Errorf("4h window: %v, %d flags", m.Rules().Window, len(rows))
+	}
+	m, _ = m.Update(viewtest.Key("["))
+
+	_, cmd := m.Update(viewtest.Key("enter"))
+	rows, _ := m.rows()
+	if msg, ok := cmd().(asset.OpenMsg); !ok || msg.Coin != rows[0].Coin {
+		t.Errorf("enter = %#v, want asset.OpenMsg for %s", msg, rows[0].Coin)
+	}
+	if _, cmd := m.Update(viewtest.Key("a")); cmd == nil {
+		t.Error("a sent nothing")
+	} else if _, ok := cmd().(ToggleAlertsMsg); !ok {
+		t.Errorf("a = %#v", cmd())
+	}
+
+	var signals []anomaly.Signal
+	for _, f := range rows {
+		signals = append(signals, f.Signal)
+	}
+	if len(signals) != 4 {
+		t.Errorf("signals = %v", signals)
+	}
+}
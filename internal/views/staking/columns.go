@@ -0,0 +1,197 @@
+package staking
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// delegationRow is a delegation with its validator resolved and the values
+// the table shows.
+type delegationRow struct {
+	api.Delegation
+	name       string
+	commission float64
+	hasComm    bool // the validator is in the validator list
+	share      float64
+	value      decimal.Decimal // zero without a HYPE price
+	locked     bool            // lockup still running
+}
+
+// rewardRow is a reward with its USD value, zero without a HYPE price.
+type rewardRow struct {
+	api.DelegatorReward
+	value decimal.Decimal
+}
+
+// eventRow is a delegation event with its kind and validator spelled out.
+type eventRow struct {
+	api.DelegatorEvent
+	kind      string
+	validator string
+}
+
+// formatValue renders a USD value, or "-" when there is no HYPE price.
+func formatValue(v decimal.Decimal) string {
+	if v.IsZero() {
+		return "-"
+	}
+	return util.FormatUSD(v)
+}
+
+var delegationColumns = []table.Column[delegationRow]{
+	{
+		Key: "validator", Title: "VALIDATOR", Width: colValidator, Left: true,
+		Cell:  func(r delegationRow) string { return truncate(r.name, colValidator) },
+		Style: func(delegationRow) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(r delegationRow) string { return strings.ToLower(r.name) }),
+	},
+	{
+		Key: "staked", Title: "STAKED", Width: colAmount, Desc: true,
+		Cell:  func(r delegationRow) string { return formatHype(r.Amount) },
+		Style: func(delegationRow) lipgloss.Style { return style.Green },
+		Cmp:   table.ByDecimal(func(r delegationRow) decimal.Decimal { return r.Amount }),
+	},
+	{
+		Key: "value", Title: "VALUE", Width: colValue, Desc: true,
+		Cell: func(r delegationRow) string { return formatValue(r.value) },
+		Cmp:  table.ByDecimal(func(r delegationRow) decimal.Decimal { return r.value }),
+	},
+	{
+		Key: "share", Title: "SHARE", Width: colShare, Desc: true,
+		Cell:  func(r delegationRow) string { return fmt.Sprintf("%.1f%%", r.share) },
+		Style: func(delegationRow) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(r delegationRow) float64 { return r.share }),
+	},
+	{
+		Key: "comm", Title: "COMM", Width: colComm,
+		Cell: func(r delegationRow) string {
+			if !r.hasComm {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f%%", r.commission*100)
+		},
+		Style: func(r delegationRow) lipgloss.Style {
+			if !r.hasComm {
+				return style.Dim
+			}
+			return lipgloss.NewStyle()
+		},
+		Cmp:     table.By(func(r delegationRow) float64 { return r.commission }),
+		Missing: func(r delegationRow) bool { return !r.hasComm },
+	},
+	{
+		Key: "locked", Title: "LOCKED UNTIL", Width: colLocked,
+		Cell: func(r delegationRow) string {
+			if !r.locked {
+				return "-"
+			}
+			return util.FormatTime(r.LockedUntilTimestamp)
+		},
+		Style: func(r delegationRow) lipgloss.Style {
+			if !r.locked {
+				return style.Dim
+			}
+			return style.Yellow
+		},
+		Cmp:     table.By(func(r delegationRow) int64 { return r.LockedUntilTimestamp }),
+		Missing: func(r delegationRow) bool { return !r.locked },
+	},
+}
+
+var rewardColumns = []table.Column[rewardRow]{
+	{
+		Key: "time", Title: "TIME", Width: colTime, Left: true,
+		Cell:  func(r rewardRow) string { return util.FormatTimeFull(r.Time) },
+		Style: func(rewardRow) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(r rewardRow) int64 { return r.Time }),
+	},
+	{
+		Key: "source", Title: "SOURCE", Width: colSource, Left: true,
+		Cell: func(r rewardRow) string { return r.Source },
+		Cmp:  table.By(func(r rewardRow) string { return r.Source }),
+	},
+	{
+		Key: "amount", Title: "AMOUNT", Width: colAmount, Desc: true,
+		Cell:  func(r rewardRow) string { return formatHype(r.TotalAmount) },
+		Style: func(rewardRow) lipgloss.Style { return style.Green },
+		Cmp:   table.ByDecimal(func(r rewardRow) decimal.Decimal { return r.TotalAmount }),
+	},
+	{
+		Key: "value", Title: "VALUE", Width: colValue, Desc: true,
+		Cell: func(r rewardRow) string { return formatValue(r.value) },
+		Cmp:  table.ByDecimal(func(r rewardRow) decimal.Decimal { return r.value }),
+	},
+}
+
+var eventColumns = []table.Column[eventRow]{
+	{
+		Key: "time", Title: "TIME", Width: colTime, Left: true,
+		Cell:  func(r eventRow) string { return util.FormatTimeFull(r.Time) },
+		Style: func(eventRow) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(r eventRow) int64 { return r.Time }),
+	},
+	{
+		Key: "event", Title: "EVENT", Width: colSource, Left: true,
+		Cell: func(r eventRow) string { return r.kind },
+		Style: func(r eventRow) lipgloss.Style {
+			switch {
+			case r.kind == "delegate" || r.kind == "deposit":
+				return style.Green
+			case r.kind == "undelegate" || strings.HasPrefix(r.kind, "withdraw"):
+				return style.Yellow
+			}
+			return style.White
+		},
+		Cmp: table.By(func(r eventRow) string { return r.kind }),
+	},
+	{
+		Key: "amount", Title: "AMOUNT", Width: colAmount, Desc: true,
+		Cell: func(r eventRow) string { return formatHype(r.Delta.Amount()) },
+		Cmp:  table.ByDecimal(func(r eventRow) decimal.Decimal { return r.Delta.Amount() }),
+	},
+	{
+		Key: "validator", Title: "VALIDATOR", Width: colValidator, Left: true,
+		Cell:    func(r eventRow) string { return r.validator },
+		Style:   func(eventRow) lipgloss.Style { return style.Dim },
+		Cmp:     table.By(func(r eventRow) string { return r.validator }),
+		Missing: func(r eventRow) bool { return r.validator == "" },
+	},
+}
+
+var delegationFilterFields = []filter.Field[delegationRow]{
+	filter.Text("validator", func(r delegationRow) string { return r.name }),
+	filter.Decimal("staked", func(r delegationRow) decimal.Decimal { return r.Amount }),
+	filter.Decimal("value", func(r delegationRow) decimal.Decimal { return r.value }),
+	filter.Number("share", func(r delegationRow) float64 { return r.share }),
+	filter.Number("comm", func(r delegationRow) float64 { return r.commission * 100 }),
+	filter.Time("locked", func(r delegationRow) int64 { return r.LockedUntilTimestamp }),
+}
+
+var rewardFilterFields = []filter.Field[rewardRow]{
+	filter.Time("time", func(r rewardRow) int64 { return r.Time }),
+	filter.Text("source", func(r rewardRow) string { return r.Source }),
+	filter.Decimal("amount", func(r rewardRow) decimal.Decimal { return r.TotalAmount }),
+	filter.Decimal("value", func(r rewardRow) decimal.Decimal { return r.value }),
+}
+
+var eventFilterFields = []filter.Field[eventRow]{
+	filter.Time("time", func(r eventRow) int64 { return r.Time }),
+	filter.Text("event", func(r eventRow) string { return r.kind }),
+	filter.Decimal("amount", func(r eventRow) decimal.Decimal { return r.Delta.Amount() }),
+	filter.Text("validator", func(r eventRow) string { return r.validator }),
+}
+
+func newTables() (table.Table[delegationRow], table.Table[rewardRow], table.Table[eventRow]) {
+	return table.New("staking", 2, delegationColumns, config.SortKey{Column: "staked", Desc: true}),
+		table.New("staking-rewards", 2, rewardColumns, config.SortKey{Column: "time", Desc: true}),
+		table.New("staking-history", 2, eventColumns, config.SortKey{Column: "time", Desc: true})
+}
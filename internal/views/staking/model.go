@@ -0,0 +1,133 @@
+package staking
+
+import (
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	store       *store.Store
+	height      int
+	showHistory bool // bottom section: reward history (false) or delegation events (true)
+
+	// The cursor keys drive the delegations or, by default, the list below
+	delegationsFocused bool
+	cursor             int // delegations
+	listCursor         int // rewards or delegation events
+
+	delegations table.Table[delegationRow]
+	rewards     table.Table[rewardRow]
+	history     table.Table[eventRow]
+
+	delegationFilter filter.Bar[delegationRow]
+	rewardFilter     filter.Bar[rewardRow]
+	historyFilter    filter.Bar[eventRow]
+}
+
+func New(s *store.Store) Model {
+	m := Model{
+		store:            s,
+		delegationFilter: filter.NewBar(delegationFilterFields),
+		rewardFilter:     filter.NewBar(rewardFilterFields),
+		historyFilter:    filter.NewBar(eventFilterFields),
+	}
+	m.delegations, m.rewards, m.history = newTables()
+	return m
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		cursor := &m.listCursor
+		if m.delegationsFocused {
+			cursor = &m.cursor
+		}
+		var cmd tea.Cmd
+		var used bool
+		switch {
+		case m.delegationsFocused:
+			m.delegationFilter, cmd, used = m.delegationFilter.Update(msg)
+		case m.showHistory:
+			m.historyFilter, cmd, used = m.historyFilter.Update(msg)
+		default:
+			m.rewardFilter, cmd, used = m.rewardFilter.Update(msg)
+		}
+		if used {
+			*cursor = 0
+			return m, cmd
+		}
+		switch msg.String() {
+		case "j", "down":
+			if *cursor < m.focusedLen()-1 {
+				*cursor++
+			}
+		case "k", "up":
+			if *cursor > 0 {
+				*cursor--
+			}
+		case "g":
+			*cursor = 0
+		case "t":
+			m.showHistory = !m.showHistory
+			m.listCursor = 0
+		case "d":
+			m.delegationsFocused = !m.delegationsFocused
+		default:
+			switch {
+			case m.delegationsFocused:
+				m.delegations, cmd = m.delegations.Update(msg)
+			case m.showHistory:
+				m.history, cmd = m.history.Update(msg)
+			default:
+				m.rewards, cmd = m.rewards.Update(msg)
+			}
+			if cmd != nil {
+				*cursor = 0
+			}
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// focusedLen is how many rows the table under the cursor shows.
+func (m Model) focusedLen() int {
+	switch {
+	case m.delegationsFocused:
+		r, _ := m.delegationRows()
+		return len(r)
+	case m.showHistory:
+		r, _ := m.eventRows()
+		return len(r)
+	}
+	r, _ := m.rewardRows()
+	return len(r)
+}
+
+// Filtering reports whether the focused table's filter bar has the
+// keyboard.
+func (m Model) Filtering() bool {
+	switch {
+	case m.delegationsFocused:
+		return m.delegationFilter.Editing()
+	case m.showHistory:
+		return m.historyFilter.Editing()
+	}
+	return m.rewardFilter.Editing()
+}
+
+// SetSortPrefs restores the saved sort orders.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.delegations = m.delegations.Restore(prefs)
+	m.rewards = m.rewards.Restore(prefs)
+	m.history = m.history.Restore(prefs)
+}
+
+func (m *Model) SetHeight(h int) {
+	m.height = h
+}
@@ -0,0 +1,280 @@
+package staking
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+const (
+	colValidator = 24
+	colAmount    = 16
+	colValue     = 14
+	colShare     = 8
+	colComm      = 8
+	colLocked    = 16
+
+	colTime   = 20
+	colSource = 20
+)
+
+var separator80 = strings.Repeat("─", 80)
+
+func (m Model) View() string {
+	staking := m.store.Staking()
+	summary := staking.Summary
+	rewards := staking.Rewards
+	hypePx := m.store.MidPrice("HYPE")
+
+	if summary == nil {
+		if err := m.store.FetchError(store.SourceStaking); err != nil {
+			return ui.RenderLoadError("staking data", err)
+		}
+		return style.Dim.Render("  Loading staking data...")
+	}
+
+	var b strings.Builder
+
+	// Summary
+	delegated := summary.Delegated
+	undelegated := summary.Undelegated
+	pending := summary.TotalPendingWithdrawal
+
+	var totalRewards, weekRewards decimal.Decimal
+	weekAgo := util.Now().Add(-7 * 24 * time.Hour).UnixMilli()
+	for _, r := range rewards {
+		totalRewards = totalRewards.Add(r.TotalAmount)
+		if r.Time >= weekAgo {
+			weekRewards = weekRewards.Add(r.TotalAmount)
+		}
+	}
+
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("Staked:"), hypeWithValue(delegated, hypePx, style.Green.Render))
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("Undelegated:"), hypeWithValue(undelegated, hypePx, style.White.Render))
+	fmt.Fprintf(&b, "  %s  %s %s\n", style.SummaryLabel.Render("Pending Wdr:"),
+		hypeWithValue(pending, hypePx, style.Yellow.Render),
+		style.Dim.Render(fmt.Sprintf("(%d pending)", summary.NPendingWithdrawals)))
+	fmt.Fprintf(&b, "  %s  %s %s\n", style.SummaryLabel.Render("Rewards:"),
+		hypeWithValue(totalRewards, hypePx, style.Green.Render),
+		style.Dim.Render(fmt.Sprintf("(7d: %s HYPE)", formatHype(weekRewards))))
+
+	// Delegations per validator
+	b.WriteString("\n")
+	rows, total := m.delegationRows()
+	if m.delegationFilter.Shown() {
+		b.WriteString(m.delegationFilter.View(len(rows), total))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.delegations.Header())
+	b.WriteString("\n")
+
+	if total == 0 {
+		b.WriteString(style.Dim.Render("  No delegations"))
+		b.WriteString("\n")
+	}
+
+	cursor, _, _ := table.Window(m.cursor, len(rows), 0)
+	for i, r := range rows {
+		if m.delegationsFocused && i == cursor {
+			b.WriteString(m.delegations.SelectedRow(r))
+		} else {
+			b.WriteString(m.delegations.Row(r))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(style.Dim.Render(separator80))
+	b.WriteString("\n")
+
+	if m.showHistory {
+		m.renderHistory(&b)
+	} else {
+		m.renderRewards(&b, rewards)
+	}
+	return b.String()
+}
+
+// delegationRows returns the delegations the filter keeps, with their
+// validators resolved and in the table's order, and how many there are.
+func (m Model) delegationRows() ([]delegationRow, int) {
+	staking := m.store.Staking()
+	var delegated decimal.Decimal
+	if staking.Summary != nil {
+		delegated = staking.Summary.Delegated
+	}
+	validators := m.store.Validators()
+	hypePx := m.store.MidPrice("HYPE")
+	now := util.Now().UnixMilli()
+
+	rows := make([]delegationRow, len(staking.Delegations))
+	for i, d := range staking.Delegations {
+		r := delegationRow{
+			Delegation: d,
+			name:       config.TruncateAddress(d.Validator),
+			locked:     d.LockedUntilTimestamp > now,
+		}
+		if delegated.Sign() > 0 {
+			r.share = d.Amount.Float64() / delegated.Float64() * 100
+		}
+		if hypePx.Sign() > 0 {
+			r.value = d.Amount.Mul(hypePx)
+		}
+		if v, ok := validators[strings.ToLower(d.Validator)]; ok {
+			if v.Name != "" {
+				r.name = v.Name
+			}
+			r.commission, r.hasComm = v.Commission.Float64(), true
+			if v.IsJailed {
+				r.name += " (jailed)"
+			}
+		}
+		rows[i] = r
+	}
+	total := len(rows)
+	rows = m.delegationFilter.Apply(rows)
+	m.delegations.Sort(rows)
+	return rows, total
+}
+
+// rewardRows returns the rewards the filter keeps, in the table's order,
+// and how many there are.
+func (m Model) rewardRows() ([]rewardRow, int) {
+	rewards := m.store.Staking().Rewards
+	hypePx := m.store.MidPrice("HYPE")
+	rows := make([]rewardRow, len(rewards))
+	for i, r := range rewards {
+		rows[i] = rewardRow{DelegatorReward: r}
+		if hypePx.Sign() > 0 {
+			rows[i].value = r.TotalAmount.Mul(hypePx)
+		}
+	}
+	rows = m.rewardFilter.Apply(rows)
+	m.rewards.Sort(rows)
+	return rows, len(rewards)
+}
+
+// eventRows returns the delegation events the filter keeps, in the table's
+// order, and how many there are.
+func (m Model) eventRows() ([]eventRow, int) {
+	history := m.store.Staking().History
+	rows := make([]eventRow, len(history))
+	for i, e := range history {
+		rows[i] = eventRow{DelegatorEvent: e, kind: e.Delta.Kind()}
+		if e.Delta.Delegate != nil {
+			rows[i].validator = config.TruncateAddress(e.Delta.Delegate.Validator)
+		}
+	}
+	rows = m.historyFilter.Apply(rows)
+	m.history.Sort(rows)
+	return rows, len(history)
+}
+
+// sectionTitle renders the bottom section's title with its key hints.
+func (m Model) sectionTitle(title, other string) string {
+	focus := "[d] sort & filter delegations"
+	if m.delegationsFocused {
+		focus = "[d] sort & filter " + strings.ToLower(title)
+	}
+	return style.White.Render(title) + style.Dim.Render("  [t] "+other+"  "+focus)
+}
+
+// renderRewards draws the reward sparkline and the scrollable reward list.
+func (m Model) renderRewards(b *strings.Builder, rewards []api.DelegatorReward) {
+	b.WriteString(m.sectionTitle("Reward History", "delegation history"))
+	b.WriteString("\n")
+	if len(rewards) == 0 {
+		b.WriteString(style.Dim.Render("  No rewards yet"))
+		return
+	}
+
+	// The sparkline runs oldest to newest whatever the list's order
+	series := make([]api.TimeValue, len(rewards))
+	for i, r := range rewards {
+		series[i] = api.TimeValue{Time: r.Time, Value: r.TotalAmount}
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Time < series[j].Time })
+	b.WriteString(ui.RenderSparkline(series, 60))
+	b.WriteString("\n")
+
+	rows, total := m.rewardRows()
+	if m.rewardFilter.Shown() {
+		b.WriteString(m.rewardFilter.View(len(rows), total))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.rewards.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.listCursor, len(rows), m.listRows(b.String()))
+	for i := start; i < end; i++ {
+		if !m.delegationsFocused && i == cursor {
+			b.WriteString(m.rewards.SelectedRow(rows[i]))
+		} else {
+			b.WriteString(m.rewards.Row(rows[i]))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// renderHistory draws delegate/undelegate/deposit/withdrawal events.
+func (m Model) renderHistory(b *strings.Builder) {
+	b.WriteString(m.sectionTitle("Delegation History", "reward history"))
+	b.WriteString("\n")
+	rows, total := m.eventRows()
+	if total == 0 {
+		b.WriteString(style.Dim.Render("  No staking events"))
+		return
+	}
+
+	if m.historyFilter.Shown() {
+		b.WriteString(m.historyFilter.View(len(rows), total))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.history.Header())
+	b.WriteString("\n")
+
+	cursor, start, end := table.Window(m.listCursor, len(rows), m.listRows(b.String()))
+	for i := start; i < end; i++ {
+		if !m.delegationsFocused && i == cursor {
+			b.WriteString(m.history.SelectedRow(rows[i]))
+		} else {
+			b.WriteString(m.history.Row(rows[i]))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// listRows returns how many rows of the bottom list fit below what has
+// already been rendered; less than 1 shows them all.
+func (m Model) listRows(above string) int {
+	return m.height - strings.Count(above, "\n") - 1
+}
+
+func hypeWithValue(amount, px decimal.Decimal, render func(...string) string) string {
+	s := render(formatHype(amount) + " HYPE")
+	if px.Sign() > 0 {
+		s += "  " + style.Dim.Render(util.FormatUSD(amount.Mul(px)))
+	}
+	return s
+}
+
+func formatHype(val decimal.Decimal) string {
+	return val.StringFixed(4)
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) > maxLen {
+		return s[:maxLen-3] + "..."
+	}
+	return s
+}
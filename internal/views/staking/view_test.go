@@ -0,0 +1,92 @@
+package staking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestHistoryView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m, _ = m.Update(viewtest.Key("t"))
+		return m.View()
+	})
+}
+
+func TestSortKeys(t *testing.T) {
+	s := viewtest.Store(t)
+	m := New(s)
+	m.SetHeight(40)
+
+	// The sort keys act on the list below until d moves them up to the
+	// delegations
+	_, cmd := m.Update(viewtest.Key("s"))
+	if msg, ok := cmd().(table.SortChangedMsg); !ok || msg.Table != "staking-rewards" {
+		t.Fatalf("s on the rewards = %#v", cmd())
+	}
+	m, _ = m.Update(viewtest.Key("d"))
+	m, _ = m.Update(viewtest.Key("<"))
+	m, cmd = m.Update(viewtest.Key("s"))
+	msg, ok := cmd().(table.SortChangedMsg)
+	if !ok || msg.Table != "staking" || msg.Keys[0].Column != "validator" {
+		t.Fatalf("s on the delegations = %#v", cmd())
+	}
+	if view := m.View(); strings.Index(view, "Demo Validator") > strings.Index(view, "Hypurr Collective") {
+		t.Errorf("delegations not sorted by validator:\n%s", view)
+	}
+
+	// A saved order comes back with the view
+	m = New(s)
+	m.SetSortPrefs(config.SortPrefs{msg.Table: msg.Keys})
+	if got := m.delegations.SortLabel(); got != "VALIDATOR ▲" {
+		t.Errorf("restored order = %q", got)
+	}
+}
+
+func TestFilterView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m, _ = m.Update(viewtest.Key("d"))
+		for _, k := range []string{"/", "d", "e", "m", "o"} {
+			m, _ = m.Update(viewtest.Key(k))
+		}
+		if !m.Filtering() {
+			t.Fatal("filter bar not open")
+		}
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return m.View()
+	})
+}
+
+func TestFilterList(t *testing.T) {
+	m := New(viewtest.Store(t))
+	m.SetHeight(40)
+	m, _ = m.Update(viewtest.Key("t"))
+	for _, k := range []string{"/", "x", "y", "z"} {
+		m, _ = m.Update(viewtest.Key(k))
+	}
+	if rows, total := m.eventRows(); len(rows) != 0 || total == 0 {
+		t.Errorf("%d of %d events after filtering on xyz", len(rows), total)
+	}
+	if rows, _ := m.delegationRows(); len(rows) != 2 {
+		t.Errorf("the list's filter hid delegations: %d left", len(rows))
+	}
+}
@@ -0,0 +1,97 @@
+package vaultmgr
+
+import (
+	"fmt"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// followerRow is a follower with the values the table shows.
+type followerRow struct {
+	api.FollowerState
+	name   string
+	leader bool
+	share  float64 // of all follower equity
+	locked bool    // lockup still running
+}
+
+var followerColumns = []table.Column[followerRow]{
+	{
+		Key: "follower", Title: "FOLLOWER", Width: colUser, Left: true,
+		Cell: func(r followerRow) string { return r.name },
+		Style: func(r followerRow) lipgloss.Style {
+			if r.leader {
+				return style.Magenta
+			}
+			return style.White
+		},
+		Cmp: table.By(func(r followerRow) string { return r.name }),
+	},
+	{
+		Key: "equity", Title: "EQUITY", Width: colEquity, Desc: true,
+		Cell:  func(r followerRow) string { return util.FormatUSD(r.VaultEquity) },
+		Style: func(followerRow) lipgloss.Style { return style.Green },
+		Cmp:   table.ByDecimal(func(r followerRow) decimal.Decimal { return r.VaultEquity }),
+	},
+	{
+		Key: "share", Title: "SHARE", Width: colShare, Desc: true,
+		Cell:  func(r followerRow) string { return fmt.Sprintf("%.2f%%", r.share) },
+		Style: func(followerRow) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(r followerRow) float64 { return r.share }),
+	},
+	{
+		Key: "pnl", Title: "PNL", Width: colPnl, Desc: true,
+		Cell:  func(r followerRow) string { return util.FormatSignedUSD(r.Pnl) },
+		Style: func(r followerRow) lipgloss.Style { return style.PnlColor(r.Pnl.Float64()) },
+		Cmp:   table.ByDecimal(func(r followerRow) decimal.Decimal { return r.Pnl }),
+	},
+	{
+		Key: "alltime", Title: "ALL-TIME PNL", Width: colAllTime, Desc: true,
+		Cell:  func(r followerRow) string { return util.FormatSignedUSD(r.AllTimePnl) },
+		Style: func(r followerRow) lipgloss.Style { return style.PnlColor(r.AllTimePnl.Float64()) },
+		Cmp:   table.ByDecimal(func(r followerRow) decimal.Decimal { return r.AllTimePnl }),
+	},
+	{
+		Key: "days", Title: "DAYS", Width: colDays, Desc: true,
+		Cell: func(r followerRow) string { return fmt.Sprintf("%d", r.DaysFollowing) },
+		Cmp:  table.By(func(r followerRow) int { return r.DaysFollowing }),
+	},
+	{
+		Key: "locked", Title: "LOCKED UNTIL", Width: colLockup,
+		Cell: func(r followerRow) string {
+			if !r.locked {
+				return "-"
+			}
+			return util.FormatTime(r.LockupUntil)
+		},
+		Style: func(r followerRow) lipgloss.Style {
+			if !r.locked {
+				return style.Dim
+			}
+			return style.Yellow
+		},
+		Cmp:     table.By(func(r followerRow) int64 { return r.LockupUntil }),
+		Missing: func(r followerRow) bool { return !r.locked },
+	},
+}
+
+var filterFields = []filter.Field[followerRow]{
+	filter.Text("follower", func(r followerRow) string { return r.name }),
+	filter.Decimal("equity", func(r followerRow) decimal.Decimal { return r.VaultEquity }),
+	filter.Number("share", func(r followerRow) float64 { return r.share }),
+	filter.Decimal("pnl", func(r followerRow) decimal.Decimal { return r.Pnl }),
+	filter.Decimal("alltime", func(r followerRow) decimal.Decimal { return r.AllTimePnl }),
+	filter.Number("days", func(r followerRow) float64 { return float64(r.DaysFollowing) }),
+	filter.Time("locked", func(r followerRow) int64 { return r.LockupUntil }),
+}
+
+func newTable() table.Table[followerRow] {
+	return table.New("vault-followers", 2, followerColumns, config.SortKey{Column: "equity", Desc: true})
+}
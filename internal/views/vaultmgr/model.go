@@ -0,0 +1,68 @@
+package vaultmgr
+
+import (
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	store  *store.Store
+	cursor int
+	height int
+	table  table.Table[followerRow]
+	filter filter.Bar[followerRow]
+}
+
+func New(s *store.Store) Model {
+	return Model{store: s, table: newTable(), filter: filter.NewBar(filterFields)}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		var cmd tea.Cmd
+		var used bool
+		if m.filter, cmd, used = m.filter.Update(msg); used {
+			m.cursor = 0
+			return m, cmd
+		}
+		switch msg.String() {
+		case "j", "down":
+			if rows, _ := m.rows(); m.cursor < len(rows)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g":
+			m.cursor = 0
+		default:
+			var cmd tea.Cmd
+			if m.table, cmd = m.table.Update(msg); cmd != nil {
+				m.cursor = 0
+			}
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// SetSortPrefs restores the saved sort order.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.table = m.table.Restore(prefs)
+}
+
+// Filtering reports whether the filter bar has the keyboard.
+func (m Model) Filtering() bool {
+	return m.filter.Editing()
+}
+
+func (m *Model) SetHeight(h int) {
+	m.height = h
+}
@@ -0,0 +1,176 @@
+package vaultmgr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+)
+
+const (
+	colUser    = 14
+	colEquity  = 16
+	colShare   = 8
+	colPnl     = 16
+	colAllTime = 16
+	colDays    = 6
+	colLockup  = 14
+)
+
+var separator80 = strings.Repeat("─", 80)
+
+func (m Model) View() string {
+	d := m.store.ManagedVault()
+	state := m.store.ClearinghouseState()
+
+	if d == nil {
+		return style.Dim.Render("  Loading vault details...")
+	}
+
+	var b strings.Builder
+
+	// Title and state badges
+	status := style.Green.Render("[OPEN]")
+	if d.IsClosed {
+		status = style.Red.Render("[CLOSED]")
+	}
+	deposits := style.Green.Render("[DEPOSITS ALLOWED]")
+	if !d.AllowDeposits {
+		deposits = style.Yellow.Render("[DEPOSITS DISABLED]")
+	}
+	fmt.Fprintf(&b, "%s  %s %s\n", style.White.Render(d.Name), status, deposits)
+	fmt.Fprintf(&b, "%s %s   %s %s\n",
+		style.Dim.Render("Vault:"), d.VaultAddress,
+		style.Dim.Render("Leader:"), style.Magenta.Render(d.Leader),
+	)
+	if desc := strings.TrimSpace(d.Description); desc != "" {
+		b.WriteString(style.Dim.Render(truncate(strings.Join(strings.Fields(desc), " "), 120)))
+		b.WriteString("\n")
+	}
+	b.WriteString(style.Dim.Render(separator80))
+	b.WriteString("\n")
+
+	// TVL: prefer the vault's own account value, fall back to follower equity
+	followers, tvl := m.rows()
+	total := len(d.Followers)
+	if state != nil {
+		if av := state.MarginSummary.AccountValue; av.Sign() > 0 {
+			tvl = av
+		}
+	}
+
+	fmt.Fprintf(&b, "  %s  %s    %s  %s\n",
+		style.SummaryLabel.Render("TVL:"), style.Green.Render(padRight(util.FormatUSD(tvl), 18)),
+		style.SummaryLabel.Render("APR:"), style.PnlColor(d.APR).Render(util.FormatPercent(d.APR*100)),
+	)
+	fmt.Fprintf(&b, "  %s  %s    %s  %s\n",
+		style.SummaryLabel.Render("Commission:"), padRight(fmt.Sprintf("%.2f%%", d.LeaderCommission*100), 18),
+		style.SummaryLabel.Render("Leader Share:"), fmt.Sprintf("%.2f%%", d.LeaderFraction*100),
+	)
+	fmt.Fprintf(&b, "  %s  %s    %s  %s\n",
+		style.SummaryLabel.Render("Distributable:"), padRight(util.FormatUSD(d.MaxDistributable), 18),
+		style.SummaryLabel.Render("Withdrawable:"), util.FormatUSD(d.MaxWithdrawable),
+	)
+
+	// Vault portfolio history
+	periodMap := make(map[string]*api.PortfolioPeriod)
+	for i := range d.Portfolio {
+		periodMap[d.Portfolio[i].Name] = &d.Portfolio[i]
+	}
+	if month := periodMap["month"]; month != nil && len(month.PnlHistory) > 0 {
+		pnl := month.PnlHistory[len(month.PnlHistory)-1].Value
+		fmt.Fprintf(&b, "\n%s %s\n", style.White.Render("PnL (30D)"), style.PnlColor(pnl.Float64()).Render(util.FormatSignedUSD(pnl)))
+		b.WriteString(ui.RenderSparkline(month.PnlHistory, 60))
+		b.WriteString("\n")
+	}
+	if allTime := periodMap["allTime"]; allTime != nil && len(allTime.AccountValueHistory) > 0 {
+		b.WriteString("\n")
+		b.WriteString(style.White.Render("Vault Value History"))
+		b.WriteString("\n")
+		b.WriteString(ui.RenderSparkline(allTime.AccountValueHistory, 60))
+		b.WriteString("\n")
+	}
+
+	// Followers
+	b.WriteString("\n")
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(followers), total))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.table.Header())
+	b.WriteString("\n")
+
+	if total == 0 {
+		b.WriteString(style.Dim.Render("  No followers"))
+		return b.String()
+	}
+
+	// Visible range: whatever height remains below the summary block
+	visibleRows := m.height - strings.Count(b.String(), "\n") - 2
+	cursor, start, end := table.Window(m.cursor, len(followers), visibleRows)
+	for i := start; i < end; i++ {
+		if i == cursor {
+			b.WriteString(m.table.SelectedRow(followers[i]))
+		} else {
+			b.WriteString(m.table.Row(followers[i]))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(style.Dim.Render(fmt.Sprintf("  %d followers  (sorted by %s)", len(followers), m.table.SortLabel())))
+
+	return b.String()
+}
+
+// rows returns the managed vault's followers the filter keeps, in the
+// table's order, and the equity of all of them.
+func (m Model) rows() ([]followerRow, decimal.Decimal) {
+	d := m.store.ManagedVault()
+	if d == nil {
+		return nil, decimal.Zero
+	}
+	var total decimal.Decimal
+	for _, f := range d.Followers {
+		total = total.Add(f.VaultEquity)
+	}
+	now := util.Now().UnixMilli()
+	rows := make([]followerRow, len(d.Followers))
+	for i, f := range d.Followers {
+		r := followerRow{
+			FollowerState: f,
+			name:          config.TruncateAddress(f.User),
+			locked:        f.LockupUntil > now,
+		}
+		if f.User == "Leader" || strings.EqualFold(f.User, d.Leader) {
+			r.name, r.leader = "Leader", true
+		}
+		if total.Sign() > 0 {
+			r.share = f.VaultEquity.Float64() / total.Float64() * 100
+		}
+		rows[i] = r
+	}
+	rows = m.filter.Apply(rows)
+	m.table.Sort(rows)
+	return rows, total
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) > maxLen {
+		return s[:maxLen-3] + "..."
+	}
+	return s
+}
+
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
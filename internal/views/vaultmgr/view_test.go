@@ -0,0 +1,94 @@
+package vaultmgr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+)
+
+const demoVault = "0x1e37a337ed460039d1b15bd3bc489de789768d5e"
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	s.SetManagedVault(s.VaultDetails()[demoVault])
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestViewLoading(t *testing.T) {
+	s := viewtest.Store(t)
+	m := New(s)
+	viewtest.Assert(t, viewtest.Crop(m.View(), 80))
+}
+
+// followedStore returns the demo store managing the demo vault with its
+// leader and two followers.
+func followedStore(t *testing.T) *store.Store {
+	s := viewtest.Store(t)
+	d := *s.VaultDetails()[demoVault]
+	d.Followers = []api.FollowerState{
+		{User: "0x00000000000000000000000000000000000000b1", VaultEquity: decimal.MustParse("25000"), Pnl: decimal.MustParse("1200"), AllTimePnl: decimal.MustParse("3400"), DaysFollowing: 40},
+		{User: d.Leader, VaultEquity: decimal.MustParse("90000"), Pnl: decimal.MustParse("5100"), AllTimePnl: decimal.MustParse("18000"), DaysFollowing: 210},
+		{User: "0x00000000000000000000000000000000000000b2", VaultEquity: decimal.MustParse("5000"), Pnl: decimal.MustParse("-300"), AllTimePnl: decimal.MustParse("-150"), DaysFollowing: 12,
+			LockupUntil: viewtest.Clock.AddDate(0, 0, 2).UnixMilli()},
+	}
+	s.SetManagedVault(&d)
+	return s
+}
+
+func TestFollowersView(t *testing.T) {
+	s := followedStore(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestSortKeys(t *testing.T) {
+	m := New(followedStore(t))
+	m.SetHeight(40)
+
+	// Over to DAYS and ascending: the newest follower first
+	for range 4 {
+		m, _ = m.Update(viewtest.Key(">"))
+	}
+	m, cmd := m.Update(viewtest.Key("s"))
+	m, _ = m.Update(viewtest.Key("s"))
+	if msg, ok := cmd().(table.SortChangedMsg); !ok || msg.Table != "vault-followers" {
+		t.Fatalf("s = %#v", cmd())
+	}
+	rows, _ := m.rows()
+	if len(rows) != 3 || rows[0].DaysFollowing != 12 || !rows[2].leader {
+		t.Errorf("rows = %+v", rows)
+	}
+	if view := m.View(); !strings.Contains(view, "sorted by DAYS ▲") {
+		t.Errorf("footer missing the order:\n%s", view)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	m := New(followedStore(t))
+	m.SetHeight(40)
+	for _, k := range []string{"/", "p", "n", "l", "<", "0"} {
+		m, _ = m.Update(viewtest.Key(k))
+	}
+	if !m.Filtering() {
+		t.Fatal("filter bar not open")
+	}
+	rows, _ := m.rows()
+	if len(rows) != 1 || rows[0].Pnl.Sign() >= 0 {
+		t.Errorf("pnl<0 kept %+v", rows)
+	}
+	if view := m.View(); !strings.Contains(view, "1 of 3") {
+		t.Errorf("filter bar missing:\n%s", view)
+	}
+}
@@ -0,0 +1,453 @@
+package vaults
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	colRank   = 4
+	colXName  = 28
+	colXAPR   = 10
+	colXTVL   = 12
+	colXAge   = 8
+	colXDD    = 9
+	colXShare = 9
+)
+
+type vaultRow struct {
+	name        string
+	address     string
+	leader      string
+	tvl         decimal.Decimal
+	ageDays     float64
+	details     *api.VaultDetails // nil until fetched
+	apr         float64
+	maxDD       float64 // fraction, -1 if unknown
+	leaderShare float64
+	selected    bool // under the cursor
+}
+
+// loading marks values still waiting on the vault's details.
+const loading = "…"
+
+var explorerColumns = []table.Column[vaultRow]{
+	{
+		Key: "name", Title: "VAULT", Width: colXName, Left: true,
+		Cell: func(r vaultRow) string { return truncName(r.name, colXName) },
+		Style: func(r vaultRow) lipgloss.Style {
+			if r.selected {
+				return style.Cyan
+			}
+			return style.White
+		},
+		Cmp: table.By(func(r vaultRow) string { return strings.ToLower(r.name) }),
+	},
+	{
+		Key: "apr", Title: "APR", Width: colXAPR, Desc: true,
+		Cell: func(r vaultRow) string {
+			if r.details == nil {
+				return loading
+			}
+			return util.FormatPercent(r.apr * 100)
+		},
+		Style: func(r vaultRow) lipgloss.Style {
+			if r.details == nil {
+				return style.Dim
+			}
+			return style.PnlColor(r.apr)
+		},
+		Cmp:     table.By(func(r vaultRow) float64 { return r.apr }),
+		Missing: vaultRow.loading,
+	},
+	{
+		Key: "tvl", Title: "TVL", Width: colXTVL, Desc: true,
+		Cell:  func(r vaultRow) string { return formatCompactUSD(r.tvl.Float64()) },
+		Style: func(vaultRow) lipgloss.Style { return style.Green },
+		Cmp:   table.ByDecimal(func(r vaultRow) decimal.Decimal { return r.tvl }),
+	},
+	{
+		Key: "age", Title: "AGE", Width: colXAge, Desc: true,
+		Cell: func(r vaultRow) string { return formatAge(r.ageDays) },
+		Cmp:  table.By(func(r vaultRow) float64 { return r.ageDays }),
+	},
+	{
+		Key: "maxdd", Title: "MAX DD", Width: colXDD, Desc: true,
+		Cell: func(r vaultRow) string {
+			if r.maxDD < 0 {
+				return loading
+			}
+			return fmt.Sprintf("-%.1f%%", r.maxDD*100)
+		},
+		Style: func(r vaultRow) lipgloss.Style {
+			if r.maxDD < 0 {
+				return style.Dim
+			}
+			return style.Red
+		},
+		Cmp:     table.By(func(r vaultRow) float64 { return r.maxDD }),
+		Missing: func(r vaultRow) bool { return r.maxDD < 0 },
+	},
+	{
+		Key: "leader", Title: "LEADER", Width: colXShare, Desc: true,
+		Cell: func(r vaultRow) string {
+			if r.details == nil {
+				return loading
+			}
+			return fmt.Sprintf("%.1f%%", r.leaderShare*100)
+		},
+		Style: func(r vaultRow) lipgloss.Style {
+			if r.details == nil {
+				return style.Dim
+			}
+			return lipgloss.NewStyle()
+		},
+		Cmp:     table.By(func(r vaultRow) float64 { return r.leaderShare }),
+		Missing: vaultRow.loading,
+	},
+}
+
+// loading reports whether the vault's details have yet to arrive. Such
+// vaults sink to the bottom of any sort on the values they carry.
+func (r vaultRow) loading() bool {
+	return r.details == nil
+}
+
+var filterFields = []filter.Field[vaultRow]{
+	filter.Text("name", func(r vaultRow) string { return r.name }),
+	filter.Text("leader", func(r vaultRow) string { return r.leader }),
+	filter.Number("apr", func(r vaultRow) float64 {
+		if r.loading() {
+			return math.NaN()
+		}
+		return r.apr * 100
+	}),
+	filter.Decimal("tvl", func(r vaultRow) decimal.Decimal { return r.tvl }),
+	filter.Number("age", func(r vaultRow) float64 { return r.ageDays }),
+	filter.Number("maxdd", func(r vaultRow) float64 {
+		if r.maxDD < 0 {
+			return math.NaN()
+		}
+		return r.maxDD * 100
+	}),
+	filter.Number("share", func(r vaultRow) float64 {
+		if r.loading() {
+			return math.NaN()
+		}
+		return r.leaderShare * 100
+	}),
+}
+
+// explorerCache keeps the explorer's rows between frames. They are rebuilt
+// when the vault list, any vault's details or the TVL threshold change, and
+// a vault's drawdown is only worked out again when its details do.
+type explorerCache struct {
+	revision  uint64
+	threshold float64
+	rows      []vaultRow // nil until built
+	drawdowns map[*api.VaultDetails]float64
+}
+
+// explorerRows returns the sorted explorer list, without the vaults under
+// the TVL threshold or hidden by the filter bar. It also returns how many
+// passed the TVL threshold.
+func (m Model) explorerRows() ([]vaultRow, int) {
+	rows := slices.Clone(m.cachedRows())
+	total := len(rows)
+	rows = m.filter.Apply(rows)
+	m.table.Sort(rows)
+	return rows, total
+}
+
+// cachedRows returns the vaults over the TVL threshold, unsorted, building
+// them again only if the store's vaults or the threshold have changed.
+// Callers must not modify the rows.
+func (m Model) cachedRows() []vaultRow {
+	revision, threshold := m.store.VaultRevision(), m.TVLThreshold()
+	c := m.rows
+	if c == nil {
+		c = &explorerCache{}
+	}
+	if c.rows != nil && c.revision == revision && c.threshold == threshold {
+		return c.rows
+	}
+	c.rows, c.drawdowns = m.buildRows(c.drawdowns, threshold)
+	c.revision, c.threshold = revision, threshold
+	return c.rows
+}
+
+// buildRows builds the explorer's rows from the store, reusing the
+// drawdowns worked out for details already seen. It returns the drawdowns
+// of the details in use.
+func (m Model) buildRows(known map[*api.VaultDetails]float64, threshold float64) ([]vaultRow, map[*api.VaultDetails]float64) {
+	summaries := m.store.VaultSummaries()
+	details := m.store.VaultDetails()
+
+	now := util.Now().UnixMilli()
+
+	rows := []vaultRow{}
+	drawdowns := make(map[*api.VaultDetails]float64)
+	for _, s := range summaries {
+		if s.IsClosed {
+			continue
+		}
+		// Child vaults (e.g. HLP strategies) are reached through their parent
+		if s.Relationship.Type == "child" {
+			continue
+		}
+		tvl := s.Tvl
+		if tvl.Float64() < threshold {
+			continue
+		}
+		r := vaultRow{
+			name:    s.Name,
+			address: s.VaultAddress,
+			leader:  s.Leader,
+			tvl:     tvl,
+			ageDays: float64(now-s.CreateTimeMillis) / float64(24*time.Hour/time.Millisecond),
+			maxDD:   -1,
+		}
+		if d, ok := details[s.VaultAddress]; ok && d != nil {
+			r.details = d
+			r.apr = d.APR
+			r.leaderShare = d.LeaderFraction
+			dd, ok := known[d]
+			if !ok {
+				dd = maxDrawdown(d.Portfolio, "allTime")
+			}
+			r.maxDD, drawdowns[d] = dd, dd
+		}
+		rows = append(rows, r)
+	}
+	return rows, drawdowns
+}
+
+// MissingDetails returns the explorer vaults whose details haven't loaded,
+// the one under the cursor first and then the rest in view, so the app can
+// fetch what is on screen before anything else.
+func (m Model) MissingDetails() []string {
+	if !m.explorer || !m.store.VaultSummariesLoaded() {
+		return nil
+	}
+	rows, _ := m.explorerRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	cursor, start, end := table.Window(m.cursor, len(rows), max(m.listRows(), 1))
+	var addrs []string
+	if rows[cursor].loading() {
+		addrs = append(addrs, rows[cursor].address)
+	}
+	for _, r := range rows[start:end] {
+		if r.loading() && r.address != rows[cursor].address {
+			addrs = append(addrs, r.address)
+		}
+	}
+	return addrs
+}
+
+// listRows is how many vaults fit in the explorer list.
+func (m Model) listRows() int {
+	if m.filter.Shown() {
+		return m.height - 4
+	}
+	return m.height - 3
+}
+
+func (m Model) selectedAddress() string {
+	rows, _ := m.explorerRows()
+	if len(rows) == 0 {
+		return ""
+	}
+	c, _, _ := table.Window(m.cursor, len(rows), 0)
+	return rows[c].address
+}
+
+func (m Model) explorerView() string {
+	loaded := m.store.VaultSummariesLoaded()
+	if !loaded {
+		return style.Dim.Render("  Loading vault list...")
+	}
+
+	rows, total := m.explorerRows()
+	if len(rows) > 0 && m.showDetail {
+		c, _, _ := table.Window(m.cursor, len(rows), 0)
+		return renderVaultDetail(rows[c])
+	}
+
+	var b strings.Builder
+	visibleRows := m.listRows()
+	if m.filter.Shown() {
+		b.WriteString(m.filter.View(len(rows), total))
+		b.WriteString("\n")
+	}
+	if len(rows) == 0 {
+		b.WriteString(style.Dim.Render("  No vaults match the filter") + "\n\n" + m.explorerFooter(0))
+		return b.String()
+	}
+
+	cursor, start, end := table.Window(m.cursor, len(rows), visibleRows)
+	rows[cursor].selected = true
+
+	b.WriteString(style.TableHeader.Render(padRight("#", colRank)))
+	b.WriteString(m.table.Header())
+	b.WriteString("\n")
+
+	for i := start; i < end; i++ {
+		marker := "  "
+		if i == cursor {
+			marker = style.Cyan.Render("▸ ")
+		}
+		b.WriteString(marker + style.Dim.Render(padRight(fmt.Sprintf("%d", i+1), colRank-2)))
+		b.WriteString(m.table.Row(rows[i]))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.explorerFooter(len(rows)))
+	return b.String()
+}
+
+func (m Model) explorerFooter(n int) string {
+	threshold := m.TVLThreshold()
+	filterLabel := "OFF"
+	if threshold > 0 {
+		filterLabel = "≥" + formatCompactUSD(threshold)
+	}
+	return style.Dim.Render(fmt.Sprintf("  %d vaults  (sorted by %s)  ", n, m.table.SortLabel())) +
+		style.Yellow.Render(fmt.Sprintf("[f] TVL filter: %s", filterLabel)) +
+		style.Dim.Render("  [</>] column  [s] sort  [enter] details  [e] my vaults")
+}
+
+func renderVaultDetail(r vaultRow) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s  %s\n", style.White.Render(r.name), style.Dim.Render(r.address))
+	fmt.Fprintf(&b, "%s %s\n", style.Dim.Render("Leader:"), style.Magenta.Render(config.TruncateAddress(r.leader)))
+
+	d := r.details
+	if d == nil {
+		b.WriteString("\n")
+		b.WriteString(style.Dim.Render("  Loading vault details..."))
+		return b.String()
+	}
+
+	if desc := strings.TrimSpace(d.Description); desc != "" {
+		b.WriteString(style.Dim.Render(truncName(strings.Join(strings.Fields(desc), " "), 120)))
+		b.WriteString("\n")
+	}
+	b.WriteString(style.Dim.Render(strings.Repeat("─", 80)))
+	b.WriteString("\n")
+
+	ddStr := "-"
+	if r.maxDD >= 0 {
+		ddStr = fmt.Sprintf("-%.2f%%", r.maxDD*100)
+	}
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("APR:"), style.PnlColor(r.apr).Render(util.FormatPercent(r.apr*100)))
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("TVL:"), style.Green.Render(util.FormatUSD(r.tvl)))
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("Age:"), formatAge(r.ageDays))
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("Max Drawdown:"), style.Red.Render(ddStr))
+	fmt.Fprintf(&b, "  %s  %.2f%%\n", style.SummaryLabel.Render("Leader Share:"), r.leaderShare*100)
+	fmt.Fprintf(&b, "  %s  %.2f%%\n", style.SummaryLabel.Render("Commission:"), d.LeaderCommission*100)
+	fmt.Fprintf(&b, "  %s  %d\n", style.SummaryLabel.Render("Followers:"), len(d.Followers))
+
+	deposits := style.Green.Render("open")
+	if !d.AllowDeposits {
+		deposits = style.Yellow.Render("disabled")
+	}
+	fmt.Fprintf(&b, "  %s  %s\n", style.SummaryLabel.Render("Deposits:"), deposits)
+
+	for _, p := range d.Portfolio {
+		switch p.Name {
+		case "month":
+			if len(p.PnlHistory) > 0 {
+				b.WriteString("\n")
+				b.WriteString(style.White.Render("PnL (30D)"))
+				b.WriteString("\n")
+				b.WriteString(ui.RenderSparkline(p.PnlHistory, 60))
+				b.WriteString("\n")
+			}
+		case "allTime":
+			if len(p.AccountValueHistory) > 0 {
+				b.WriteString("\n")
+				b.WriteString(style.White.Render("Vault Value History"))
+				b.WriteString("\n")
+				b.WriteString(ui.RenderSparkline(p.AccountValueHistory, 60))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(style.Dim.Render("  [enter/esc] back to list"))
+	return b.String()
+}
+
+// maxDrawdown returns the largest peak-to-trough decline (as a fraction) of
+// the vault's compounded returns over the named portfolio period. Returns are
+// derived from PnL deltas over the prior account value so deposits and
+// withdrawals don't register as gains or losses. Returns -1 if the history is
+// too short.
+func maxDrawdown(periods []api.PortfolioPeriod, name string) float64 {
+	for _, p := range periods {
+		if p.Name != name {
+			continue
+		}
+		n := len(p.AccountValueHistory)
+		if len(p.PnlHistory) < n {
+			n = len(p.PnlHistory)
+		}
+		if n < 2 {
+			return -1
+		}
+		index, peak, maxDD := 1.0, 1.0, 0.0
+		for i := 1; i < n; i++ {
+			prevValue := p.AccountValueHistory[i-1].Value.Float64()
+			if prevValue <= 0 {
+				continue
+			}
+			ret := p.PnlHistory[i].Value.Sub(p.PnlHistory[i-1].Value).Float64() / prevValue
+			index *= 1 + ret
+			peak = math.Max(peak, index)
+			if dd := (peak - index) / peak; dd > maxDD {
+				maxDD = dd
+			}
+		}
+		return maxDD
+	}
+	return -1
+}
+
+func formatAge(days float64) string {
+	if days >= 365 {
+		return fmt.Sprintf("%.1fy", days/365)
+	}
+	return fmt.Sprintf("%.0fd", days)
+}
+
+func formatCompactUSD(val float64) string {
+	abs := math.Abs(val)
+	if abs >= 1_000_000_000 {
+		return fmt.Sprintf("$%.2fB", val/1_000_000_000)
+	}
+	if abs >= 1_000_000 {
+		return fmt.Sprintf("$%.2fM", val/1_000_000)
+	}
+	if abs >= 1_000 {
+		return fmt.Sprintf("$%.1fK", val/1_000)
+	}
+	return fmt.Sprintf("$%.0f", val)
+}
@@ -1,37 +1,253 @@
 package vaults
 
 import (
+	"strings"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
 	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// TVL filter thresholds in USD for the explorer. Pressing 'f' cycles through these.
+var tvlThresholds = []float64{
+	0,          // no filter
+	10_000,     // $10K
+	100_000,    // $100K (default)
+	1_000_000,  // $1M
+	10_000_000, // $10M
+}
+
+const defaultTVLIndex = 2 // $100K
+
+// ExplorerOpenedMsg is emitted when the explorer is shown so the app can load
+// the public vault list and enrich it with vault details.
+type ExplorerOpenedMsg struct{}
+
+// UnlocksOpenedMsg is emitted when the unlock timeline is shown so the app
+// can refresh vault equities for every configured wallet.
+type UnlocksOpenedMsg struct{}
+
+// ToggleLockupAlertsMsg asks the app to flip lockup-expiry alerts.
+type ToggleLockupAlertsMsg struct{}
+
+// DetailsRequestMsg asks the app to fetch vaultDetails for one vault.
+type DetailsRequestMsg struct {
+	Address string
+}
+
 type Model struct {
 	store  *store.Store
-	scroll int
 	height int
+
+	// The wallet's vault investments
+	investCursor int
+	investTable  table.Table[investmentRow]
+	investFilter filter.Bar[investmentRow]
+
+	// Unlock timeline across all wallets
+	timeline     bool
+	unlockCursor int
+	unlockTable  table.Table[unlockRow]
+	unlockFilter filter.Bar[unlockRow]
+	lockupAlerts bool
+	activeAddr   string
+	walletNames  map[string]string // address -> wallet name
+
+	// Explorer mode
+	explorer     bool
+	cursor       int
+	showDetail   bool
+	table        table.Table[vaultRow]
+	filter       filter.Bar[vaultRow]
+	tvlFilterIdx int
+	rows         *explorerCache // shared by copies of the model
 }
 
 func New(s *store.Store) Model {
-	return Model{store: s}
+	return Model{
+		store:        s,
+		investTable:  table.New("vaults", 2, investmentColumns, config.SortKey{Column: "equity", Desc: true}),
+		investFilter: filter.NewBar(investmentFilterFields),
+		unlockTable:  table.New("vault-unlocks", 2, unlockColumns, config.SortKey{Column: "unlocks"}),
+		unlockFilter: filter.NewBar(unlockFilterFields),
+		table:        table.New("vault-explorer", 2, explorerColumns, config.SortKey{Column: "apr", Desc: true}),
+		filter:       filter.NewBar(filterFields),
+		tvlFilterIdx: defaultTVLIndex,
+		rows:         &explorerCache{},
+	}
 }
 
 func (m Model) Init() tea.Cmd { return nil }
 
+func (m Model) TVLThreshold() float64 {
+	return tvlThresholds[m.tvlFilterIdx]
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		var cmd tea.Cmd
+		var used bool
+		switch {
+		case m.explorer && !m.showDetail:
+			if m.filter, cmd, used = m.filter.Update(msg); used {
+				m.cursor = 0
+			}
+		case m.timeline:
+			if m.unlockFilter, cmd, used = m.unlockFilter.Update(msg); used {
+				m.unlockCursor = 0
+			}
+		case !m.explorer:
+			if m.investFilter, cmd, used = m.investFilter.Update(msg); used {
+				m.investCursor = 0
+			}
+		}
+		if used {
+			return m, cmd
+		}
 		switch msg.String() {
-		case "j", "down":
-			m.scroll++
-		case "k", "up":
-			if m.scroll > 0 {
-				m.scroll--
+		case "e":
+			m.explorer = !m.explorer
+			m.timeline = false
+			m.showDetail = false
+			if m.explorer {
+				return m, func() tea.Msg { return ExplorerOpenedMsg{} }
+			}
+			return m, nil
+		case "u":
+			m.timeline = !m.timeline
+			m.explorer = false
+			m.unlockCursor = 0
+			if m.timeline {
+				return m, func() tea.Msg { return UnlocksOpenedMsg{} }
 			}
+			return m, nil
+		case "a":
+			return m, func() tea.Msg { return ToggleLockupAlertsMsg{} }
 		}
+		if m.explorer {
+			return m.updateExplorer(msg)
+		}
+		if m.timeline {
+			return m.updateTimeline(msg)
+		}
+		return m.updateInvestments(msg)
 	}
 	return m, nil
 }
 
+func (m Model) updateInvestments(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if rows, _ := m.investmentRows(); m.investCursor < len(rows)-1 {
+			m.investCursor++
+		}
+	case "k", "up":
+		if m.investCursor > 0 {
+			m.investCursor--
+		}
+	case "g":
+		m.investCursor = 0
+	default:
+		var cmd tea.Cmd
+		if m.investTable, cmd = m.investTable.Update(msg); cmd != nil {
+			m.investCursor = 0
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m Model) updateTimeline(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.unlockCursor < len(m.unlockRows(m.store.VaultUnlocks(m.activeAddr)))-1 {
+			m.unlockCursor++
+		}
+	case "k", "up":
+		if m.unlockCursor > 0 {
+			m.unlockCursor--
+		}
+	case "g":
+		m.unlockCursor = 0
+	default:
+		var cmd tea.Cmd
+		if m.unlockTable, cmd = m.unlockTable.Update(msg); cmd != nil {
+			m.unlockCursor = 0
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m Model) updateExplorer(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.cursor++
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "g":
+		m.cursor = 0
+	case "f":
+		m.tvlFilterIdx = (m.tvlFilterIdx + 1) % len(tvlThresholds)
+		m.cursor = 0
+	case "enter":
+		m.showDetail = !m.showDetail
+		if m.showDetail {
+			if addr := m.selectedAddress(); addr != "" {
+				return m, func() tea.Msg { return DetailsRequestMsg{Address: addr} }
+			}
+		}
+	case "esc":
+		m.showDetail = false
+	default:
+		var cmd tea.Cmd
+		if m.table, cmd = m.table.Update(msg); cmd != nil {
+			m.cursor = 0
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+// SetSortPrefs restores the saved sort orders.
+func (m *Model) SetSortPrefs(prefs config.SortPrefs) {
+	m.investTable = m.investTable.Restore(prefs)
+	m.unlockTable = m.unlockTable.Restore(prefs)
+	m.table = m.table.Restore(prefs)
+}
+
+// Filtering reports whether the filter bar of the table on screen has the
+// keyboard.
+func (m Model) Filtering() bool {
+	switch {
+	case m.explorer:
+		return m.filter.Editing()
+	case m.timeline:
+		return m.unlockFilter.Editing()
+	}
+	return m.investFilter.Editing()
+}
+
 func (m *Model) SetHeight(h int) {
 	m.height = h
 }
+
+// SetWallets tells the view which wallet is active and how to label the
+// others in the unlock timeline.
+func (m *Model) SetWallets(wallets []config.Wallet, activeAddr string) {
+	m.activeAddr = activeAddr
+	m.walletNames = make(map[string]string, len(wallets))
+	for _, w := range wallets {
+		m.walletNames[strings.ToLower(w.Address)] = w.Name
+	}
+}
+
+// SetLockupAlerts reflects whether lockup-expiry alerts are enabled.
+func (m *Model) SetLockupAlerts(on bool) {
+	m.lockupAlerts = on
+}
@@ -0,0 +1,188 @@
+package vaults
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/config"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	colTWhen   = 16
+	colTIn     = 10
+	colTWallet = 14
+	colTVault  = 28
+	colTEquity = 14
+	colTWithdr = 14
+)
+
+// unlockRow is one wallet's vault stake with the names and estimate the
+// timeline shows.
+type unlockRow struct {
+	store.VaultUnlock
+	wallet       string // the wallet's name, or its truncated address
+	active       bool   // the active wallet's stake
+	vault        string
+	withdrawable decimal.Decimal
+}
+
+var unlockColumns = []table.Column[unlockRow]{
+	{
+		Key: "unlocks", Title: "UNLOCKS AT", Width: colTWhen, Left: true,
+		Cell: func(r unlockRow) string {
+			if r.UnlockAt == 0 {
+				return "-"
+			}
+			return util.FormatTime(r.UnlockAt)
+		},
+		Style: func(unlockRow) lipgloss.Style { return style.Dim },
+		Cmp:   table.By(func(r unlockRow) int64 { return r.UnlockAt }),
+	},
+	{
+		Key: "in", Title: "IN", Width: colTIn,
+		Cell: func(r unlockRow) string {
+			if remaining := time.UnixMilli(r.UnlockAt).Sub(util.Now()); remaining > 0 {
+				return util.FormatCountdown(remaining)
+			}
+			return "unlocked"
+		},
+		Style: func(r unlockRow) lipgloss.Style {
+			if r.UnlockAt > util.Now().UnixMilli() {
+				return style.Yellow
+			}
+			return style.Green
+		},
+	},
+	{
+		Key: "wallet", Title: "WALLET", Width: colTWallet, Left: true,
+		Cell: func(r unlockRow) string { return truncName(r.wallet, colTWallet) },
+		Style: func(r unlockRow) lipgloss.Style {
+			if r.active {
+				return style.Cyan
+			}
+			return style.White
+		},
+		Cmp: table.By(func(r unlockRow) string { return strings.ToLower(r.wallet) }),
+	},
+	{
+		Key: "vault", Title: "VAULT", Width: colTVault, Left: true,
+		Cell:  func(r unlockRow) string { return truncName(r.vault, colTVault) },
+		Style: func(unlockRow) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(r unlockRow) string { return strings.ToLower(r.vault) }),
+	},
+	{
+		Key: "equity", Title: "EQUITY", Width: colTEquity, Desc: true,
+		Cell:  func(r unlockRow) string { return util.FormatUSD(r.Equity) },
+		Style: func(unlockRow) lipgloss.Style { return style.Green },
+		Cmp:   table.ByDecimal(func(r unlockRow) decimal.Decimal { return r.Equity }),
+	},
+	{
+		Key: "withdrawable", Title: "WITHDRAWABLE", Width: colTWithdr, Desc: true,
+		Cell: func(r unlockRow) string { return util.FormatUSD(r.withdrawable) },
+		Cmp:  table.ByDecimal(func(r unlockRow) decimal.Decimal { return r.withdrawable }),
+	},
+}
+
+var unlockFilterFields = []filter.Field[unlockRow]{
+	filter.Time("unlocks", func(r unlockRow) int64 { return r.UnlockAt }),
+	filter.Text("wallet", func(r unlockRow) string { return r.wallet }),
+	filter.Text("vault", func(r unlockRow) string { return r.vault }),
+	filter.Decimal("equity", func(r unlockRow) decimal.Decimal { return r.Equity }),
+	filter.Decimal("withdrawable", func(r unlockRow) decimal.Decimal { return r.withdrawable }),
+}
+
+// timelineView lists every wallet's vault stakes, by default ordered by
+// when their lockups end, with an estimate of what becomes withdrawable.
+func (m Model) timelineView() string {
+	unlocks := m.store.VaultUnlocks(m.activeAddr)
+	if len(unlocks) == 0 {
+		return style.Dim.Render("  No vault stakes in any wallet") + "\n\n" + m.footerKeys()
+	}
+	rows := m.unlockRows(unlocks)
+
+	var b strings.Builder
+	visibleRows := m.height - 6
+	if m.unlockFilter.Shown() {
+		b.WriteString(m.unlockFilter.View(len(rows), len(unlocks)))
+		b.WriteString("\n")
+		visibleRows--
+	}
+	b.WriteString(m.unlockTable.Header())
+	b.WriteString("\n")
+
+	now := util.Now()
+	nowMs := now.UnixMilli()
+	weekAhead := now.Add(7 * 24 * time.Hour).UnixMilli()
+
+	var unlockedNow, unlockingWeek, lockedLater decimal.Decimal
+	for _, u := range unlocks {
+		switch {
+		case u.UnlockAt <= nowMs:
+			unlockedNow = unlockedNow.Add(u.Equity)
+		case u.UnlockAt <= weekAhead:
+			unlockingWeek = unlockingWeek.Add(u.Equity)
+		default:
+			lockedLater = lockedLater.Add(u.Equity)
+		}
+	}
+
+	if len(rows) == 0 {
+		b.WriteString(style.Dim.Render("  No vault stakes match the filter"))
+		b.WriteString("\n")
+	}
+	cursor, start, end := table.Window(m.unlockCursor, len(rows), visibleRows)
+	for i := start; i < end; i++ {
+		if i == cursor {
+			b.WriteString(m.unlockTable.SelectedRow(rows[i]))
+		} else {
+			b.WriteString(m.unlockTable.Row(rows[i]))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  %s %s   %s %s   %s %s  %s\n",
+		style.White.Render("Unlocked:"), style.Green.Render(util.FormatUSD(unlockedNow)),
+		style.White.Render("Next 7d:"), style.Yellow.Render(util.FormatUSD(unlockingWeek)),
+		style.White.Render("Locked >7d:"), style.Dim.Render(util.FormatUSD(lockedLater)),
+		style.Dim.Render(fmt.Sprintf("(sorted by %s)", m.unlockTable.SortLabel())),
+	)
+	b.WriteString(m.footerKeys())
+	return b.String()
+}
+
+// unlockRows resolves unlocks' wallet and vault names, keeping those the
+// filter does, in the table's order.
+func (m Model) unlockRows(unlocks []store.VaultUnlock) []unlockRow {
+	details := m.store.VaultDetails()
+	now := util.Now().UnixMilli()
+
+	rows := make([]unlockRow, len(unlocks))
+	for i, u := range unlocks {
+		r := unlockRow{
+			VaultUnlock:  u,
+			wallet:       m.walletNames[strings.ToLower(u.Wallet)],
+			active:       strings.EqualFold(u.Wallet, m.activeAddr),
+			vault:        m.store.VaultName(u.VaultAddress),
+			withdrawable: estimateWithdrawable(u.Equity, u.UnlockAt, now, details[u.VaultAddress]),
+		}
+		if r.wallet == "" {
+			r.wallet = config.TruncateAddress(u.Wallet)
+		}
+		if r.vault == "" {
+			r.vault = truncAddr(u.VaultAddress)
+		}
+		rows[i] = r
+	}
+	rows = m.unlockFilter.Apply(rows)
+	m.unlockTable.Sort(rows)
+	return rows
+}
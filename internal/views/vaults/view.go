@@ -2,10 +2,20 @@ package vaults
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/filter"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
 	"github.com/born1337/hyperliquid-terminal/internal/style"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/ui"
 	"github.com/born1337/hyperliquid-terminal/internal/util"
+	"github.com/charmbracelet/lipgloss"
 )
 
 const (
@@ -14,87 +24,269 @@ const (
 	colPnl     = 16
 	colAllTime = 16
 	colAPR     = 8
+	colLockup  = 10
+	colWithdr  = 14
 )
 
-func (m Model) View() string {
-	m.store.RLock()
-	equities := m.store.VaultEquities
-	details := m.store.VaultDetails
-	m.store.RUnlock()
-
-	if len(equities) == 0 {
-		return style.Dim.Render("  No vault investments")
-	}
-
-	var b strings.Builder
-
-	header := padRight("VAULT", colName) + "  " +
-		padLeft("YOUR EQUITY", colEquity) + "  " +
-		padLeft("YOUR PNL", colPnl) + "  " +
-		padLeft("ALL-TIME PNL", colAllTime) + "  " +
-		padLeft("APR", colAPR)
-	b.WriteString(style.TableHeader.Render(header))
-	b.WriteString("\n")
+// investmentRow is one of the wallet's vault stakes with its vault's
+// details resolved.
+type investmentRow struct {
+	api.VaultEquity
+	name         string
+	equity       decimal.Decimal // the follower state's when it has one
+	pnl          decimal.Decimal
+	allTimePnl   decimal.Decimal
+	hasPnl       bool // the vault's details carry the wallet's follower state
+	apr          float64
+	unlockAt     int64 // ms; 0 if never locked
+	withdrawable decimal.Decimal
+}
 
-	var totalEquity float64
+// locked reports whether the stake's lockup is still running.
+func (r investmentRow) locked() bool {
+	return r.unlockAt > util.Now().UnixMilli()
+}
 
-	for _, ve := range equities {
-		equity := util.ParseFloat(ve.Equity)
-		totalEquity += equity
+var investmentColumns = []table.Column[investmentRow]{
+	{
+		Key: "vault", Title: "VAULT", Width: colName, Left: true,
+		Cell:  func(r investmentRow) string { return truncName(r.name, colName) },
+		Style: func(investmentRow) lipgloss.Style { return style.White },
+		Cmp:   table.By(func(r investmentRow) string { return strings.ToLower(r.name) }),
+	},
+	{
+		Key: "equity", Title: "YOUR EQUITY", Width: colEquity, Desc: true,
+		Cell:  func(r investmentRow) string { return util.FormatUSD(r.equity) },
+		Style: func(investmentRow) lipgloss.Style { return style.Green },
+		Cmp:   table.ByDecimal(func(r investmentRow) decimal.Decimal { return r.equity }),
+	},
+	{
+		Key: "pnl", Title: "YOUR PNL", Width: colPnl, Desc: true,
+		Cell: func(r investmentRow) string {
+			if !r.hasPnl {
+				return "-"
+			}
+			return util.FormatSignedUSD(r.pnl)
+		},
+		Style: func(r investmentRow) lipgloss.Style {
+			if !r.hasPnl {
+				return style.Dim
+			}
+			return style.PnlColor(r.pnl.Float64())
+		},
+		Cmp:     table.ByDecimal(func(r investmentRow) decimal.Decimal { return r.pnl }),
+		Missing: func(r investmentRow) bool { return !r.hasPnl },
+	},
+	{
+		Key: "alltime", Title: "ALL-TIME PNL", Width: colAllTime, Desc: true,
+		Cell: func(r investmentRow) string {
+			if !r.hasPnl {
+				return "-"
+			}
+			return util.FormatSignedUSD(r.allTimePnl)
+		},
+		Style: func(r investmentRow) lipgloss.Style {
+			if !r.hasPnl {
+				return style.Dim
+			}
+			return style.PnlColor(r.allTimePnl.Float64())
+		},
+		Cmp:     table.ByDecimal(func(r investmentRow) decimal.Decimal { return r.allTimePnl }),
+		Missing: func(r investmentRow) bool { return !r.hasPnl },
+	},
+	{
+		Key: "apr", Title: "APR", Width: colAPR, Desc: true,
+		Cell: func(r investmentRow) string {
+			if r.apr == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f%%", r.apr*100)
+		},
+		Style: func(r investmentRow) lipgloss.Style {
+			if r.apr == 0 {
+				return style.Dim
+			}
+			return style.PnlColor(r.apr)
+		},
+		Cmp:     table.By(func(r investmentRow) float64 { return r.apr }),
+		Missing: func(r investmentRow) bool { return r.apr == 0 },
+	},
+	{
+		Key: "lockup", Title: "LOCKUP", Width: colLockup,
+		Cell: func(r investmentRow) string {
+			if !r.locked() {
+				return "unlocked"
+			}
+			return util.FormatCountdown(time.UnixMilli(r.unlockAt).Sub(util.Now()))
+		},
+		Style: func(r investmentRow) lipgloss.Style {
+			if !r.locked() {
+				return style.Green
+			}
+			return style.Yellow
+		},
+		Cmp: table.By(func(r investmentRow) int64 { return r.unlockAt }),
+	},
+	{
+		Key: "withdrawable", Title: "WITHDRAWABLE", Width: colWithdr, Desc: true,
+		Cell: func(r investmentRow) string { return util.FormatUSD(r.withdrawable) },
+		Style: func(r investmentRow) lipgloss.Style {
+			if r.withdrawable.Sign() > 0 {
+				return style.Green
+			}
+			return style.Dim
+		},
+		Cmp: table.ByDecimal(func(r investmentRow) decimal.Decimal { return r.withdrawable }),
+	},
+}
 
-		name := truncAddr(ve.VaultAddress)
-		pnlCell := style.Dim.Render(padLeft("-", colPnl))
-		allTimeCell := style.Dim.Render(padLeft("-", colAllTime))
-		aprCell := style.Dim.Render(padLeft("-", colAPR))
+var investmentFilterFields = []filter.Field[investmentRow]{
+	filter.Text("vault", func(r investmentRow) string { return r.name }),
+	filter.Decimal("equity", func(r investmentRow) decimal.Decimal { return r.equity }),
+	filter.Number("pnl", func(r investmentRow) float64 {
+		if !r.hasPnl {
+			return math.NaN()
+		}
+		return r.pnl.Float64()
+	}),
+	filter.Number("alltime", func(r investmentRow) float64 {
+		if !r.hasPnl {
+			return math.NaN()
+		}
+		return r.allTimePnl.Float64()
+	}),
+	filter.Number("apr", func(r investmentRow) float64 {
+		if r.apr == 0 {
+			return math.NaN()
+		}
+		return r.apr * 100
+	}),
+	filter.Time("lockup", func(r investmentRow) int64 { return r.unlockAt }),
+	filter.Decimal("withdrawable", func(r investmentRow) decimal.Decimal { return r.withdrawable }),
+}
 
-		if d, ok := details[ve.VaultAddress]; ok {
-			name = truncName(d.Name, colName)
+func (m Model) View() string {
+	if m.explorer {
+		return m.explorerView()
+	}
+	if m.timeline {
+		return m.timelineView()
+	}
 
-			if d.FollowerState != nil {
-				pnl := util.ParseFloat(d.FollowerState.Pnl)
-				allTimePnl := util.ParseFloat(d.FollowerState.AllTimePnl)
-				pnlCell = style.PnlColor(pnl).Render(padLeft(util.FormatSignedUSD(pnl), colPnl))
-				allTimeCell = style.PnlColor(allTimePnl).Render(padLeft(util.FormatSignedUSD(allTimePnl), colAllTime))
+	rows, all := m.investmentRows()
+	if len(all) == 0 {
+		empty := style.Dim.Render("  No vault investments")
+		if err := m.store.FetchError(store.SourceVaults); err != nil {
+			empty = ui.RenderLoadError("vault investments", err)
+		}
+		return empty + "\n\n" + m.footerKeys()
+	}
 
-				if d.FollowerState.VaultEquity != "" {
-					equity = util.ParseFloat(d.FollowerState.VaultEquity)
-				}
-			}
+	var b strings.Builder
+	visibleRows := m.height - 5
+	if m.investFilter.Shown() {
+		b.WriteString(m.investFilter.View(len(rows), len(all)))
+		b.WriteString("\n")
+		visibleRows--
+	}
+	b.WriteString(m.investTable.Header())
+	b.WriteString("\n")
 
-			if d.APR != 0 {
-				aprCell = style.PnlColor(d.APR).Render(padLeft(fmt.Sprintf("%.1f%%", d.APR*100), colAPR))
-			}
-		}
+	var totalEquity, totalWithdrawable decimal.Decimal
+	for _, r := range all {
+		totalEquity = totalEquity.Add(r.equity)
+		totalWithdrawable = totalWithdrawable.Add(r.withdrawable)
+	}
 
-		cells := []string{
-			style.White.Render(padRight(name, colName)),
-			"  ",
-			style.Green.Render(padLeft(util.FormatUSD(equity), colEquity)),
-			"  ",
-			pnlCell,
-			"  ",
-			allTimeCell,
-			"  ",
-			aprCell,
+	if len(rows) == 0 {
+		b.WriteString(style.Dim.Render("  No vaults match the filter"))
+		b.WriteString("\n")
+	}
+	cursor, start, end := table.Window(m.investCursor, len(rows), visibleRows)
+	for i := start; i < end; i++ {
+		if i == cursor {
+			b.WriteString(m.investTable.SelectedRow(rows[i]))
+		} else {
+			b.WriteString(m.investTable.Row(rows[i]))
 		}
-		b.WriteString(strings.Join(cells, ""))
 		b.WriteString("\n")
 	}
 
 	// Total
-	totalWidth := colName + colEquity + colPnl + colAllTime + colAPR + 8
 	b.WriteString("\n")
-	b.WriteString(style.Dim.Render(strings.Repeat("─", totalWidth)))
+	b.WriteString(style.Dim.Render(strings.Repeat("─", m.investTable.Width())))
 	b.WriteString("\n")
-	b.WriteString(fmt.Sprintf("  %s %s  %s",
+	b.WriteString(fmt.Sprintf("  %s %s  %s %s  %s\n",
 		style.White.Render("Total Vault Equity:"),
 		style.Green.Render(util.FormatUSD(totalEquity)),
-		style.Dim.Render(fmt.Sprintf("(%d vaults)", len(equities))),
+		style.White.Render("Est. Withdrawable:"),
+		style.Green.Render(util.FormatUSD(totalWithdrawable)),
+		style.Dim.Render(fmt.Sprintf("(%d vaults, sorted by %s)", len(all), m.investTable.SortLabel())),
 	))
+	b.WriteString(m.footerKeys())
 
 	return b.String()
 }
 
+// investmentRows returns the wallet's vault stakes the filter keeps, in the
+// table's order, and all of them in the store's.
+func (m Model) investmentRows() (rows, all []investmentRow) {
+	equities := m.store.VaultEquities()
+	details := m.store.VaultDetails()
+	now := util.Now().UnixMilli()
+
+	all = make([]investmentRow, len(equities))
+	for i, ve := range equities {
+		r := investmentRow{
+			VaultEquity: ve,
+			name:        truncAddr(ve.VaultAddress),
+			equity:      ve.Equity,
+			unlockAt:    ve.LockedUntilTimestamp,
+		}
+		d, ok := details[ve.VaultAddress]
+		if ok {
+			r.name = d.Name
+			r.apr = d.APR
+			if f := d.FollowerState; f != nil {
+				r.pnl, r.allTimePnl, r.hasPnl = f.Pnl, f.AllTimePnl, true
+				if !f.VaultEquity.IsZero() {
+					r.equity = f.VaultEquity
+				}
+				if r.unlockAt == 0 {
+					r.unlockAt = f.LockupUntil
+				}
+			}
+		}
+		r.withdrawable = estimateWithdrawable(r.equity, r.unlockAt, now, d)
+		all[i] = r
+	}
+	rows = m.investFilter.Apply(slices.Clone(all))
+	m.investTable.Sort(rows)
+	return rows, all
+}
+
+// estimateWithdrawable is what could be withdrawn from a vault right now:
+// nothing while locked, otherwise the stake capped by the vault's
+// MaxDistributable (what it can pay out without closing positions).
+func estimateWithdrawable(equity decimal.Decimal, unlockAt, now int64, d *api.VaultDetails) decimal.Decimal {
+	if unlockAt > now {
+		return decimal.Zero
+	}
+	if d != nil && d.MaxDistributable.Cmp(equity) < 0 {
+		return d.MaxDistributable
+	}
+	return equity
+}
+
+func (m Model) footerKeys() string {
+	alerts := "OFF"
+	if m.lockupAlerts {
+		alerts = "ON"
+	}
+	return style.Dim.Render("  [e] explore vaults  [u] unlock timeline  [</>] column  [s] sort  ") +
+		style.Yellow.Render(fmt.Sprintf("[a] lockup alerts: %s", alerts))
+}
+
 func truncName(name string, maxLen int) string {
 	if len(name) > maxLen {
 		return name[:maxLen-3] + "..."
@@ -115,10 +307,3 @@ func padRight(s string, width int) string {
 	}
 	return s + strings.Repeat(" ", width-len(s))
 }
-
-func padLeft(s string, width int) string {
-	if len(s) >= width {
-		return s
-	}
-	return strings.Repeat(" ", width-len(s)) + s
-}
@@ -0,0 +1,149 @@
+package vaults
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/table"
+	"github.com/born1337/hyperliquid-terminal/internal/viewtest"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		return m.View()
+	})
+}
+
+func TestExplorerView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m, _ = m.Update(viewtest.Key("e"))
+		return m.View()
+	})
+}
+
+func TestTimelineView(t *testing.T) {
+	s := viewtest.Store(t)
+	viewtest.Run(t, func(size viewtest.Size) string {
+		m := New(s)
+		m.SetHeight(size.ViewHeight())
+		m, _ = m.Update(viewtest.Key("u"))
+		return m.View()
+	})
+}
+
+func TestSortKeys(t *testing.T) {
+	s := viewtest.Store(t)
+	m := New(s)
+	m.SetHeight(40)
+
+	// Investments over to VAULT, A to Z
+	m, _ = m.Update(viewtest.Key("<"))
+	m, cmd := m.Update(viewtest.Key("s"))
+	if msg, ok := cmd().(table.SortChangedMsg); !ok || msg.Table != "vaults" {
+		t.Fatalf("s = %#v", cmd())
+	}
+	if rows, _ := m.investmentRows(); len(rows) != 2 || rows[0].name != "Demo Basis Trader" {
+		t.Errorf("investments = %+v", rows)
+	}
+	if view := m.View(); !strings.Contains(view, "sorted by VAULT ▲") {
+		t.Errorf("footer missing the order:\n%s", view)
+	}
+
+	// The timeline keeps its own order: by vault, skipping IN
+	m, _ = m.Update(viewtest.Key("u"))
+	m, _ = m.Update(viewtest.Key(">"))
+	m, _ = m.Update(viewtest.Key(">"))
+	m, cmd = m.Update(viewtest.Key("s"))
+	if msg, ok := cmd().(table.SortChangedMsg); !ok || msg.Table != "vault-unlocks" || msg.Keys[0].Column != "vault" {
+		t.Fatalf("s = %#v", cmd())
+	}
+	rows := m.unlockRows(s.VaultUnlocks(m.activeAddr))
+	for i := 1; i < len(rows); i++ {
+		if strings.ToLower(rows[i].vault) < strings.ToLower(rows[i-1].vault) {
+			t.Errorf("unlocks not by vault: %+v", rows)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	s := viewtest.Store(t)
+	m := New(s)
+	m.SetHeight(40)
+	for _, k := range []string{"/", "d", "e", "m", "o"} {
+		m, _ = m.Update(viewtest.Key(k))
+	}
+	if !m.Filtering() {
+		t.Fatal("investments filter bar not open")
+	}
+	if rows, all := m.investmentRows(); len(rows) != 1 || len(all) != 2 || rows[0].name != "Demo Basis Trader" {
+		t.Errorf("demo kept %+v", rows)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "1 of 2") || !strings.Contains(view, "$33,200.00") {
+		t.Errorf("filter bar or totals missing:\n%s", view)
+	}
+
+	// The timeline has a bar of its own
+	m, _ = m.Update(viewtest.Key("u"))
+	if m.Filtering() {
+		t.Fatal("timeline filter bar open")
+	}
+	for _, k := range []string{"/", "h", "y", "p", "e", "r"} {
+		m, _ = m.Update(viewtest.Key(k))
+	}
+	if !m.Filtering() {
+		t.Fatal("timeline filter bar not open")
+	}
+	if view := m.View(); !strings.Contains(view, "2 of 4") || strings.Contains(view, "Demo Basis") {
+		t.Errorf("timeline not filtered:\n%s", view)
+	}
+}
+
+func TestMissingDetails(t *testing.T) {
+	viewtest.Setup(t)
+	s := store.New()
+	var summaries []api.VaultSummary
+	for i, addr := range []string{"0xa", "0xb", "0xc", "0xd", "0xe"} {
+		summaries = append(summaries, api.VaultSummary{
+			Name:         "Vault " + addr,
+			VaultAddress: addr,
+			Tvl:          decimal.NewFromInt(int64(5-i) * 1_000_000),
+		})
+	}
+	s.SetVaultSummaries(summaries)
+	s.SetVaultDetails("0xb", &api.VaultDetails{APR: 0.1})
+
+	m := New(s)
+	m.SetHeight(5) // two rows of vaults
+	if got := m.MissingDetails(); got != nil {
+		t.Errorf("MissingDetails outside the explorer = %v", got)
+	}
+	for _, k := range []string{"e", ">", "s", "j", "j", "j"} {
+		m, _ = m.Update(viewtest.Key(k))
+	}
+	// Sorted by TVL with the cursor on 0xd and 0xc above it
+	if got := m.MissingDetails(); !slices.Equal(got, []string{"0xd", "0xc"}) {
+		t.Errorf("MissingDetails = %v, want the cursor row first", got)
+	}
+
+	// The cached rows follow the store
+	s.SetVaultDetails("0xd", &api.VaultDetails{APR: 0.2})
+	if got := m.MissingDetails(); !slices.Equal(got, []string{"0xc"}) {
+		t.Errorf("MissingDetails after 0xd loaded = %v", got)
+	}
+	m, _ = m.Update(viewtest.Key("g"))
+	if got := m.MissingDetails(); !slices.Equal(got, []string{"0xa"}) {
+		t.Errorf("MissingDetails at the top = %v", got)
+	}
+}
@@ -0,0 +1,244 @@
+// Package viewtest is the golden-file harness for rendering tests. It builds
+// a store from the fakehl demo fixture on a pinned clock, renders views at
+// several terminal sizes with color stripped, and compares the output with
+// testdata/<test name>.golden.
+//
+// Regenerate golden files after an intended layout change with:
+//
+//	go test ./internal/views/... ./internal/ui/... -update
+package viewtest
+
+import (
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/fakehl"
+	"github.com/born1337/hyperliquid-terminal/internal/store"
+	"github.com/born1337/hyperliquid-terminal/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Clock is the pinned "now" for rendering tests.
+var Clock = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+// Size is a terminal size.
+type Size struct {
+	Width  int
+	Height int
+}
+
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// ViewHeight is the height the app gives a view at this terminal size
+// (title, header, tabs, status bar and spacing take 12 lines).
+func (s Size) ViewHeight() int {
+	return s.Height - 12
+}
+
+// Sizes are the terminal sizes every view is rendered at.
+var Sizes = []Size{{80, 24}, {120, 40}, {200, 60}}
+
+// Setup pins util.Now to Clock and the local zone to UTC for the test.
+func Setup(t *testing.T) {
+	t.Helper()
+	prevNow, prevLocal := util.Now, time.Local
+	util.Now = func() time.Time { return Clock }
+	time.Local = time.UTC
+	t.Cleanup(func() {
+		util.Now, time.Local = prevNow, prevLocal
+	})
+}
+
+// Store returns a store loaded from the demo fixture the way the app loads
+// it: account state, staking, vault details and explorer data, predicted
+// fundings, and funding history, order book and candles for each position.
+// It calls Setup.
+func Store(t *testing.T) *store.Store {
+	t.Helper()
+	Setup(t)
+
+	f, err := fakehl.LoadFixture("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := fakehl.NewWithClock(f, fakehl.Scenario{}, 1, func() time.Time { return Clock })
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+	c := api.NewClient(ts.URL + "/info")
+	addr := srv.User()
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := t.Context()
+	s := store.New()
+	mids, err := c.GetAllMids(ctx)
+	must(err)
+	s.SetAllMids(mids)
+	meta, err := c.GetMetaAndAssetCtxs(ctx)
+	must(err)
+	s.SetMetaAndAssetCtxs(meta)
+	state, err := c.GetClearinghouseState(ctx, addr)
+	must(err)
+	s.SetClearinghouseState(state)
+	orders, err := c.GetOpenOrders(ctx, addr)
+	must(err)
+	s.SetOpenOrders(orders)
+	fills, err := c.GetUserFills(ctx, addr)
+	must(err)
+	s.SetFills(fills)
+	payments, err := c.GetUserFunding(ctx, addr, Clock.Add(-7*24*time.Hour).UnixMilli())
+	must(err)
+	s.SetFundingPayments(payments)
+	portfolio, err := c.GetPortfolio(ctx, addr)
+	must(err)
+	s.SetPortfolio(portfolio)
+	fees, err := c.GetUserFees(ctx, addr)
+	must(err)
+	s.SetUserFees(fees)
+	equities, err := c.GetUserVaultEquities(ctx, addr)
+	must(err)
+	s.SetVaultEquities(equities)
+	s.SetWalletVaultEquities(map[string][]api.VaultEquity{addr: equities})
+
+	summaries, err := c.GetVaultSummaries(ctx)
+	must(err)
+	s.SetVaultSummaries(summaries)
+	for _, v := range summaries {
+		d, err := c.GetVaultDetails(ctx, v.VaultAddress, addr)
+		must(err)
+		s.SetVaultDetails(v.VaultAddress, d)
+	}
+
+	var staking store.Staking
+	staking.Summary, err = c.GetDelegatorSummary(ctx, addr)
+	must(err)
+	staking.Delegations, err = c.GetDelegations(ctx, addr)
+	must(err)
+	staking.Rewards, err = c.GetDelegatorRewards(ctx, addr)
+	must(err)
+	staking.History, err = c.GetDelegatorHistory(ctx, addr)
+	must(err)
+	s.SetStaking(staking)
+	validators, err := c.GetValidatorSummaries(ctx)
+	must(err)
+	s.UpdateValidators(validators)
+
+	predicted, err := c.GetPredictedFundings(ctx)
+	must(err)
+	s.SetPredictedFundings(predicted)
+	for _, ap := range state.AssetPositions {
+		coin := ap.Position.Coin
+		history, err := c.GetFundingHistory(ctx, coin, Clock.Add(-7*24*time.Hour).UnixMilli())
+		must(err)
+		s.SetFundingHistory(coin, history)
+		book, err := c.GetL2Book(ctx, coin)
+		must(err)
+		s.SetL2Book(book)
+		candles, err := c.GetCandleSnapshot(ctx, coin, "1h", Clock.Add(-7*24*time.Hour).UnixMilli(), Clock.UnixMilli())
+		must(err)
+		s.SetCandles(coin, candles)
+	}
+
+	s.UpdateFundingRates()
+	return s
+}
+
+// Run renders at each of Sizes in a subtest and checks the output against
+// the subtest's golden file. render is given the size and returns the raw
+// view; Run crops it to the width.
+func Run(t *testing.T, render func(Size) string) {
+	t.Helper()
+	for _, size := range Sizes {
+		t.Run(size.String(), func(t *testing.T) {
+			Assert(t, Crop(render(size), size.Width))
+		})
+	}
+}
+
+// Key is a key press for driving a view's Update.
+func Key(k string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// Strip removes ANSI escape sequences.
+func Strip(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+// Crop strips color and clips every line to width cells, the way a
+// terminal would show it. Trailing spaces are dropped to keep diffs quiet.
+func Crop(s string, width int) string {
+	lines := strings.Split(Strip(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(runewidth.Truncate(line, width, ""), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Assert compares got with the test's golden file, or rewrites the file
+// when -update is set.
+func Assert(t *testing.T, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", filepath.FromSlash(t.Name())+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from golden file %s (run with -update if intended)\n%s", t.Name(), path, diff(string(want), got))
+	}
+}
+
+// diff shows the first differing lines.
+func diff(want, got string) string {
+	wl, gl := strings.Split(want, "\n"), strings.Split(got, "\n")
+	var b strings.Builder
+	shown := 0
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w == g {
+			continue
+		}
+		fmt.Fprintf(&b, "line %d:\n  want: %q\n  got:  %q\n", i+1, w, g)
+		if shown++; shown == 5 {
+			b.WriteString("  ...\n")
+			break
+		}
+	}
+	return b.String()
+}
@@ -2,6 +2,8 @@ package ws
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -9,49 +11,160 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+const (
+	// pingInterval is how often an application-level ping is sent. The
+	// server closes connections idle for a minute, and each pong resets the
+	// read deadline.
+	pingInterval = 20 * time.Second
+	// readTimeout is how long a connection may go without any message,
+	// pongs included, before it is treated as dead and redialled.
+	readTimeout = 45 * time.Second
+)
+
+// State is the connection state reported on the status channel.
+type State int
+
+const (
+	StateConnecting State = iota
+	StateConnected
+	StateReconnecting
+	StateClosed
+)
+
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "CONNECTING"
+	case StateConnected:
+		return "CONNECTED"
+	case StateReconnecting:
+		return "RECONNECTING"
+	case StateClosed:
+		return "DISCONNECTED"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Status is a connection state change.
+type Status struct {
+	State   State
+	Attempt int   // reconnect attempt, from 1; 0 otherwise
+	Err     error // why the connection dropped or the last attempt failed
+	Time    time.Time
+}
+
 type Client struct {
 	url           string
 	conn          *websocket.Conn
 	mu            sync.Mutex
-	msgCh         chan Message
+	feed          *Feed
+	statusCh      chan Status
 	done          chan struct{}
+	closed        bool
 	subscriptions []SubRequest
 	connected     bool
+	onMessage     func(Message)
+
+	// Last message time per channel, pongs included
+	last map[string]time.Time
+
+	pingInterval time.Duration
+	readTimeout  time.Duration
 }
 
-func NewClient(url string, msgCh chan Message) *Client {
+// NewClient creates a client that queues incoming messages on feed.
+func NewClient(url string, feed *Feed) *Client {
 	return &Client{
-		url:   url,
-		msgCh: msgCh,
-		done:  make(chan struct{}),
+		url:          url,
+		feed:         feed,
+		statusCh:     make(chan Status, 16),
+		done:         make(chan struct{}),
+		last:         make(map[string]time.Time),
+		pingInterval: pingInterval,
+		readTimeout:  readTimeout,
 	}
 }
 
+// OnMessage registers fn to observe every decoded message before it is
+// queued. Call it before Connect.
+func (c *Client) OnMessage(fn func(Message)) {
+	c.onMessage = fn
+}
+
+// Status returns the channel connection state changes are sent on. It is
+// closed by Close. If the reader falls behind, the oldest changes are
+// dropped rather than blocking the connection.
+func (c *Client) Status() <-chan Status {
+	return c.statusCh
+}
+
 func (c *Client) Connected() bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.connected
 }
 
+// LastMessage returns when a message last arrived on channel, or the zero
+// time if none has.
+func (c *Client) LastMessage(channel string) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last[channel]
+}
+
+// LastMessages returns the last message time of every channel seen.
+func (c *Client) LastMessages() map[string]time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]time.Time, len(c.last))
+	for ch, t := range c.last {
+		out[ch] = t
+	}
+	return out
+}
+
+// Start connects, and if that fails keeps retrying in the background with
+// backoff. The first attempt's error is returned; progress is reported on
+// the status channel either way.
+func (c *Client) Start() error {
+	c.emit(Status{State: StateConnecting})
+	err := c.Connect()
+	if err != nil {
+		go c.reconnect(err)
+	}
+	return err
+}
+
 func (c *Client) Connect() error {
 	conn, _, err := websocket.DefaultDialer.Dial(c.url, nil)
 	if err != nil {
 		return err
 	}
 	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		conn.Close()
+		return errors.New("ws: client closed")
+	}
 	c.conn = conn
 	c.connected = true
+	subs := append([]SubRequest(nil), c.subscriptions...)
 	c.mu.Unlock()
 
 	// Resubscribe
-	for _, sub := range c.subscriptions {
+	for _, sub := range subs {
 		c.send(sub)
 	}
 
-	go c.readLoop()
+	c.emit(Status{State: StateConnected})
+	stop := make(chan struct{})
+	go c.pingLoop(conn, stop)
+	go c.readLoop(conn, stop)
 	return nil
 }
 
+// Subscribe sends sub and remembers it for reconnects. Subscribing before
+// Connect just queues it.
 func (c *Client) Subscribe(sub SubRequest) {
 	c.mu.Lock()
 	// Deduplicate: check if this subscription already exists
@@ -85,20 +198,76 @@ func (c *Client) send(msg interface{}) {
 	c.conn.WriteMessage(websocket.TextMessage, data)
 }
 
-func (c *Client) readLoop() {
+// emit reports a state change without blocking; when the buffer is full
+// the oldest change is dropped so the latest state always gets through.
+func (c *Client) emit(s Status) {
+	if s.Time.IsZero() {
+		s.Time = time.Now()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	for {
+		select {
+		case c.statusCh <- s:
+			return
+		default:
+		}
+		select {
+		case <-c.statusCh:
+		default:
+		}
+	}
+}
+
+// pingLoop keeps the connection alive and gives the read deadline something
+// to see on quiet subscriptions.
+func (c *Client) pingLoop(conn *websocket.Conn, stop chan struct{}) {
+	t := time.NewTicker(c.pingInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-c.done:
+			return
+		case <-t.C:
+			c.mu.Lock()
+			err := conn.WriteMessage(websocket.TextMessage, []byte(`{"method":"ping"}`))
+			c.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) readLoop(conn *websocket.Conn, stop chan struct{}) {
+	var readErr error
 	defer func() {
+		close(stop)
 		c.mu.Lock()
 		c.connected = false
-		if c.conn != nil {
-			c.conn.Close()
+		conn.Close()
+		if c.conn == conn {
+			c.conn = nil
 		}
 		c.mu.Unlock()
-		c.reconnect()
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+		c.reconnect(readErr)
 	}()
 
 	for {
-		_, data, err := c.conn.ReadMessage()
+		conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+		_, data, err := conn.ReadMessage()
 		if err != nil {
+			readErr = err
 			return
 		}
 
@@ -106,28 +275,34 @@ func (c *Client) readLoop() {
 		if err := json.Unmarshal(data, &msg); err != nil {
 			continue
 		}
-
-		select {
-		case c.msgCh <- msg:
-		case <-c.done:
-			return
-		default:
-			log.Printf("ws: dropped message on channel %s (buffer full)", msg.Channel)
+		c.mu.Lock()
+		c.last[msg.Channel] = time.Now()
+		c.mu.Unlock()
+		if msg.Channel == "pong" {
+			continue
+		}
+		if c.onMessage != nil {
+			c.onMessage(msg)
 		}
+		c.feed.Push(msg)
 	}
 }
 
-func (c *Client) reconnect() {
+// reconnect redials with exponential backoff until it succeeds or the
+// client is closed, reporting each attempt.
+func (c *Client) reconnect(cause error) {
 	backoff := time.Second
 	maxBackoff := 30 * time.Second
 
-	for {
+	for attempt := 1; ; attempt++ {
+		c.emit(Status{State: StateReconnecting, Attempt: attempt, Err: cause})
 		select {
 		case <-c.done:
 			return
 		case <-time.After(backoff):
 			if err := c.Connect(); err != nil {
 				log.Printf("ws reconnect failed: %v", err)
+				cause = err
 				backoff *= 2
 				if backoff > maxBackoff {
 					backoff = maxBackoff
@@ -139,11 +314,19 @@ func (c *Client) reconnect() {
 	}
 }
 
+// Close stops the client for good: the connection is closed, reconnects
+// stop, and the status channel is closed.
 func (c *Client) Close() {
-	close(c.done)
 	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.done)
 	if c.conn != nil {
 		c.conn.Close()
 	}
-	c.mu.Unlock()
+	c.connected = false
+	close(c.statusCh)
 }
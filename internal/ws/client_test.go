@@ -0,0 +1,177 @@
+package ws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testServer accepts connections and hands each to serve. It reports the
+// number of connections made.
+func testServer(t *testing.T, serve func(*websocket.Conn)) (string, *atomic.Int32) {
+	t.Helper()
+	var conns atomic.Int32
+	var up websocket.Upgrader
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := up.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns.Add(1)
+		defer conn.Close()
+		serve(conn)
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http"), &conns
+}
+
+// echoPong answers pings and sends one allMids update per subscription.
+func echoPong(conn *websocket.Conn) {
+	for {
+		var req struct {
+			Method string `json:"method"`
+		}
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		switch req.Method {
+		case "ping":
+			conn.WriteJSON(map[string]string{"channel": "pong"})
+		case "subscribe":
+			conn.WriteJSON(map[string]any{"channel": "allMids", "data": map[string]any{"mids": map[string]string{"BTC": "1"}}})
+		}
+	}
+}
+
+func newTestClient(url string) (*Client, *Feed) {
+	feed := NewFeed()
+	c := NewClient(url, feed)
+	c.pingInterval = 20 * time.Millisecond
+	c.readTimeout = 150 * time.Millisecond
+	return c, feed
+}
+
+func nextStatus(t *testing.T, c *Client) Status {
+	t.Helper()
+	select {
+	case s := <-c.Status():
+		return s
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for a status change")
+	}
+	return Status{}
+}
+
+func TestSubscribeBeforeConnect(t *testing.T) {
+	url, _ := testServer(t, echoPong)
+	c, feed := newTestClient(url)
+	defer c.Close()
+
+	c.Subscribe(SubAllMids())
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if s := nextStatus(t, c); s.State != StateConnecting {
+		t.Errorf("first status = %v, want CONNECTING", s.State)
+	}
+	if s := nextStatus(t, c); s.State != StateConnected {
+		t.Errorf("second status = %v, want CONNECTED", s.State)
+	}
+
+	got := make(chan []Message, 1)
+	go func() {
+		msgs, _ := feed.Next()
+		got <- msgs
+	}()
+	select {
+	case msgs := <-got:
+		var data AllMidsData
+		if len(msgs) != 1 || msgs[0].Channel != "allMids" || json.Unmarshal(msgs[0].Data, &data) != nil || data.Mids["BTC"].String() != "1" {
+			t.Errorf("msgs = %+v", msgs)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("queued subscription was not sent on connect")
+	}
+	if c.LastMessage("allMids").IsZero() {
+		t.Error("LastMessage(allMids) not recorded")
+	}
+}
+
+func TestHeartbeatKeepsConnectionAlive(t *testing.T) {
+	url, conns := testServer(t, echoPong)
+	c, feed := newTestClient(url)
+	defer c.Close()
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Several read timeouts pass with only pongs arriving
+	time.Sleep(500 * time.Millisecond)
+	if !c.Connected() || conns.Load() != 1 {
+		t.Errorf("connected = %v after %d connections; pongs should keep it up", c.Connected(), conns.Load())
+	}
+	if c.LastMessage("pong").IsZero() {
+		t.Error("no pong recorded")
+	}
+	if s := feed.Stats(); len(s) != 0 {
+		t.Errorf("pongs leaked to the feed: %+v", s)
+	}
+}
+
+func TestSilentConnectionReconnects(t *testing.T) {
+	// A half-open connection: the server reads but never answers
+	url, conns := testServer(t, func(conn *websocket.Conn) {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	c, _ := newTestClient(url)
+	defer c.Close()
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if s := nextStatus(t, c); s.State != StateConnected {
+		t.Fatalf("status = %v, want CONNECTED", s.State)
+	}
+
+	s := nextStatus(t, c)
+	if s.State != StateReconnecting || s.Attempt != 1 || s.Err == nil {
+		t.Fatalf("status = %+v, want first RECONNECTING with the read error", s)
+	}
+	if s := nextStatus(t, c); s.State != StateConnected {
+		t.Fatalf("status = %v, want CONNECTED again", s.State)
+	}
+	if conns.Load() != 2 {
+		t.Errorf("connections = %d, want 2", conns.Load())
+	}
+}
+
+func TestCloseStopsReconnecting(t *testing.T) {
+	url, _ := testServer(t, func(conn *websocket.Conn) {})
+	c, _ := newTestClient(url)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	c.Close()
+
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case _, ok := <-c.Status():
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("status channel not closed after Close")
+		}
+	}
+}
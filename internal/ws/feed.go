@@ -0,0 +1,191 @@
+package ws
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// coalesced channels carry full snapshots, so only the latest pending
+// message matters and older ones are superseded rather than queued.
+var coalesced = map[string]bool{
+	"allMids":  true,
+	"webData2": true,
+}
+
+// maxPending caps the lossless queue so a stalled reader can't grow memory
+// without bound. Messages past it are dropped and counted.
+const maxPending = 100_000
+
+// ChannelStats counts what happened to one channel's messages.
+type ChannelStats struct {
+	Channel   string
+	Received  int64
+	Delivered int64
+	Coalesced int64 // superseded by a newer snapshot before delivery
+	Dropped   int64 // lost to a full queue
+	Pending   int
+	Lag       time.Duration // how long the last delivered message waited
+	MaxLag    time.Duration
+}
+
+type queued struct {
+	msg Message
+	at  time.Time
+}
+
+// Feed sits between the connection and the app. Push never blocks the
+// read loop; Next hands the reader everything pending in one batch.
+// Coalesced channels keep only their latest message, every other channel
+// is delivered in arrival order without loss.
+type Feed struct {
+	mu     sync.Mutex
+	queue  []queued
+	latest map[string]queued
+	stats  map[string]*ChannelStats
+	ready  chan struct{}
+	closed bool
+}
+
+func NewFeed() *Feed {
+	return &Feed{
+		latest: make(map[string]queued),
+		stats:  make(map[string]*ChannelStats),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+func (f *Feed) stat(channel string) *ChannelStats {
+	s, ok := f.stats[channel]
+	if !ok {
+		s = &ChannelStats{Channel: channel}
+		f.stats[channel] = s
+	}
+	return s
+}
+
+// Push queues msg for the reader.
+func (f *Feed) Push(msg Message) {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return
+	}
+	s := f.stat(msg.Channel)
+	s.Received++
+	q := queued{msg: msg, at: time.Now()}
+	switch {
+	case coalesced[msg.Channel]:
+		if _, ok := f.latest[msg.Channel]; ok {
+			s.Coalesced++
+		} else {
+			s.Pending++
+		}
+		f.latest[msg.Channel] = q
+	case len(f.queue) >= maxPending:
+		s.Dropped++
+	default:
+		f.queue = append(f.queue, q)
+		s.Pending++
+	}
+	f.mu.Unlock()
+
+	select {
+	case f.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Next blocks until messages are pending and returns all of them: queued
+// messages in arrival order, then the latest of each coalesced channel.
+// It returns false once the feed is closed.
+func (f *Feed) Next() ([]Message, bool) {
+	for {
+		f.mu.Lock()
+		if f.closed {
+			f.mu.Unlock()
+			return nil, false
+		}
+		if len(f.queue) > 0 || len(f.latest) > 0 {
+			msgs := f.drain(time.Now())
+			f.mu.Unlock()
+			return msgs, true
+		}
+		f.mu.Unlock()
+		<-f.ready
+	}
+}
+
+func (f *Feed) drain(now time.Time) []Message {
+	msgs := make([]Message, 0, len(f.queue)+len(f.latest))
+	deliver := func(q queued) {
+		s := f.stat(q.msg.Channel)
+		s.Delivered++
+		s.Pending--
+		s.Lag = now.Sub(q.at)
+		if s.Lag > s.MaxLag {
+			s.MaxLag = s.Lag
+		}
+		msgs = append(msgs, q.msg)
+	}
+	for _, q := range f.queue {
+		deliver(q)
+	}
+	f.queue = nil
+
+	channels := make([]string, 0, len(f.latest))
+	for ch := range f.latest {
+		channels = append(channels, ch)
+	}
+	sort.Strings(channels)
+	for _, ch := range channels {
+		deliver(f.latest[ch])
+		delete(f.latest, ch)
+	}
+	return msgs
+}
+
+// Close discards anything pending and wakes a blocked Next.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	f.closed = true
+	f.queue = nil
+	f.latest = nil
+	f.mu.Unlock()
+	select {
+	case f.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Stats returns per-channel counters, by channel name.
+func (f *Feed) Stats() []ChannelStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]ChannelStats, 0, len(f.stats))
+	for _, s := range f.stats {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Channel < out[j].Channel })
+	return out
+}
+
+// Totals sums Stats across channels. Lag is the worst last-delivery lag of
+// any channel.
+func (f *Feed) Totals() ChannelStats {
+	var t ChannelStats
+	for _, s := range f.Stats() {
+		t.Received += s.Received
+		t.Delivered += s.Delivered
+		t.Coalesced += s.Coalesced
+		t.Dropped += s.Dropped
+		t.Pending += s.Pending
+		if s.Lag > t.Lag {
+			t.Lag = s.Lag
+		}
+		if s.MaxLag > t.MaxLag {
+			t.MaxLag = s.MaxLag
+		}
+	}
+	return t
+}
@@ -0,0 +1,119 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func msg(channel, data string) Message {
+	return Message{Channel: channel, Data: json.RawMessage(data)}
+}
+
+func TestFeedCoalescesMidsAndKeepsFills(t *testing.T) {
+	f := NewFeed()
+	for i := 0; i < 1000; i++ {
+		f.Push(msg("allMids", fmt.Sprintf(`{"mids":{"BTC":"%d"}}`, i)))
+		if i%100 == 0 {
+			f.Push(msg("userFills", fmt.Sprintf(`{"fills":[{"tid":%d}]}`, i)))
+		}
+		if i == 500 {
+			f.Push(msg("orderUpdates", `[]`))
+		}
+	}
+
+	msgs, ok := f.Next()
+	if !ok {
+		t.Fatal("Next returned closed")
+	}
+	var channels []string
+	for _, m := range msgs {
+		channels = append(channels, m.Channel)
+	}
+	want := []string{"userFills", "userFills", "userFills", "userFills", "userFills", "userFills",
+		"orderUpdates", "userFills", "userFills", "userFills", "userFills", "allMids"}
+	if fmt.Sprint(channels) != fmt.Sprint(want) {
+		t.Fatalf("channels = %v\nwant %v", channels, want)
+	}
+	if string(msgs[len(msgs)-1].Data) != `{"mids":{"BTC":"999"}}` {
+		t.Errorf("coalesced allMids = %s, want the latest", msgs[len(msgs)-1].Data)
+	}
+	if string(msgs[7].Data) != `{"fills":[{"tid":600}]}` {
+		t.Errorf("fills out of order: %s", msgs[7].Data)
+	}
+
+	stats := map[string]ChannelStats{}
+	for _, s := range f.Stats() {
+		stats[s.Channel] = s
+	}
+	mids := stats["allMids"]
+	if mids.Received != 1000 || mids.Delivered != 1 || mids.Coalesced != 999 || mids.Pending != 0 {
+		t.Errorf("allMids stats = %+v", mids)
+	}
+	fills := stats["userFills"]
+	if fills.Received != 10 || fills.Delivered != 10 || fills.Coalesced != 0 || fills.Dropped != 0 {
+		t.Errorf("userFills stats = %+v", fills)
+	}
+	if tot := f.Totals(); tot.Received != 1011 || tot.Delivered != 12 || tot.Coalesced != 999 {
+		t.Errorf("totals = %+v", tot)
+	}
+}
+
+func TestFeedDropsPastCap(t *testing.T) {
+	f := NewFeed()
+	for i := 0; i < maxPending+5; i++ {
+		f.Push(msg("orderUpdates", `[]`))
+	}
+	s := f.Totals()
+	if s.Pending != maxPending || s.Dropped != 5 {
+		t.Errorf("pending %d dropped %d, want %d and 5", s.Pending, s.Dropped, maxPending)
+	}
+	msgs, _ := f.Next()
+	if len(msgs) != maxPending {
+		t.Errorf("delivered %d", len(msgs))
+	}
+}
+
+func TestFeedNextBlocksUntilPush(t *testing.T) {
+	f := NewFeed()
+	got := make(chan []Message, 1)
+	go func() {
+		msgs, _ := f.Next()
+		got <- msgs
+	}()
+	select {
+	case <-got:
+		t.Fatal("Next returned with nothing pending")
+	case <-time.After(50 * time.Millisecond):
+	}
+	f.Push(msg("userFundings", `{}`))
+	select {
+	case msgs := <-got:
+		if len(msgs) != 1 {
+			t.Errorf("msgs = %+v", msgs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Next did not wake on Push")
+	}
+}
+
+func TestFeedCloseWakesNext(t *testing.T) {
+	f := NewFeed()
+	done := make(chan bool, 1)
+	go func() {
+		_, ok := f.Next()
+		done <- ok
+	}()
+	time.Sleep(20 * time.Millisecond)
+	f.Close()
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("Next returned ok after Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not wake Next")
+	}
+	f.Push(msg("allMids", `{}`)) // no panic after Close
+}
@@ -1,6 +1,11 @@
 package ws
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/born1337/hyperliquid-terminal/internal/api"
+	"github.com/born1337/hyperliquid-terminal/internal/decimal"
+)
 
 // Subscription request
 type SubRequest struct {
@@ -16,26 +21,26 @@ type Message struct {
 
 // allMids channel data
 type AllMidsData struct {
-	Mids map[string]string `json:"mids"`
+	Mids api.AllMids `json:"mids"`
 }
 
 // orderUpdates channel data
 type OrderUpdate struct {
-	Order  OrderInfo `json:"order"`
-	Status string    `json:"status"`
-	StatusTimestamp int64 `json:"statusTimestamp"`
+	Order           OrderInfo `json:"order"`
+	Status          string    `json:"status"`
+	StatusTimestamp int64     `json:"statusTimestamp"`
 }
 
 type OrderInfo struct {
-	Coin       string `json:"coin"`
-	Side       string `json:"side"`
-	LimitPx    string `json:"limitPx"`
-	Sz         string `json:"sz"`
-	Oid        int64  `json:"oid"`
-	Timestamp  int64  `json:"timestamp"`
-	OrigSz     string `json:"origSz"`
-	OrderType  string `json:"orderType"`
-	ReduceOnly bool   `json:"reduceOnly"`
+	Coin       string          `json:"coin"`
+	Side       string          `json:"side"`
+	LimitPx    decimal.Decimal `json:"limitPx"`
+	Sz         decimal.Decimal `json:"sz"`
+	Oid        int64           `json:"oid"`
+	Timestamp  int64           `json:"timestamp"`
+	OrigSz     decimal.Decimal `json:"origSz"`
+	OrderType  string          `json:"orderType"`
+	ReduceOnly bool            `json:"reduceOnly"`
 }
 
 // user channel data: fills, funding, etc. vary by subscription type
@@ -45,22 +50,50 @@ type UserEvent struct {
 }
 
 type UserFillWs struct {
-	Coin      string `json:"coin"`
-	Px        string `json:"px"`
-	Sz        string `json:"sz"`
-	Side      string `json:"side"`
-	Time      int64  `json:"time"`
-	ClosedPnl string `json:"closedPnl"`
-	Hash      string `json:"hash"`
-	Fee       string `json:"fee"`
-	Tid       int64  `json:"tid"`
-	Dir       string `json:"dir"`
+	Coin      string          `json:"coin"`
+	Px        decimal.Decimal `json:"px"`
+	Sz        decimal.Decimal `json:"sz"`
+	Side      string          `json:"side"`
+	Time      int64           `json:"time"`
+	ClosedPnl decimal.Decimal `json:"closedPnl"`
+	Hash      string          `json:"hash"`
+	Fee       decimal.Decimal `json:"fee"`
+	Tid       int64           `json:"tid"`
+	Oid       int64           `json:"oid"`
+	Dir       string          `json:"dir"`
 }
 
 type UserFundingWs struct {
-	Time        int64  `json:"time"`
-	Coin        string `json:"coin"`
-	Usdc        string `json:"usdc"`
-	Szi         string `json:"szi"`
-	FundingRate string `json:"fundingRate"`
+	Time        int64           `json:"time"`
+	Coin        string          `json:"coin"`
+	Usdc        decimal.Decimal `json:"usdc"`
+	Szi         decimal.Decimal `json:"szi"`
+	FundingRate decimal.Decimal `json:"fundingRate"`
+}
+
+// userFills channel data. The first message after subscribing is a
+// snapshot of recent fills; later ones carry only new fills.
+type UserFills struct {
+	IsSnapshot bool         `json:"isSnapshot"`
+	User       string       `json:"user"`
+	Fills      []UserFillWs `json:"fills"`
+}
+
+// userFundings channel data, snapshot first like userFills
+type UserFundings struct {
+	IsSnapshot bool            `json:"isSnapshot"`
+	User       string          `json:"user"`
+	Fundings   []UserFundingWs `json:"fundings"`
+}
+
+// webData2 channel data: a full snapshot of the account's clearinghouse
+// state and open orders, with the perp universe and asset contexts, pushed
+// whenever any of it changes.
+type WebData2 struct {
+	User               string                  `json:"user"`
+	ClearinghouseState *api.ClearinghouseState `json:"clearinghouseState"`
+	OpenOrders         []api.OpenOrder         `json:"openOrders"`
+	Meta               *api.Meta               `json:"meta"`
+	AssetCtxs          []api.AssetCtx          `json:"assetCtxs"`
+	ServerTime         int64                   `json:"serverTime"`
 }
@@ -11,7 +11,7 @@ func TestAllMidsDataUnmarshal(t *testing.T) {
 	if err := json.Unmarshal([]byte(raw), &data); err != nil {
 		t.Fatalf("Unmarshal error: %v", err)
 	}
-	if data.Mids["BTC"] != "91000.50" {
+	if data.Mids["BTC"].String() != "91000.50" {
 		t.Errorf("BTC = %q, want 91000.50", data.Mids["BTC"])
 	}
 	if len(data.Mids) != 2 {
@@ -74,7 +74,7 @@ func TestUserEventFillsUnmarshal(t *testing.T) {
 	if event.Fills[0].Coin != "ETH" {
 		t.Errorf("Fills[0].Coin = %q, want ETH", event.Fills[0].Coin)
 	}
-	if event.Fills[0].ClosedPnl != "50.00" {
+	if event.Fills[0].ClosedPnl.String() != "50.00" {
 		t.Errorf("Fills[0].ClosedPnl = %q, want 50.00", event.Fills[0].ClosedPnl)
 	}
 }
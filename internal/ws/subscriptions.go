@@ -38,3 +38,13 @@ func SubOrderUpdates(user string) SubRequest {
 		},
 	}
 }
+
+func SubWebData2(user string) SubRequest {
+	return SubRequest{
+		Method: "subscribe",
+		Subscription: map[string]string{
+			"type": "webData2",
+			"user": user,
+		},
+	}
+}